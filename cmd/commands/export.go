@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"fmt"
+	"github.com/rigochain/rigo-go/node"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportHeight int64
+	exportOutput string
+)
+
+func init() {
+	ExportCmd.Flags().Int64Var(&exportHeight, "height", 0, "the height of the state to export (0 means the last height)")
+	ExportCmd.Flags().StringVar(&exportOutput, "output", "exported_genesis.json", "the file path to write the genesis document to")
+}
+
+// ExportCmd writes the state of the given height as a genesis document.
+var ExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the application state of the given height as a genesis document",
+	Long: `
+The exported genesis document has all accounts, delegatees with their stakes, frozen stakes, rewards,
+proposals, the governance parameters and the code and storage of all contracts at '--height'.
+It is used to restart a network from the exported state or to fork a network for testing.
+All heights in the exported state are rebased, so that the new network starts right after '--height'.
+The node should be stopped before exporting.
+`,
+	RunE: export,
+}
+
+func export(cmd *cobra.Command, args []string) error {
+	genDoc, err := node.ExportGenesis(rootConfig, exportHeight, logger)
+	if err != nil {
+		return fmt.Errorf("failed to export state: %w", err)
+	}
+
+	if err := genDoc.SaveAs(exportOutput); err != nil {
+		return fmt.Errorf("failed to write genesis document: %w", err)
+	}
+
+	fmt.Printf("Exported state to %s\n", exportOutput)
+	return nil
+}
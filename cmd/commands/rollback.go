@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"fmt"
+	"github.com/rigochain/rigo-go/node"
+	"github.com/spf13/cobra"
+)
+
+var rollbackHeights int64
+
+func init() {
+	RollbackCmd.Flags().Int64Var(&rollbackHeights, "heights", 1, "the number of heights to roll back")
+}
+
+// RollbackCmd rewinds the application state and the tendermint state.
+var RollbackCmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "Rollback the application state and the tendermint state by the given number of heights",
+	Long: `
+A rollback is performed to recover from an incorrect application state transition,
+for example after a bad upgrade or an app hash mismatch.
+It rewinds all ledgers of the application and the tendermint state by the number of heights given by '--heights'.
+The blocks higher than the rolled back height + 1 are removed from the block store.
+When the node is restarted, the block of the rolled back height + 1 is re-executed
+and the next blocks are fetched from the peers.
+`,
+	RunE: rollback,
+}
+
+func rollback(cmd *cobra.Command, args []string) error {
+	height, hash, err := node.RollbackState(rootConfig, rollbackHeights, logger)
+	if err != nil {
+		return fmt.Errorf("failed to rollback state: %w", err)
+	}
+
+	fmt.Printf("Rolled back state to height %d and hash %X\n", height, hash)
+	return nil
+}
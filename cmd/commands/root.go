@@ -41,7 +41,15 @@ func ParseConfig() (*cfg.Config, error) {
 	if err := conf.ValidateBasic(); err != nil {
 		return nil, fmt.Errorf("error in rootConfig file: %v", err)
 	}
-	return &cfg.Config{conf, ""}, nil
+
+	appConf := cfg.DefaultAppConfig()
+	if err := viper.UnmarshalKey("app", appConf); err != nil {
+		return nil, err
+	}
+	if err := appConf.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("error in rootConfig file: %v", err)
+	}
+	return &cfg.Config{conf, "", appConf}, nil
 }
 
 // RootCmd is the root command for Tendermint core.
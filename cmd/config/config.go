@@ -1,14 +1,113 @@
 package config
 
-import tmcfg "github.com/tendermint/tendermint/config"
+import (
+	"errors"
+	"fmt"
+	tmcfg "github.com/tendermint/tendermint/config"
+	"path/filepath"
+)
 
 type Config struct {
 	*tmcfg.Config
 	ChainID string
+	App     *AppConfig
 }
 
 func DefaultConfig() *Config {
 	return &Config{
 		Config: tmcfg.DefaultConfig(),
+		App:    DefaultAppConfig(),
 	}
 }
+
+// AppConfig is the configuration of rigo application.
+// It is read from the `[app]` section of config.toml.
+type AppConfig struct {
+	// SnapshotInterval is the block interval at which a state-sync snapshot is taken.
+	// It should be a multiple of RewardHashInterval. 0 means that no snapshot is taken.
+	SnapshotInterval int64 `mapstructure:"snapshot_interval"`
+	// SnapshotKeepRecent is the number of recent snapshots to keep.
+	// 0 means that all snapshots are kept.
+	SnapshotKeepRecent int `mapstructure:"snapshot_keep_recent"`
+	// ParallelDeliverTx enables the parallel execution of the txs in a block.
+	// The txs which don't conflict with each other are executed in parallel,
+	// and the result is the same as the serial execution.
+	ParallelDeliverTx bool `mapstructure:"parallel_deliver_tx"`
+	// Pruning is the strategy to prune the old versions of the ledgers and the EVM state.
+	//   - "archive": all versions are kept.
+	//   - "keep-recent": the recent `pruning_keep_recent` versions are kept.
+	//   - "keep-every": every `pruning_keep_every`-th version is kept in addition to the recent versions.
+	// The pruned versions can not be queried.
+	Pruning           string `mapstructure:"pruning"`
+	PruningKeepRecent int64  `mapstructure:"pruning_keep_recent"`
+	PruningKeepEvery  int64  `mapstructure:"pruning_keep_every"`
+	// PruningInterval is the block interval at which the pruned versions are deleted.
+	PruningInterval int64 `mapstructure:"pruning_interval"`
+	// ReplaceTxBumpRatio is the ratio(%) by which the gas price of a tx should be higher than the tx in the mempool
+	// to replace it. The tx in the mempool is replaced by the tx of the same sender and nonce.
+	ReplaceTxBumpRatio int64 `mapstructure:"replace_tx_bump_ratio"`
+}
+
+const (
+	PruningArchive    = "archive"
+	PruningKeepRecent = "keep-recent"
+	PruningKeepEvery  = "keep-every"
+
+	// RewardHashInterval is the block interval at which the root hash of the rewards is included in the app hash.
+	// A snapshot can be verified against the app hash only at these heights.
+	RewardHashInterval = 10
+
+	// MinPruningKeepRecent is the minimum of `pruning_keep_recent`.
+	// The recent versions are needed to calculate the rewards,
+	// to take the snapshots and to roll back the state.
+	MinPruningKeepRecent = 100
+)
+
+func DefaultAppConfig() *AppConfig {
+	return &AppConfig{
+		SnapshotInterval:   0,
+		SnapshotKeepRecent: 2,
+		ParallelDeliverTx:  false,
+		Pruning:            PruningArchive,
+		PruningKeepRecent:  362880,
+		PruningKeepEvery:   0,
+		PruningInterval:    10,
+		ReplaceTxBumpRatio: 10,
+	}
+}
+
+func (cfg *AppConfig) ValidateBasic() error {
+	if cfg.SnapshotInterval < 0 {
+		return errors.New("snapshot_interval can't be negative")
+	}
+	if cfg.SnapshotInterval%RewardHashInterval != 0 {
+		return fmt.Errorf("snapshot_interval must be a multiple of %v", RewardHashInterval)
+	}
+	if cfg.SnapshotKeepRecent < 0 {
+		return errors.New("snapshot_keep_recent can't be negative")
+	}
+	if cfg.ReplaceTxBumpRatio < 0 {
+		return errors.New("replace_tx_bump_ratio can't be negative")
+	}
+	switch cfg.Pruning {
+	case PruningArchive:
+	case PruningKeepRecent, PruningKeepEvery:
+		if cfg.PruningKeepRecent < MinPruningKeepRecent {
+			return fmt.Errorf("pruning_keep_recent can't be less than %v", MinPruningKeepRecent)
+		}
+		if cfg.Pruning == PruningKeepEvery && cfg.PruningKeepEvery <= 0 {
+			return errors.New("pruning_keep_every must be positive")
+		}
+		if cfg.PruningInterval <= 0 {
+			return errors.New("pruning_interval must be positive")
+		}
+	default:
+		return fmt.Errorf("unknown pruning strategy: %v", cfg.Pruning)
+	}
+	return nil
+}
+
+// SnapshotDir returns the directory where state-sync snapshots are stored.
+func (cfg *Config) SnapshotDir() string {
+	return filepath.Join(cfg.DBDir(), "snapshots")
+}
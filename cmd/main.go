@@ -13,6 +13,8 @@ func main() {
 		commands.NewInitFilesCmd(),
 		commands.ResetPrivValidatorCmd,
 		commands.ResetAllCmd,
+		commands.RollbackCmd,
+		commands.ExportCmd,
 		commands.NewRunNodeCmd(node.NewRigoNode),
 		commands.ShowNodeIDCmd,
 		commands.NewWalletKeyCmd(),
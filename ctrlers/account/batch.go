@@ -0,0 +1,101 @@
+package account
+
+import (
+	"bytes"
+	"github.com/holiman/uint256"
+	atypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/types"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+)
+
+func (ctrler *AcctCtrler) validateBatch(ctx *atypes.TrxContext) xerrors.XError {
+	payload, ok := ctx.Tx.Payload.(*atypes.TrxPayloadBatch)
+	if !ok {
+		return xerrors.ErrInvalidTrxPayloadType
+	}
+	if bytes.Compare(ctx.Tx.To, types.ZeroAddress()) != 0 {
+		return xerrors.ErrInvalidTrxPayloadParams.Wrapf("the 'to' field of TRX_BATCH should be zero address")
+	}
+
+	n := uint64(len(payload.Recipients))
+	if n == 0 {
+		return xerrors.ErrInvalidTrxPayloadParams.Wrapf("no recipient")
+	}
+	// the number of the recipients is limited by `MaxTrxGas`,
+	// and the fee of `MinTrxGas` is charged for each recipient.
+	minGas := ctx.GovHandler.MinTrxGas()
+	if n > ctx.GovHandler.MaxTrxGas()/minGas {
+		return xerrors.ErrInvalidTrxPayloadParams.Wrapf("too many recipients: it should be less than or equal to %v", ctx.GovHandler.MaxTrxGas()/minGas)
+	}
+	if ctx.Tx.Gas < minGas*n {
+		return xerrors.ErrInvalidGas.Wrapf("too small gas: %v recipients need %v gas at least", n, minGas*n)
+	}
+
+	sum := uint256.NewInt(0)
+	for _, r := range payload.Recipients {
+		if len(r.To) != types.AddrSize || bytes.Compare(r.To, types.ZeroAddress()) == 0 {
+			return xerrors.ErrInvalidAddress.Wrapf("wrong recipient: %v", r.To)
+		}
+		if bytes.Compare(r.To, ctx.Tx.From) == 0 {
+			return xerrors.ErrInvalidTrxPayloadParams.Wrapf("the sender can not be a recipient")
+		}
+		if r.Amount == nil || r.Amount.Sign() == 0 {
+			return xerrors.ErrInvalidAmount.Wrapf("zero amount to %v", r.To)
+		}
+		if _, overflow := sum.AddOverflow(sum, r.Amount); overflow {
+			return xerrors.ErrOverFlow
+		}
+	}
+	if sum.Cmp(ctx.Tx.Amount) != 0 {
+		return xerrors.ErrInvalidAmount.Wrapf("the amount of the tx(%v) is not the sum of the amounts to the recipients(%v)", ctx.Tx.Amount.Dec(), sum.Dec())
+	}
+	return nil
+}
+
+// execBatch transfers the amounts to the recipients.
+// If any of them fails, nothing is transferred.
+func (ctrler *AcctCtrler) execBatch(ctx *atypes.TrxContext) xerrors.XError {
+	payload, _ := ctx.Tx.Payload.(*atypes.TrxPayloadBatch)
+
+	// all the recipients are found first,
+	// so that the contracts are rejected before any balance is changed.
+	recipients := make([]*atypes.Account, len(payload.Recipients))
+	for i, r := range payload.Recipients {
+		acct := ctx.AcctHandler.FindOrNewAccount(r.To, ctx.Exec)
+		if acct == nil {
+			return xerrors.ErrNotFoundAccount.Wrapf("address: %v", r.To)
+		}
+		if acct.GetCode() != nil {
+			return xerrors.ErrInvalidTrxPayloadParams.Wrapf("the recipient(%v) is a contract", r.To)
+		}
+		recipients[i] = acct
+	}
+
+	if xerr := ctx.Sender.SubBalance(ctx.Tx.Amount); xerr != nil {
+		return xerr
+	}
+	for i, acct := range recipients {
+		if xerr := acct.AddBalance(payload.Recipients[i].Amount); xerr != nil {
+			for j, acct0 := range recipients[:i] {
+				_ = acct0.SubBalance(payload.Recipients[j].Amount)
+			}
+			_ = ctx.Sender.AddBalance(ctx.Tx.Amount)
+			return xerr
+		}
+	}
+
+	for i, acct := range recipients {
+		_ = ctx.AcctHandler.SetAccountCommittable(acct, ctx.Exec)
+
+		ctx.Events = append(ctx.Events, abcitypes.Event{
+			Type: atypes.EVENT_TYPE_BATCH_TRANSFER,
+			Attributes: []abcitypes.EventAttribute{
+				{Key: []byte(atypes.EVENT_ATTR_TXSENDER), Value: []byte(ctx.Tx.From.String()), Index: true},
+				{Key: []byte(atypes.EVENT_ATTR_TXRECVER), Value: []byte(acct.Address.String()), Index: true},
+				{Key: []byte(atypes.EVENT_ATTR_AMOUNT), Value: []byte(payload.Recipients[i].Amount.Dec()), Index: false},
+			},
+		})
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package account
+
+import (
+	"github.com/holiman/uint256"
+	atypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/ledger"
+	abytes "github.com/rigochain/rigo-go/types/bytes"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+)
+
+var blockFeeKey = ledger.ToLedgerKey(abytes.ZeroBytes(32))
+
+// BlockFee is the base fee and the gas used of a block, from which the base fee of the next block is computed.
+// It is kept in `feeLedger` so that it is rolled back and restored from a snapshot with the accounts.
+type BlockFee struct {
+	Height  int64        `json:"height,string"`
+	BaseFee *uint256.Int `json:"baseFee,omitempty"`
+	GasUsed uint64       `json:"gasUsed,string"`
+}
+
+func (fee *BlockFee) Key() ledger.LedgerKey {
+	return blockFeeKey
+}
+
+func (fee *BlockFee) Encode() ([]byte, xerrors.XError) {
+	if bz, err := tmjson.Marshal(fee); err != nil {
+		return nil, xerrors.From(err)
+	} else {
+		return bz, nil
+	}
+}
+
+func (fee *BlockFee) Decode(bz []byte) xerrors.XError {
+	if err := tmjson.Unmarshal(bz, fee); err != nil {
+		return xerrors.From(err)
+	}
+	return nil
+}
+
+var _ ledger.ILedgerItem = (*BlockFee)(nil)
+
+// setBlockFee records the base fee and the gas used of the block of `ctx`.
+// It is called in EndBlock, after all transactions of the block are executed.
+func (ctrler *AcctCtrler) setBlockFee(ctx *atypes.BlockContext) xerrors.XError {
+	return ctrler.feeLedger.SetFinality(&BlockFee{
+		Height:  ctx.Height(),
+		BaseFee: ctx.BaseFee(),
+		GasUsed: ctx.GasUsed(),
+	})
+}
+
+// LastBlockFee returns the base fee and the gas used of the last committed block.
+// It returns nil, if no block has been committed since the fee is recorded.
+func (ctrler *AcctCtrler) LastBlockFee() *BlockFee {
+	ctrler.mtx.RLock()
+	defer ctrler.mtx.RUnlock()
+
+	fee, xerr := ctrler.feeLedger.Read(blockFeeKey)
+	if xerr != nil {
+		return nil
+	}
+	return fee
+}
@@ -1,6 +1,7 @@
 package account
 
 import (
+	"bytes"
 	"github.com/holiman/uint256"
 	cfg "github.com/rigochain/rigo-go/cmd/config"
 	atypes "github.com/rigochain/rigo-go/ctrlers/types"
@@ -10,25 +11,47 @@ import (
 	"github.com/rigochain/rigo-go/types/xerrors"
 	abcitypes "github.com/tendermint/tendermint/abci/types"
 	tmlog "github.com/tendermint/tendermint/libs/log"
+	tmdb "github.com/tendermint/tm-db"
+	"strconv"
 	"sync"
 )
 
 type AcctCtrler struct {
 	acctLedger ledger.IFinalityLedger[*atypes.Account]
+	// the base fee and the gas used of the last block
+	feeLedger ledger.IFinalityLedger[*BlockFee]
+
+	// the fee distributions of the blocks, which are kept for the queries.
+	feeDistDB tmdb.DB
+	feeDist   *FeeDistribution
 
 	logger tmlog.Logger
 	mtx    sync.RWMutex
 }
 
 func NewAcctCtrler(config *cfg.Config, logger tmlog.Logger) (*AcctCtrler, error) {
-	if execLedger, err := ledger.NewFinalityLedger[*atypes.Account]("accounts", config.DBDir(), 128, func() *atypes.Account { return &atypes.Account{} }); err != nil {
+	pruning := atypes.PruningOptionOf(config.App)
+	execLedger, xerr := ledger.NewFinalityLedger[*atypes.Account]("accounts", config.DBDir(), 128, pruning, func() *atypes.Account { return &atypes.Account{} })
+	if xerr != nil {
+		return nil, xerr
+	}
+	feeLedger, xerr := ledger.NewFinalityLedger[*BlockFee]("block_fee", config.DBDir(), 1, pruning, func() *BlockFee { return &BlockFee{} })
+	if xerr != nil {
+		_ = execLedger.Close()
+		return nil, xerr
+	}
+	feeDistDB, err := tmdb.NewDB("fee_distributions", "goleveldb", config.DBDir())
+	if err != nil {
+		_ = execLedger.Close()
+		_ = feeLedger.Close()
 		return nil, err
-	} else {
-		return &AcctCtrler{
-			acctLedger: execLedger,
-			logger:     logger.With("module", "rigo_AcctCtrler"),
-		}, nil
 	}
+	return &AcctCtrler{
+		acctLedger: execLedger,
+		feeLedger:  feeLedger,
+		feeDistDB:  feeDistDB,
+		logger:     logger.With("module", "rigo_AcctCtrler"),
+	}, nil
 }
 
 func (ctrler *AcctCtrler) ImmutableAcctCtrlerAt(height int64) (atypes.IAccountHandler, xerrors.XError) {
@@ -58,6 +81,22 @@ func (ctrler *AcctCtrler) InitLedger(req interface{}) xerrors.XError {
 			Address: addr,
 			Balance: holder.Balance.Clone(),
 		}
+		if holder.Vesting != nil {
+			if xerr := holder.Vesting.ValidateBasic(); xerr != nil {
+				return xerrors.ErrInitChain.Wrapf("wrong vesting of %v: %v", addr, xerr)
+			}
+			if holder.Vesting.Amount.Gt(acct.Balance) {
+				return xerrors.ErrInitChain.Wrapf("the vesting amount of %v is more than its balance", addr)
+			}
+			acct.AddVesting(holder.Vesting, 0)
+		}
+		if xerr := ctrler.setAccountCommittable(acct, true); xerr != nil {
+			return xerr
+		}
+	}
+
+	// the accounts exported from a running network
+	for _, acct := range genAppState.Accounts {
 		if xerr := ctrler.setAccountCommittable(acct, true); xerr != nil {
 			return xerr
 		}
@@ -76,29 +115,79 @@ func (ctrler *AcctCtrler) ValidateTrx(ctx *atypes.TrxContext) xerrors.XError {
 		if len(url) > atypes.MAX_ACCT_DOCURL {
 			return xerrors.ErrInvalidTrxPayloadParams.Wrapf("too long url. it should be less than %d.", atypes.MAX_ACCT_DOCURL)
 		}
+	case atypes.TRX_MULTISIG:
+		ms := ctx.Tx.Payload.(*atypes.TrxPayloadMultiSig).MultiSig()
+		if xerr := ms.ValidateBasic(); xerr != nil {
+			return xerr
+		}
+		if bytes.Compare(ms.Address(), ctx.Tx.To) != 0 {
+			return xerrors.ErrInvalidTrxPayloadParams.Wrapf("wrong address of the multi-signature account - expected: %v, actual: %v", ms.Address(), ctx.Tx.To)
+		}
+		if ctx.Receiver.GetMultiSig() != nil {
+			return xerrors.ErrInvalidTrxPayloadParams.Wrapf("the multi-signature account(%v) already exists", ctx.Tx.To)
+		}
+		if ctx.Receiver.GetCode() != nil {
+			return xerrors.ErrInvalidTrxPayloadParams.Wrapf("the account(%v) is a contract", ctx.Tx.To)
+		}
+	case atypes.TRX_BATCH:
+		return ctrler.validateBatch(ctx)
+	case atypes.TRX_VESTING:
+		return ctrler.validateVesting(ctx)
 	}
 
 	return nil
 }
 
 func (ctrler *AcctCtrler) ExecuteTrx(ctx *atypes.TrxContext) xerrors.XError {
-	ctrler.mtx.Lock()
-	defer ctrler.mtx.Unlock()
-
+	// `ctx.Sender` and `ctx.Receiver` are found by `ctx.AcctHandler`,
+	// so they are set to the ledger through `ctx.AcctHandler` too.
+	// When txs are executed in parallel, `ctx.AcctHandler` is not `AcctCtrler` but the handler of the lane.
 	switch ctx.Tx.GetType() {
 	case atypes.TRX_TRANSFER:
 		if xerr := ctrler.transfer(ctx.Sender, ctx.Receiver, ctx.Tx.Amount); xerr != nil {
 			return xerr
 		}
 	case atypes.TRX_SETDOC:
-		ctrler.setDoc(ctx.Sender,
-			ctx.Tx.Payload.(*atypes.TrxPayloadSetDoc).Name,
-			ctx.Tx.Payload.(*atypes.TrxPayloadSetDoc).URL)
+		name := ctx.Tx.Payload.(*atypes.TrxPayloadSetDoc).Name
+		url := ctx.Tx.Payload.(*atypes.TrxPayloadSetDoc).URL
+		ctrler.setDoc(ctx.Sender, name, url)
+
+		ctx.Events = append(ctx.Events, abcitypes.Event{
+			Type: atypes.EVENT_TYPE_SETDOC,
+			Attributes: []abcitypes.EventAttribute{
+				{Key: []byte(atypes.EVENT_ATTR_ADDRESS), Value: []byte(ctx.Sender.Address.String()), Index: true},
+				{Key: []byte(atypes.EVENT_ATTR_NAME), Value: []byte(name), Index: true},
+				{Key: []byte(atypes.EVENT_ATTR_URL), Value: []byte(url), Index: false},
+			},
+		})
+	case atypes.TRX_BATCH:
+		if xerr := ctrler.execBatch(ctx); xerr != nil {
+			return xerr
+		}
+	case atypes.TRX_VESTING:
+		if xerr := ctrler.execVesting(ctx); xerr != nil {
+			return xerr
+		}
+	case atypes.TRX_MULTISIG:
+		// the amount of the tx is the initial balance of the multi-signature account.
+		if xerr := ctrler.transfer(ctx.Sender, ctx.Receiver, ctx.Tx.Amount); xerr != nil {
+			return xerr
+		}
+		ms := ctx.Tx.Payload.(*atypes.TrxPayloadMultiSig).MultiSig()
+		ctx.Receiver.SetMultiSig(ms)
+
+		ctx.Events = append(ctx.Events, abcitypes.Event{
+			Type: atypes.EVENT_TYPE_MULTISIG,
+			Attributes: []abcitypes.EventAttribute{
+				{Key: []byte(atypes.EVENT_ATTR_ADDRESS), Value: []byte(ctx.Receiver.Address.String()), Index: true},
+				{Key: []byte(atypes.EVENT_ATTR_THRESHOLD), Value: []byte(strconv.FormatUint(uint64(ms.Threshold), 10)), Index: false},
+			},
+		})
 	}
 
-	_ = ctrler.setAccountCommittable(ctx.Sender, ctx.Exec)
+	_ = ctx.AcctHandler.SetAccountCommittable(ctx.Sender, ctx.Exec)
 	if ctx.Receiver != nil {
-		_ = ctrler.setAccountCommittable(ctx.Receiver, ctx.Exec)
+		_ = ctx.AcctHandler.SetAccountCommittable(ctx.Receiver, ctx.Exec)
 	}
 
 	return nil
@@ -113,22 +202,15 @@ func (ctrler *AcctCtrler) EndBlock(ctx *atypes.BlockContext) ([]abcitypes.Event,
 	ctrler.mtx.Lock()
 	defer ctrler.mtx.Unlock()
 
+	ctrler.feeDist = nil
+
+	if xerr := ctrler.setBlockFee(ctx); xerr != nil {
+		return nil, xerr
+	}
+
 	header := ctx.BlockInfo().Header
 	if header.GetProposerAddress() != nil && ctx.SumFee().Sign() > 0 {
-		//
-		// give fee to block proposer
-		// If the validator(proposer) has no balance in genesis and this is first tx fee reward,
-		// the validator's account may not exist yet not in ledger.
-		acct := ctrler.findAccount(header.GetProposerAddress(), true)
-		if acct == nil {
-			acct = atypes.NewAccount(header.GetProposerAddress())
-		}
-		xerr := acct.AddBalance(ctx.SumFee())
-		if xerr != nil {
-			return nil, xerr
-		}
-
-		return nil, ctrler.setAccountCommittable(acct, true)
+		return ctrler.distributeFee(ctx)
 	}
 	return nil, nil
 }
@@ -137,7 +219,37 @@ func (ctrler *AcctCtrler) Commit() ([]byte, int64, xerrors.XError) {
 	ctrler.mtx.Lock()
 	defer ctrler.mtx.Unlock()
 
-	return ctrler.acctLedger.Commit()
+	hash, ver, xerr := ctrler.acctLedger.Commit()
+	if xerr != nil {
+		return nil, -1, xerr
+	}
+	feeHash, feeVer, xerr := ctrler.feeLedger.Commit()
+	if xerr != nil {
+		return nil, -1, xerr
+	}
+	if ver != feeVer {
+		return nil, -1, xerrors.ErrCommit.Wrapf("error: AcctCtrler.Commit() has wrong version number - v0:%v, v1:%v", ver, feeVer)
+	}
+	if ctrler.feeDist != nil {
+		if xerr := ctrler.putFeeDistribution(ctrler.feeDist); xerr != nil {
+			return nil, -1, xerr
+		}
+		ctrler.feeDist = nil
+	}
+	return rootHashes(hash, feeHash).Hash(), ver, nil
+}
+
+func (ctrler *AcctCtrler) Rollback(height int64) xerrors.XError {
+	ctrler.mtx.Lock()
+	defer ctrler.mtx.Unlock()
+
+	if xerr := ctrler.acctLedger.Rollback(height); xerr != nil {
+		return xerr
+	}
+	if xerr := ctrler.feeLedger.Rollback(height); xerr != nil {
+		return xerr
+	}
+	return ctrler.rollbackFeeDistributions(height)
 }
 
 func (ctrler *AcctCtrler) Close() xerrors.XError {
@@ -151,6 +263,18 @@ func (ctrler *AcctCtrler) Close() xerrors.XError {
 		ctrler.logger.Debug("AcctCtrler - close ledgers")
 		ctrler.acctLedger = nil
 	}
+	if ctrler.feeLedger != nil {
+		if xerr := ctrler.feeLedger.Close(); xerr != nil {
+			ctrler.logger.Error("AcctCtrler", "feeLedger.Close() returns error", xerr.Error())
+		}
+		ctrler.feeLedger = nil
+	}
+	if ctrler.feeDistDB != nil {
+		if err := ctrler.feeDistDB.Close(); err != nil {
+			ctrler.logger.Error("AcctCtrler", "feeDistDB.Close() returns error", err.Error())
+		}
+		ctrler.feeDistDB = nil
+	}
 	return nil
 }
 
@@ -199,6 +323,20 @@ func (ctrler *AcctCtrler) ReadAccount(addr types.Address) *atypes.Account {
 	return ctrler.readAccount(addr)
 }
 
+// ReadTotalBalance returns the sum of the balances of all accounts at the last committed height.
+// It reads all accounts, so it should not be called every block.
+func (ctrler *AcctCtrler) ReadTotalBalance() *uint256.Int {
+	ctrler.mtx.RLock()
+	defer ctrler.mtx.RUnlock()
+
+	ret := uint256.NewInt(0)
+	_ = ctrler.acctLedger.IterateReadAllFinalityItems(func(acct *atypes.Account) xerrors.XError {
+		_ = ret.Add(ret, acct.Balance)
+		return nil
+	})
+	return ret
+}
+
 func (ctrler *AcctCtrler) readAccount(addr types.Address) *atypes.Account {
 	if acct, xerr := ctrler.acctLedger.Read(addr.Array32()); xerr != nil {
 		// db error or not found
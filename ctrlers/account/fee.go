@@ -0,0 +1,282 @@
+package account
+
+import (
+	"encoding/binary"
+	"github.com/holiman/uint256"
+	atypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/types"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"strconv"
+)
+
+// FeeDistribution is the result of distributing the fee of a block.
+// `Total` is the sum of `Proposer`, `Validators`, `Community` and `Burned`.
+// `Tip` is the part of `Total` paid over the base fee, and it is included in `Proposer`.
+type FeeDistribution struct {
+	Height     int64
+	Total      *uint256.Int
+	Tip        *uint256.Int
+	Proposer   *uint256.Int
+	Validators *uint256.Int
+	Community  *uint256.Int
+	Burned     *uint256.Int
+}
+
+func newFeeDistribution(height int64) *FeeDistribution {
+	return &FeeDistribution{
+		Height:     height,
+		Total:      uint256.NewInt(0),
+		Tip:        uint256.NewInt(0),
+		Proposer:   uint256.NewInt(0),
+		Validators: uint256.NewInt(0),
+		Community:  uint256.NewInt(0),
+		Burned:     uint256.NewInt(0),
+	}
+}
+
+func (fd *FeeDistribution) MarshalJSON() ([]byte, error) {
+	// the amounts are marshaled to decimal strings like `Account::Balance` in the account query.
+	return tmjson.Marshal(&struct {
+		Height     int64  `json:"height,string"`
+		Total      string `json:"total"`
+		Tip        string `json:"tip"`
+		Proposer   string `json:"proposer"`
+		Validators string `json:"validators"`
+		Community  string `json:"community"`
+		Burned     string `json:"burned"`
+	}{
+		Height:     fd.Height,
+		Total:      fd.Total.Dec(),
+		Tip:        fd.Tip.Dec(),
+		Proposer:   fd.Proposer.Dec(),
+		Validators: fd.Validators.Dec(),
+		Community:  fd.Community.Dec(),
+		Burned:     fd.Burned.Dec(),
+	})
+}
+
+func (fd *FeeDistribution) UnmarshalJSON(bz []byte) error {
+	tm := &struct {
+		Height     int64  `json:"height,string"`
+		Total      string `json:"total"`
+		Tip        string `json:"tip"`
+		Proposer   string `json:"proposer"`
+		Validators string `json:"validators"`
+		Community  string `json:"community"`
+		Burned     string `json:"burned"`
+	}{}
+	if err := tmjson.Unmarshal(bz, tm); err != nil {
+		return err
+	}
+
+	var err error
+	fd.Height = tm.Height
+	for _, v := range []struct {
+		dst **uint256.Int
+		src string
+	}{
+		{&fd.Total, tm.Total},
+		{&fd.Tip, tm.Tip},
+		{&fd.Proposer, tm.Proposer},
+		{&fd.Validators, tm.Validators},
+		{&fd.Community, tm.Community},
+		{&fd.Burned, tm.Burned},
+	} {
+		if *v.dst, err = uint256.FromDecimal(v.src); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// distributeFee gives the fee of the block to the proposer, the validators who signed the last block and
+// the community pool by the ratios of the governance parameters, and burns the rest.
+// Only the base fee (the gas used * the base fee) is distributed by the ratios,
+// and the tip paid over it is given to the proposer.
+// The validators share their portion in proportion to their voting power.
+// The remainders of divisions and the portion of validators, when no validator has signed, are given to the proposer.
+func (ctrler *AcctCtrler) distributeFee(ctx *atypes.BlockContext) ([]abcitypes.Event, xerrors.XError) {
+	dist := newFeeDistribution(ctx.Height())
+	dist.Total = ctx.SumFee()
+
+	base := dist.Total
+	if baseFee := ctx.BaseFee(); baseFee != nil {
+		base = new(uint256.Int).Mul(baseFee, uint256.NewInt(ctx.GasUsed()))
+		if base.Gt(dist.Total) {
+			base = dist.Total
+		}
+	}
+	dist.Tip = new(uint256.Int).Sub(dist.Total, base)
+
+	var validatorsRatio, communityRatio, burnRatio int64
+	if gov := ctx.GovHandler; gov != nil {
+		// if all ratios are 0 (e.g. the parameters before the fee distribution), the whole fee is given to the proposer.
+		validatorsRatio, communityRatio, burnRatio = gov.FeeValidatorsRatio(), gov.FeeCommunityRatio(), gov.FeeBurnRatio()
+	}
+
+	var evts []abcitypes.Event
+
+	if validatorsRatio > 0 {
+		portion := feePortion(base, validatorsRatio)
+
+		var sumPower int64
+		votes := ctx.BlockInfo().LastCommitInfo.Votes
+		for _, vote := range votes {
+			if vote.SignedLastBlock {
+				sumPower += vote.Validator.Power
+			}
+		}
+		for _, vote := range votes {
+			if !vote.SignedLastBlock || sumPower == 0 {
+				continue
+			}
+			amt := new(uint256.Int).Mul(portion, uint256.NewInt(uint64(vote.Validator.Power)))
+			amt = amt.Div(amt, uint256.NewInt(uint64(sumPower)))
+			if amt.IsZero() {
+				continue
+			}
+			if xerr := ctrler.rewardFee(vote.Validator.Address, amt); xerr != nil {
+				return nil, xerr
+			}
+			_ = dist.Validators.Add(dist.Validators, amt)
+			evts = append(evts, feeRewardEvent(vote.Validator.Address, amt, atypes.EVENT_ROLE_VALIDATOR))
+		}
+	}
+
+	if communityRatio > 0 {
+		dist.Community = feePortion(base, communityRatio)
+		if !dist.Community.IsZero() {
+			if xerr := ctrler.rewardFee(atypes.CommunityPoolAddress, dist.Community); xerr != nil {
+				return nil, xerr
+			}
+			evts = append(evts, feeRewardEvent(atypes.CommunityPoolAddress, dist.Community, atypes.EVENT_ROLE_COMMUNITY))
+		}
+	}
+
+	if burnRatio > 0 {
+		// the burned fee is given to nobody.
+		dist.Burned = feePortion(base, burnRatio)
+		if !dist.Burned.IsZero() {
+			ctx.AddBurned(dist.Burned)
+			evts = append(evts, abcitypes.Event{
+				Type: atypes.EVENT_TYPE_FEE_BURNED,
+				Attributes: []abcitypes.EventAttribute{
+					{Key: []byte(atypes.EVENT_ATTR_AMOUNT), Value: []byte(dist.Burned.Dec()), Index: false},
+					{Key: []byte(atypes.EVENT_ATTR_HEIGHT), Value: []byte(strconv.FormatInt(dist.Height, 10)), Index: false},
+				},
+			})
+		}
+	}
+
+	dist.Proposer = new(uint256.Int).Sub(dist.Total, dist.Validators)
+	_ = dist.Proposer.Sub(dist.Proposer, dist.Community)
+	_ = dist.Proposer.Sub(dist.Proposer, dist.Burned)
+	if !dist.Proposer.IsZero() {
+		proposer := types.Address(ctx.BlockInfo().Header.ProposerAddress)
+		if xerr := ctrler.rewardFee(proposer, dist.Proposer); xerr != nil {
+			return nil, xerr
+		}
+		evts = append(evts, feeRewardEvent(proposer, dist.Proposer, atypes.EVENT_ROLE_PROPOSER))
+	}
+
+	ctrler.feeDist = dist
+	return evts, nil
+}
+
+func (ctrler *AcctCtrler) rewardFee(addr types.Address, amt *uint256.Int) xerrors.XError {
+	// If the recipient has no balance in genesis and this is its first fee reward,
+	// the account of it may not exist yet in the ledger.
+	acct := ctrler.findAccount(addr, true)
+	if acct == nil {
+		acct = atypes.NewAccount(addr)
+	}
+	if xerr := acct.AddBalance(amt); xerr != nil {
+		return xerr
+	}
+	return ctrler.setAccountCommittable(acct, true)
+}
+
+func feePortion(total *uint256.Int, ratio int64) *uint256.Int {
+	ret := new(uint256.Int).Mul(total, uint256.NewInt(uint64(ratio)))
+	return ret.Div(ret, uint256.NewInt(100))
+}
+
+func feeRewardEvent(addr types.Address, amt *uint256.Int, role string) abcitypes.Event {
+	return abcitypes.Event{
+		Type: atypes.EVENT_TYPE_FEE_REWARD,
+		Attributes: []abcitypes.EventAttribute{
+			{Key: []byte(atypes.EVENT_ATTR_ADDRESS), Value: []byte(addr.String()), Index: true},
+			{Key: []byte(atypes.EVENT_ATTR_ROLE), Value: []byte(role), Index: true},
+			{Key: []byte(atypes.EVENT_ATTR_AMOUNT), Value: []byte(amt.Dec()), Index: false},
+		},
+	}
+}
+
+func feeDistKey(height int64) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, uint64(height))
+	return k
+}
+
+// putFeeDistribution records `dist` in `feeDistDB`.
+// The records are not a part of the app hash, so they are not included in the snapshots.
+func (ctrler *AcctCtrler) putFeeDistribution(dist *FeeDistribution) xerrors.XError {
+	bz, err := tmjson.Marshal(dist)
+	if err != nil {
+		return xerrors.From(err)
+	}
+	if err := ctrler.feeDistDB.SetSync(feeDistKey(dist.Height), bz); err != nil {
+		return xerrors.From(err)
+	}
+	return nil
+}
+
+// FeeDistributionAt returns the fee distribution of the block at `height`.
+// If the block has no fee, all amounts of the returned value are 0.
+func (ctrler *AcctCtrler) FeeDistributionAt(height int64) (*FeeDistribution, xerrors.XError) {
+	ctrler.mtx.RLock()
+	defer ctrler.mtx.RUnlock()
+
+	bz, err := ctrler.feeDistDB.Get(feeDistKey(height))
+	if err != nil {
+		return nil, xerrors.From(err)
+	} else if bz == nil {
+		return newFeeDistribution(height), nil
+	}
+
+	dist := &FeeDistribution{}
+	if err := tmjson.Unmarshal(bz, dist); err != nil {
+		return nil, xerrors.From(err)
+	}
+	return dist, nil
+}
+
+// rollbackFeeDistributions removes the records of the blocks higher than `height`.
+func (ctrler *AcctCtrler) rollbackFeeDistributions(height int64) xerrors.XError {
+	itr, err := ctrler.feeDistDB.Iterator(feeDistKey(height+1), nil)
+	if err != nil {
+		return xerrors.From(err)
+	}
+
+	var keys [][]byte
+	for ; itr.Valid(); itr.Next() {
+		keys = append(keys, append([]byte(nil), itr.Key()...))
+	}
+	if err := itr.Close(); err != nil {
+		return xerrors.From(err)
+	}
+
+	batch := ctrler.feeDistDB.NewBatch()
+	defer batch.Close()
+	for _, k := range keys {
+		if err := batch.Delete(k); err != nil {
+			return xerrors.From(err)
+		}
+	}
+	if err := batch.WriteSync(); err != nil {
+		return xerrors.From(err)
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package account
+
+import (
+	atypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/genesis"
+	"github.com/rigochain/rigo-go/types/xerrors"
+)
+
+// ExportGenesis puts all accounts at `height` into `appState`.
+func (ctrler *AcctCtrler) ExportGenesis(height int64, appState *genesis.GenesisAppState) xerrors.XError {
+	ctrler.mtx.RLock()
+	defer ctrler.mtx.RUnlock()
+
+	immuLedger, xerr := ctrler.acctLedger.ImmutableLedgerAt(height, 0)
+	if xerr != nil {
+		return xerr
+	}
+	return immuLedger.IterateReadAllItems(func(acct *atypes.Account) xerrors.XError {
+		appState.Accounts = append(appState.Accounts, acct)
+		return nil
+	})
+}
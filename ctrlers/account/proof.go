@@ -0,0 +1,57 @@
+package account
+
+import (
+	atypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/types"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	tmcrypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
+)
+
+const (
+	proofLedgerAccounts = "accounts"
+	proofLedgerBlockFee = "block_fee"
+)
+
+func rootHashes(acctsHash, feeHash []byte) atypes.MerkleRoots {
+	return atypes.MerkleRoots{
+		proofLedgerAccounts: acctsHash,
+		proofLedgerBlockFee: feeHash,
+	}
+}
+
+func (ctrler *AcctCtrler) RootHashAt(height int64) ([]byte, xerrors.XError) {
+	hash, xerr := ctrler.acctLedger.RootHashAt(height)
+	if xerr != nil {
+		return nil, xerr
+	}
+	feeHash, xerr := ctrler.feeLedger.RootHashAt(height)
+	if xerr != nil {
+		return nil, xerr
+	}
+	return rootHashes(hash, feeHash).Hash(), nil
+}
+
+// Prove returns the account of `req.Data` at `req.Height`.
+// If the account does not exist, the returned account is nil and the proof is the proof of absence.
+func (ctrler *AcctCtrler) Prove(req abcitypes.RequestQuery) ([]byte, *tmcrypto.ProofOps, xerrors.XError) {
+	bz, op, xerr := ctrler.acctLedger.ProveAt(req.Height, types.Address(req.Data).Array32())
+	if xerr != nil {
+		return nil, nil, xerrors.ErrQuery.Wrap(xerr)
+	}
+	hash, xerr := ctrler.acctLedger.RootHashAt(req.Height)
+	if xerr != nil {
+		return nil, nil, xerrors.ErrQuery.Wrap(xerr)
+	}
+	feeHash, xerr := ctrler.feeLedger.RootHashAt(req.Height)
+	if xerr != nil {
+		return nil, nil, xerrors.ErrQuery.Wrap(xerr)
+	}
+	rootOp, xerr := rootHashes(hash, feeHash).ProofOp(proofLedgerAccounts)
+	if xerr != nil {
+		return nil, nil, xerrors.ErrQuery.Wrap(xerr)
+	}
+	return bz, &tmcrypto.ProofOps{Ops: []tmcrypto.ProofOp{*op, *rootOp}}, nil
+}
+
+var _ atypes.IProofHandler = (*AcctCtrler)(nil)
@@ -10,6 +10,18 @@ import (
 )
 
 func (ctrler *AcctCtrler) Query(req abcitypes.RequestQuery) ([]byte, xerrors.XError) {
+	if req.Path == "fee_distribution" {
+		dist, xerr := ctrler.FeeDistributionAt(req.Height)
+		if xerr != nil {
+			return nil, xerrors.ErrQuery.Wrap(xerr)
+		}
+		if raw, err := tmjson.Marshal(dist); err != nil {
+			return nil, xerrors.ErrQuery.Wrap(err)
+		} else {
+			return raw, nil
+		}
+	}
+
 	immuLedger, xerr := ctrler.acctLedger.ImmutableLedgerAt(req.Height, 0)
 	if xerr != nil {
 		return nil, xerrors.ErrQuery.Wrap(xerr)
@@ -0,0 +1,42 @@
+package account
+
+import (
+	atypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/types/xerrors"
+)
+
+const (
+	snapshotStoreAccounts = "accounts"
+	snapshotStoreBlockFee = "block_fee"
+)
+
+func (ctrler *AcctCtrler) Snapshot(height int64) (atypes.SnapshotExporter, xerrors.XError) {
+	ctrler.mtx.RLock()
+	defer ctrler.mtx.RUnlock()
+
+	acctLedger, feeLedger := ctrler.acctLedger, ctrler.feeLedger
+
+	return func(cb func(*atypes.SnapshotItemProto) xerrors.XError) xerrors.XError {
+		if xerr := atypes.ExportLedgerSnapshot(snapshotStoreAccounts, height, acctLedger, cb); xerr != nil {
+			return xerr
+		}
+		return atypes.ExportLedgerSnapshot(snapshotStoreBlockFee, height, feeLedger, cb)
+	}, nil
+}
+
+func (ctrler *AcctCtrler) RestoreSnapshot(height int64, items atypes.SnapshotItems) ([]byte, xerrors.XError) {
+	ctrler.mtx.Lock()
+	defer ctrler.mtx.Unlock()
+
+	hash, xerr := atypes.ImportLedgerSnapshot(snapshotStoreAccounts, height, ctrler.acctLedger, items)
+	if xerr != nil {
+		return nil, xerr
+	}
+	feeHash, xerr := atypes.ImportLedgerSnapshot(snapshotStoreBlockFee, height, ctrler.feeLedger, items)
+	if xerr != nil {
+		return nil, xerr
+	}
+	return rootHashes(hash, feeHash).Hash(), nil
+}
+
+var _ atypes.ISnapshotHandler = (*AcctCtrler)(nil)
@@ -0,0 +1,50 @@
+package account
+
+import (
+	"bytes"
+	atypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/types"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"strconv"
+)
+
+func (ctrler *AcctCtrler) validateVesting(ctx *atypes.TrxContext) xerrors.XError {
+	payload, ok := ctx.Tx.Payload.(*atypes.TrxPayloadVesting)
+	if !ok {
+		return xerrors.ErrInvalidTrxPayloadType
+	}
+	if xerr := payload.Vesting(ctx.Tx.Amount).ValidateBasic(); xerr != nil {
+		return xerr
+	}
+	if ctx.Tx.Amount.Lt(atypes.MinVestingAmount) {
+		return xerrors.ErrInvalidAmount.Wrapf("the vesting amount should be at least %v", atypes.MinVestingAmount.Dec())
+	}
+	if bytes.Compare(ctx.Tx.To, types.ZeroAddress()) == 0 || bytes.Compare(ctx.Tx.To, ctx.Tx.From) == 0 {
+		return xerrors.ErrInvalidAddress.Wrapf("wrong vesting account: %v", ctx.Tx.To)
+	}
+	if ctx.Receiver.GetCode() != nil {
+		return xerrors.ErrInvalidTrxPayloadParams.Wrapf("the account(%v) is a contract", ctx.Tx.To)
+	}
+	// the schedule is added to the existing schedules of the account without changing them,
+	// so nobody can delay the unlocking of an account by sending it a small amount on a long schedule.
+	return nil
+}
+
+func (ctrler *AcctCtrler) execVesting(ctx *atypes.TrxContext) xerrors.XError {
+	if xerr := ctrler.transfer(ctx.Sender, ctx.Receiver, ctx.Tx.Amount); xerr != nil {
+		return xerr
+	}
+	v := ctx.Tx.Payload.(*atypes.TrxPayloadVesting).Vesting(ctx.Tx.Amount)
+	ctx.Receiver.AddVesting(v, ctx.BlockTime)
+
+	ctx.Events = append(ctx.Events, abcitypes.Event{
+		Type: atypes.EVENT_TYPE_VESTING,
+		Attributes: []abcitypes.EventAttribute{
+			{Key: []byte(atypes.EVENT_ATTR_ADDRESS), Value: []byte(ctx.Receiver.Address.String()), Index: true},
+			{Key: []byte(atypes.EVENT_ATTR_AMOUNT), Value: []byte(v.Amount.Dec()), Index: false},
+			{Key: []byte(atypes.EVENT_ATTR_END), Value: []byte(strconv.FormatInt(v.End, 10)), Index: false},
+		},
+	})
+	return nil
+}
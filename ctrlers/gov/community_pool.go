@@ -0,0 +1,64 @@
+package gov
+
+import (
+	"bytes"
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"sort"
+	"strconv"
+)
+
+// spendCommunityPool transfers the amount of `spend` from the community pool to the recipient.
+func (ctrler *GovCtrler) spendCommunityPool(spend *ctrlertypes.CommunitySpend, acctHandler ctrlertypes.IAccountHandler) xerrors.XError {
+	if acctHandler == nil {
+		return xerrors.ErrNotFoundAccount.Wrapf("no account handler to spend the community pool")
+	}
+	if xerr := acctHandler.Transfer(ctrlertypes.CommunityPoolAddress, spend.Recipient, spend.Amount, true); xerr != nil {
+		return xerr
+	}
+	if xerr := ctrler.spendLedger.SetFinality(spend); xerr != nil {
+		return xerr
+	}
+	ctrler.spends = append(ctrler.spends, spend)
+	return nil
+}
+
+func communitySpendEvent(spend *ctrlertypes.CommunitySpend) abcitypes.Event {
+	return abcitypes.Event{
+		Type: ctrlertypes.EVENT_TYPE_COMMUNITY_SPEND,
+		Attributes: []abcitypes.EventAttribute{
+			{Key: []byte(ctrlertypes.EVENT_ATTR_ADDRESS), Value: []byte(spend.Recipient.String()), Index: true},
+			{Key: []byte(ctrlertypes.EVENT_ATTR_TXHASH), Value: []byte(spend.TxHash.String()), Index: true},
+			{Key: []byte(ctrlertypes.EVENT_ATTR_AMOUNT), Value: []byte(spend.Amount.Dec()), Index: false},
+			{Key: []byte(ctrlertypes.EVENT_ATTR_HEIGHT), Value: []byte(strconv.FormatInt(spend.Height, 10)), Index: false},
+		},
+	}
+}
+
+// CommunitySpends returns the spends of the community pool up to `height`, in order of the applied height.
+// The spends are kept in `spendLedger`, so the history is a part of the app hash and of the snapshots.
+func (ctrler *GovCtrler) CommunitySpends(height int64) ([]*ctrlertypes.CommunitySpend, xerrors.XError) {
+	ctrler.mtx.RLock()
+	defer ctrler.mtx.RUnlock()
+
+	atLedger, xerr := ctrler.spendLedger.ImmutableLedgerAt(height, 0)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	var spends []*ctrlertypes.CommunitySpend
+	if xerr := atLedger.IterateReadAllItems(func(spend *ctrlertypes.CommunitySpend) xerrors.XError {
+		spends = append(spends, spend)
+		return nil
+	}); xerr != nil {
+		return nil, xerr
+	}
+	sort.Slice(spends, func(i, j int) bool {
+		if spends[i].Height != spends[j].Height {
+			return spends[i].Height < spends[j].Height
+		}
+		return bytes.Compare(spends[i].TxHash, spends[j].TxHash) < 0
+	})
+	return spends, nil
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/rigochain/rigo-go/ledger"
 	"github.com/rigochain/rigo-go/types"
 	abytes "github.com/rigochain/rigo-go/types/bytes"
-	"github.com/rigochain/rigo-go/types/crypto"
 	"github.com/rigochain/rigo-go/types/xerrors"
 	abcitypes "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/json"
@@ -23,10 +22,18 @@ import (
 type GovCtrler struct {
 	ctrlertypes.GovParams
 	newGovParams *ctrlertypes.GovParams
+	// the upgrade plan which should be applied at the current block.
+	upgradePlan *ctrlertypes.UpgradePlan
+	// the upgrades applied already. it is used to reject the upgrade proposals with a duplicated name.
+	upgradeHistory ctrlertypes.IUpgradeHistory
 
 	paramsLedger   ledger.IFinalityLedger[*ctrlertypes.GovParams]
 	proposalLedger ledger.IFinalityLedger[*proposal.GovProposal]
 	frozenLedger   ledger.IFinalityLedger[*proposal.GovProposal]
+	spendLedger    ledger.IFinalityLedger[*ctrlertypes.CommunitySpend]
+
+	// the community pool spends of the current block.
+	spends []*ctrlertypes.CommunitySpend
 
 	logger log.Logger
 	mtx    sync.RWMutex
@@ -37,8 +44,10 @@ func NewGovCtrler(config *cfg.Config, logger log.Logger) (*GovCtrler, error) {
 	newProposalProvider := func() *proposal.GovProposal {
 		return &proposal.GovProposal{}
 	}
+	newSpendProvider := func() *ctrlertypes.CommunitySpend { return &ctrlertypes.CommunitySpend{} }
 
-	paramsLedger, xerr := ledger.NewFinalityLedger[*ctrlertypes.GovParams]("gov_params", config.DBDir(), 1, newGovParamsProvider)
+	pruning := ctrlertypes.PruningOptionOf(config.App)
+	paramsLedger, xerr := ledger.NewFinalityLedger[*ctrlertypes.GovParams]("gov_params", config.DBDir(), 1, pruning, newGovParamsProvider)
 	if xerr != nil {
 		return nil, xerr
 	}
@@ -51,12 +60,17 @@ func NewGovCtrler(config *cfg.Config, logger log.Logger) (*GovCtrler, error) {
 		params = &ctrlertypes.GovParams{} // empty params
 	}
 
-	proposalLedger, xerr := ledger.NewFinalityLedger[*proposal.GovProposal]("proposal", config.DBDir(), 1, newProposalProvider)
+	proposalLedger, xerr := ledger.NewFinalityLedger[*proposal.GovProposal]("proposal", config.DBDir(), 1, pruning, newProposalProvider)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	frozenLedger, xerr := ledger.NewFinalityLedger[*proposal.GovProposal]("frozen_proposal", config.DBDir(), 1, pruning, newProposalProvider)
 	if xerr != nil {
 		return nil, xerr
 	}
 
-	frozenLedger, xerr := ledger.NewFinalityLedger[*proposal.GovProposal]("frozen_proposal", config.DBDir(), 1, newProposalProvider)
+	spendLedger, xerr := ledger.NewFinalityLedger[*ctrlertypes.CommunitySpend]("community_spends", config.DBDir(), 1, pruning, newSpendProvider)
 	if xerr != nil {
 		return nil, xerr
 	}
@@ -66,6 +80,7 @@ func NewGovCtrler(config *cfg.Config, logger log.Logger) (*GovCtrler, error) {
 		paramsLedger:   paramsLedger,
 		proposalLedger: proposalLedger,
 		frozenLedger:   frozenLedger,
+		spendLedger:    spendLedger,
 		logger:         logger.With("module", "rigo_GovCtrler"),
 	}, nil
 }
@@ -78,18 +93,29 @@ func (ctrler *GovCtrler) InitLedger(req interface{}) xerrors.XError {
 	if !ok {
 		return xerrors.ErrInitChain.Wrapf("wrong parameter: GovCtrler::InitLedger requires *genesis.GenesisAppState")
 	}
+	if xerr := genAppState.GovParams.CheckFeeRatios(); xerr != nil {
+		return xerrors.ErrInitChain.Wrap(xerr)
+	}
 	ctrler.GovParams = *genAppState.GovParams
 	_ = ctrler.paramsLedger.SetFinality(&ctrler.GovParams)
-	return nil
+	return ctrler.importProposals(genAppState)
 }
 
 func (ctrler *GovCtrler) BeginBlock(blockCtx *ctrlertypes.BlockContext) ([]abcitypes.Event, xerrors.XError) {
 	var evts []abcitypes.Event
 
-	byzantines := blockCtx.BlockInfo().ByzantineValidators
+	byzantines := blockCtx.ByzantineValidators()
 	if byzantines != nil && len(byzantines) > 0 {
 		ctrler.logger.Info("GovCtrler: Byzantine validators is found", "count", len(byzantines))
 		for _, evi := range byzantines {
+			// the misbehavior punished at a previous block is not punished again.
+			if blockCtx.StakeHandler != nil && blockCtx.StakeHandler.IsPunished(&evi) {
+				ctrler.logger.Info("GovCtrler: the evidence has been already handled",
+					"byzantine", types.Address(evi.Validator.Address),
+					"evidenceType", abcitypes.EvidenceType_name[int32(evi.Type)],
+					"height", evi.Height)
+				continue
+			}
 			if slashed, xerr := ctrler.doPunish(&evi); xerr != nil {
 				ctrler.logger.Error("Error when punishing",
 					"byzantine", types.Address(evi.Validator.Address),
@@ -197,6 +223,26 @@ func (ctrler *GovCtrler) ValidateTrx(ctx *ctrlertypes.TrxContext) xerrors.XError
 				if err := json.Unmarshal(option, checkGovParams); err != nil {
 					return xerrors.ErrInvalidTrxPayloadParams.Wrap(err)
 				}
+				if xerr := checkGovParams.CheckFeeRatios(); xerr != nil {
+					return xerr
+				}
+			}
+		} else if txpayload.OptType == proposal.PROPOSAL_UPGRADE {
+			for _, option := range txpayload.Options {
+				plan, xerr := ctrlertypes.DecodeUpgradePlan(option)
+				if xerr != nil {
+					return xerrors.ErrInvalidTrxPayloadParams.Wrap(xerr)
+				}
+				if xerr := ctrler.checkUpgradePlan(plan, txpayload.ApplyingHeight, ctx.Exec); xerr != nil {
+					return xerr
+				}
+			}
+		} else if txpayload.OptType == proposal.PROPOSAL_COMMUNITY_SPEND {
+			// the balance of the community pool is checked when the proposal is applied.
+			for _, option := range txpayload.Options {
+				if _, xerr := ctrlertypes.DecodeCommunitySpend(option); xerr != nil {
+					return xerrors.ErrInvalidTrxPayloadParams.Wrap(xerr)
+				}
 			}
 		}
 		endVotingHeight := txpayload.StartVotingHeight + txpayload.VotingPeriodBlocks
@@ -333,7 +379,7 @@ func (ctrler *GovCtrler) EndBlock(ctx *ctrlertypes.BlockContext) ([]abcitypes.Ev
 		return nil, xerr
 	}
 
-	applied, xerr := ctrler.applyProposals(ctx.Height())
+	applied, failed, xerr := ctrler.applyProposals(ctx.Height(), ctx.AcctHandler)
 	if xerr != nil {
 		return nil, xerr
 	}
@@ -362,6 +408,17 @@ func (ctrler *GovCtrler) EndBlock(ctx *ctrlertypes.BlockContext) ([]abcitypes.Ev
 			},
 		})
 	}
+	for _, h := range failed {
+		evts = append(evts, abcitypes.Event{
+			Type: "proposal",
+			Attributes: []abcitypes.EventAttribute{
+				{Key: []byte("failed"), Value: []byte(h.String()), Index: true},
+			},
+		})
+	}
+	for _, spend := range ctrler.spends {
+		evts = append(evts, communitySpendEvent(spend))
+	}
 
 	return evts, nil
 }
@@ -398,8 +455,11 @@ func (ctrler *GovCtrler) freezeProposals(height int64) ([]abytes.HexBytes, []aby
 	return frozen, removed, xerr
 }
 
-func (ctrler *GovCtrler) applyProposals(height int64) ([]abytes.HexBytes, xerrors.XError) {
-	var applied []abytes.HexBytes
+// applyProposals applies the frozen proposals whose applying height is reached.
+// The community spend proposals which can not be executed (e.g. the community pool has not enough balance) are
+// removed without any change and returned as `failed`.
+func (ctrler *GovCtrler) applyProposals(height int64, acctHandler ctrlertypes.IAccountHandler) ([]abytes.HexBytes, []abytes.HexBytes, xerrors.XError) {
+	var applied, failed []abytes.HexBytes
 	xerr := ctrler.frozenLedger.IterateReadAllItems(func(prop *proposal.GovProposal) xerrors.XError {
 		if prop.ApplyingHeight <= height {
 			if _, xerr := ctrler.frozenLedger.DelFinality(prop.Key()); xerr != nil {
@@ -429,6 +489,27 @@ func (ctrler *GovCtrler) applyProposals(height int64) ([]abytes.HexBytes, xerror
 						return xerr
 					}
 					ctrler.newGovParams = newGovParams
+				case proposal.PROPOSAL_UPGRADE:
+					plan, xerr := ctrlertypes.DecodeUpgradePlan(prop.MajorOption.Option())
+					if xerr != nil {
+						ctrler.logger.Error("Apply proposal", "error", xerr, "option", string(prop.MajorOption.Option()))
+						return xerr
+					}
+					plan.Height = height
+					ctrler.upgradePlan = plan
+				case proposal.PROPOSAL_COMMUNITY_SPEND:
+					spend, xerr := ctrlertypes.DecodeCommunitySpend(prop.MajorOption.Option())
+					if xerr != nil {
+						ctrler.logger.Error("Apply proposal", "error", xerr, "option", string(prop.MajorOption.Option()))
+						return xerr
+					}
+					spend.Height = height
+					spend.TxHash = prop.TxHash
+					if xerr := ctrler.spendCommunityPool(spend, acctHandler); xerr != nil {
+						ctrler.logger.Error("Apply proposal", "error", xerr, "spend", string(prop.MajorOption.Option()))
+						failed = append(failed, prop.TxHash)
+						return nil
+					}
 				default:
 					key := prop.Key()
 					ctrler.logger.Debug("Apply proposal", "key(txHash)", abytes.HexBytes(key[:]), "type", prop.OptType)
@@ -442,7 +523,7 @@ func (ctrler *GovCtrler) applyProposals(height int64) ([]abytes.HexBytes, xerror
 		return nil
 	})
 
-	return applied, xerr
+	return applied, failed, xerr
 }
 
 func (ctrler *GovCtrler) Commit() ([]byte, int64, xerrors.XError) {
@@ -462,8 +543,13 @@ func (ctrler *GovCtrler) Commit() ([]byte, int64, xerrors.XError) {
 		return nil, -1, xerr
 	}
 
-	if v0 != v1 || v1 != v2 {
-		return nil, -1, xerrors.ErrCommit.Wrapf("error: GovCtrler.Commit() has wrong version number - v0:%v, v1:%v, v2:%v", v0, v1, v2)
+	h3, v3, xerr := ctrler.spendLedger.Commit()
+	if xerr != nil {
+		return nil, -1, xerr
+	}
+
+	if v0 != v1 || v1 != v2 || v2 != v3 {
+		return nil, -1, xerrors.ErrCommit.Wrapf("error: GovCtrler.Commit() has wrong version number - v0:%v, v1:%v, v2:%v, v3:%v", v0, v1, v2, v3)
 	}
 
 	if ctrler.newGovParams != nil {
@@ -471,10 +557,48 @@ func (ctrler *GovCtrler) Commit() ([]byte, int64, xerrors.XError) {
 		ctrler.newGovParams = nil
 		ctrler.logger.Debug("New governance parameters is committed", "gov_params", ctrler.GovParams.String())
 	}
-	return crypto.DefaultHash(h0, h1, h2), v0, nil
+	ctrler.upgradePlan = nil
+	ctrler.spends = nil
+	return rootHashes(h0, h1, h2, h3).Hash(), v0, nil
 
 }
 
+// Rollback discards the ledgers' versions newer than `height` and reloads the governance parameters of `height`.
+func (ctrler *GovCtrler) Rollback(height int64) xerrors.XError {
+	ctrler.mtx.Lock()
+	defer ctrler.mtx.Unlock()
+
+	if xerr := ctrler.paramsLedger.Rollback(height); xerr != nil {
+		return xerr
+	}
+	if xerr := ctrler.proposalLedger.Rollback(height); xerr != nil {
+		return xerr
+	}
+	if xerr := ctrler.frozenLedger.Rollback(height); xerr != nil {
+		return xerr
+	}
+	if xerr := ctrler.spendLedger.Rollback(height); xerr != nil {
+		return xerr
+	}
+
+	ctrler.newGovParams = nil
+	ctrler.spends = nil
+	ctrler.upgradePlan = nil
+
+	params, xerr := ctrler.paramsLedger.Get(ledger.ToLedgerKey(abytes.ZeroBytes(32)))
+	if xerr == xerrors.ErrNotFoundResult {
+		ctrler.GovParams = ctrlertypes.GovParams{} // empty params
+		return nil
+	} else if xerr != nil {
+		return xerr
+	}
+	bz, xerr := params.Encode()
+	if xerr != nil {
+		return xerr
+	}
+	return ctrler.GovParams.Decode(bz)
+}
+
 func (ctrler *GovCtrler) Close() xerrors.XError {
 	ctrler.mtx.Lock()
 	defer ctrler.mtx.Unlock()
@@ -491,6 +615,18 @@ func (ctrler *GovCtrler) Close() xerrors.XError {
 		}
 		ctrler.proposalLedger = nil
 	}
+	if ctrler.frozenLedger != nil {
+		if xerr := ctrler.frozenLedger.Close(); xerr != nil {
+			ctrler.logger.Error("frozenLedger.Close()", "error", xerr.Error())
+		}
+		ctrler.frozenLedger = nil
+	}
+	if ctrler.spendLedger != nil {
+		if xerr := ctrler.spendLedger.Close(); xerr != nil {
+			ctrler.logger.Error("spendLedger.Close()", "error", xerr.Error())
+		}
+		ctrler.spendLedger = nil
+	}
 	return nil
 }
 
@@ -501,6 +637,58 @@ func (ctrler *GovCtrler) GetGovParams() ctrlertypes.GovParams {
 	return ctrler.GovParams
 }
 
+// SetUpgradeHistory sets the upgrades applied already.
+func (ctrler *GovCtrler) SetUpgradeHistory(history ctrlertypes.IUpgradeHistory) {
+	ctrler.mtx.Lock()
+	defer ctrler.mtx.Unlock()
+
+	ctrler.upgradeHistory = history
+}
+
+// checkUpgradePlan checks that `plan` is applied at `applyingHeight`
+// and that no upgrade with the same name has been applied or proposed.
+func (ctrler *GovCtrler) checkUpgradePlan(plan *ctrlertypes.UpgradePlan, applyingHeight int64, exec bool) xerrors.XError {
+	// the height of a plan is set to the applying height of the proposal.
+	// so, a plan with another height (e.g. a past height) is wrong.
+	if plan.Height != 0 && plan.Height != applyingHeight {
+		return xerrors.ErrInvalidTrxPayloadParams.Wrapf("the height of the upgrade plan(%v) is not the applying height(%v)", plan.Height, applyingHeight)
+	}
+	if ctrler.upgradeHistory != nil && ctrler.upgradeHistory.HasUpgrade(plan.Name) {
+		return xerrors.ErrDuplicatedKey.Wrapf("the upgrade %q has been applied already", plan.Name)
+	}
+
+	checkProposal := func(prop *proposal.GovProposal) xerrors.XError {
+		if prop.OptType != proposal.PROPOSAL_UPGRADE {
+			return nil
+		}
+		for _, opt := range prop.Options {
+			if proposed, xerr := ctrlertypes.DecodeUpgradePlan(opt.Option()); xerr == nil && proposed.Name == plan.Name {
+				return xerrors.ErrDuplicatedKey.Wrapf("the upgrade %q has been proposed already", plan.Name)
+			}
+		}
+		return nil
+	}
+	if xerr := ctrler.proposalLedger.IterateReadAllItems(checkProposal); xerr != nil {
+		return xerr
+	}
+	if exec {
+		// the proposals submitted in the current block.
+		if xerr := ctrler.proposalLedger.IterateFinalityUpdatedItems(checkProposal); xerr != nil {
+			return xerr
+		}
+	}
+	return ctrler.frozenLedger.IterateReadAllItems(checkProposal)
+}
+
+// UpgradePlan returns the upgrade plan applied by the current block.
+// It returns nil if there is no upgrade at the current block.
+func (ctrler *GovCtrler) UpgradePlan() *ctrlertypes.UpgradePlan {
+	ctrler.mtx.RLock()
+	defer ctrler.mtx.RUnlock()
+
+	return ctrler.upgradePlan
+}
+
 func (ctrler *GovCtrler) ReadAllProposals() ([]*proposal.GovProposal, xerrors.XError) {
 	ctrler.mtx.RLock()
 	defer ctrler.mtx.RUnlock()
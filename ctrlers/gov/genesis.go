@@ -0,0 +1,67 @@
+package gov
+
+import (
+	"github.com/rigochain/rigo-go/ctrlers/gov/proposal"
+	"github.com/rigochain/rigo-go/genesis"
+	"github.com/rigochain/rigo-go/ledger"
+	"github.com/rigochain/rigo-go/types/bytes"
+	"github.com/rigochain/rigo-go/types/xerrors"
+)
+
+// ExportGenesis puts the governance parameters and all proposals at `height` into `appState`.
+// The heights of the proposals are rebased to the new network, which starts after `height`.
+func (ctrler *GovCtrler) ExportGenesis(height int64, appState *genesis.GenesisAppState) xerrors.XError {
+	ctrler.mtx.RLock()
+	defer ctrler.mtx.RUnlock()
+
+	paramsLedger, xerr := ctrler.paramsLedger.ImmutableLedgerAt(height, 0)
+	if xerr != nil {
+		return xerr
+	}
+	govParams, xerr := paramsLedger.Read(ledger.ToLedgerKey(bytes.ZeroBytes(32)))
+	if xerr != nil {
+		return xerr
+	}
+	appState.GovParams = govParams
+
+	proposalLedger, xerr := ctrler.proposalLedger.ImmutableLedgerAt(height, 0)
+	if xerr != nil {
+		return xerr
+	}
+	if xerr := proposalLedger.IterateReadAllItems(func(prop *proposal.GovProposal) xerrors.XError {
+		appState.Proposals = append(appState.Proposals, rebaseProposal(prop, height))
+		return nil
+	}); xerr != nil {
+		return xerr
+	}
+
+	frozenLedger, xerr := ctrler.frozenLedger.ImmutableLedgerAt(height, 0)
+	if xerr != nil {
+		return xerr
+	}
+	return frozenLedger.IterateReadAllItems(func(prop *proposal.GovProposal) xerrors.XError {
+		appState.FrozenProposals = append(appState.FrozenProposals, rebaseProposal(prop, height))
+		return nil
+	})
+}
+
+func rebaseProposal(prop *proposal.GovProposal, height int64) *proposal.GovProposal {
+	prop.StartVotingHeight -= height
+	prop.EndVotingHeight -= height
+	prop.ApplyingHeight -= height
+	return prop
+}
+
+func (ctrler *GovCtrler) importProposals(genAppState *genesis.GenesisAppState) xerrors.XError {
+	for _, prop := range genAppState.Proposals {
+		if xerr := ctrler.proposalLedger.SetFinality(prop); xerr != nil {
+			return xerr
+		}
+	}
+	for _, prop := range genAppState.FrozenProposals {
+		if xerr := ctrler.frozenLedger.SetFinality(prop); xerr != nil {
+			return xerr
+		}
+	}
+	return nil
+}
@@ -67,6 +67,10 @@ func (s *stakeHandlerMock) DelegatedPowerOf(addr types.Address) int64 {
 	return 0
 }
 
+func (s *stakeHandlerMock) IsPunished(evi *abcitypes.Evidence) bool {
+	return false
+}
+
 func (s *stakeHandlerMock) PickAddress(i int) types.Address {
 	return s.delegatees[i].Addr
 }
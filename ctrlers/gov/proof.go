@@ -0,0 +1,99 @@
+package gov
+
+import (
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/ledger"
+	"github.com/rigochain/rigo-go/types/bytes"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	tmcrypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
+)
+
+const (
+	proofLedgerParams    = "gov_params"
+	proofLedgerProposals = "proposal"
+	proofLedgerFrozen    = "frozen_proposal"
+	proofLedgerSpends    = "community_spends"
+)
+
+func rootHashes(paramsHash, proposalsHash, frozenHash, spendsHash []byte) ctrlertypes.MerkleRoots {
+	return ctrlertypes.MerkleRoots{
+		proofLedgerParams:    paramsHash,
+		proofLedgerProposals: proposalsHash,
+		proofLedgerFrozen:    frozenHash,
+		proofLedgerSpends:    spendsHash,
+	}
+}
+
+func (ctrler *GovCtrler) rootHashesAt(height int64) (ctrlertypes.MerkleRoots, xerrors.XError) {
+	h0, xerr := ctrler.paramsLedger.RootHashAt(height)
+	if xerr != nil {
+		return nil, xerr
+	}
+	h1, xerr := ctrler.proposalLedger.RootHashAt(height)
+	if xerr != nil {
+		return nil, xerr
+	}
+	h2, xerr := ctrler.frozenLedger.RootHashAt(height)
+	if xerr != nil {
+		return nil, xerr
+	}
+	h3, xerr := ctrler.spendLedger.RootHashAt(height)
+	if xerr != nil {
+		return nil, xerr
+	}
+	return rootHashes(h0, h1, h2, h3), nil
+}
+
+func (ctrler *GovCtrler) RootHashAt(height int64) ([]byte, xerrors.XError) {
+	roots, xerr := ctrler.rootHashesAt(height)
+	if xerr != nil {
+		return nil, xerr
+	}
+	return roots.Hash(), nil
+}
+
+// Prove returns the governance parameters or the proposal of `req.Data` at `req.Height`.
+// A proposal is proven in the ledger of the voting proposals or in the ledger of the frozen proposals.
+func (ctrler *GovCtrler) Prove(req abcitypes.RequestQuery) ([]byte, *tmcrypto.ProofOps, xerrors.XError) {
+	var name string
+	var bz []byte
+	var op *tmcrypto.ProofOp
+	var xerr xerrors.XError
+
+	switch req.Path {
+	case "gov_params":
+		name = proofLedgerParams
+		bz, op, xerr = ctrler.paramsLedger.ProveAt(req.Height, ledger.ToLedgerKey(bytes.ZeroBytes(32)))
+	case "proposal":
+		if len(req.Data) == 0 {
+			return nil, nil, xerrors.ErrQuery.Wrapf("the proof of all proposals is not supported")
+		}
+		name = proofLedgerProposals
+		bz, op, xerr = ctrler.proposalLedger.ProveAt(req.Height, ledger.ToLedgerKey(req.Data))
+		if xerr == nil && bz == nil {
+			name = proofLedgerFrozen
+			bz, op, xerr = ctrler.frozenLedger.ProveAt(req.Height, ledger.ToLedgerKey(req.Data))
+		}
+		if xerr == nil && bz == nil {
+			xerr = xerrors.ErrNotFoundProposal
+		}
+	default:
+		return nil, nil, xerrors.ErrQuery.Wrapf("unknown query path")
+	}
+	if xerr != nil {
+		return nil, nil, xerrors.ErrQuery.Wrap(xerr)
+	}
+
+	roots, xerr := ctrler.rootHashesAt(req.Height)
+	if xerr != nil {
+		return nil, nil, xerrors.ErrQuery.Wrap(xerr)
+	}
+	rootOp, xerr := roots.ProofOp(name)
+	if xerr != nil {
+		return nil, nil, xerrors.ErrQuery.Wrap(xerr)
+	}
+	return bz, &tmcrypto.ProofOps{Ops: []tmcrypto.ProofOp{*op, *rootOp}}, nil
+}
+
+var _ ctrlertypes.IProofHandler = (*GovCtrler)(nil)
@@ -6,10 +6,12 @@ import (
 )
 
 const (
-	PROPOSAL_ONCHAIN   = 0x0100
-	PROPOSAL_OFFCHAIN  = 0x0200
-	PROPOSAL_GOVPARAMS = PROPOSAL_ONCHAIN | 0x01
-	PROPOSAL_COMMON    = PROPOSAL_OFFCHAIN | 0x00
+	PROPOSAL_ONCHAIN         = 0x0100
+	PROPOSAL_OFFCHAIN        = 0x0200
+	PROPOSAL_GOVPARAMS       = PROPOSAL_ONCHAIN | 0x01
+	PROPOSAL_UPGRADE         = PROPOSAL_ONCHAIN | 0x02
+	PROPOSAL_COMMUNITY_SPEND = PROPOSAL_ONCHAIN | 0x03
+	PROPOSAL_COMMON          = PROPOSAL_OFFCHAIN | 0x00
 )
 
 const (
@@ -0,0 +1,74 @@
+package gov
+
+import (
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/ledger"
+	"github.com/rigochain/rigo-go/types/bytes"
+	"github.com/rigochain/rigo-go/types/xerrors"
+)
+
+const (
+	snapshotStoreParams    = "gov_params"
+	snapshotStoreProposals = "proposal"
+	snapshotStoreFrozen    = "frozen_proposal"
+	snapshotStoreSpends    = "community_spends"
+)
+
+func (ctrler *GovCtrler) Snapshot(height int64) (ctrlertypes.SnapshotExporter, xerrors.XError) {
+	ctrler.mtx.RLock()
+	defer ctrler.mtx.RUnlock()
+
+	paramsLedger, proposalLedger, frozenLedger, spendLedger := ctrler.paramsLedger, ctrler.proposalLedger, ctrler.frozenLedger, ctrler.spendLedger
+
+	return func(cb func(*ctrlertypes.SnapshotItemProto) xerrors.XError) xerrors.XError {
+		if xerr := ctrlertypes.ExportLedgerSnapshot(snapshotStoreParams, height, paramsLedger, cb); xerr != nil {
+			return xerr
+		}
+		if xerr := ctrlertypes.ExportLedgerSnapshot(snapshotStoreProposals, height, proposalLedger, cb); xerr != nil {
+			return xerr
+		}
+		if xerr := ctrlertypes.ExportLedgerSnapshot(snapshotStoreFrozen, height, frozenLedger, cb); xerr != nil {
+			return xerr
+		}
+		return ctrlertypes.ExportLedgerSnapshot(snapshotStoreSpends, height, spendLedger, cb)
+	}, nil
+}
+
+func (ctrler *GovCtrler) RestoreSnapshot(height int64, items ctrlertypes.SnapshotItems) ([]byte, xerrors.XError) {
+	ctrler.mtx.Lock()
+	defer ctrler.mtx.Unlock()
+
+	h0, xerr := ctrlertypes.ImportLedgerSnapshot(snapshotStoreParams, height, ctrler.paramsLedger, items)
+	if xerr != nil {
+		return nil, xerr
+	}
+	h1, xerr := ctrlertypes.ImportLedgerSnapshot(snapshotStoreProposals, height, ctrler.proposalLedger, items)
+	if xerr != nil {
+		return nil, xerr
+	}
+	h2, xerr := ctrlertypes.ImportLedgerSnapshot(snapshotStoreFrozen, height, ctrler.frozenLedger, items)
+	if xerr != nil {
+		return nil, xerr
+	}
+	h3, xerr := ctrlertypes.ImportLedgerSnapshot(snapshotStoreSpends, height, ctrler.spendLedger, items)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	params, xerr := ctrler.paramsLedger.Get(ledger.ToLedgerKey(bytes.ZeroBytes(32)))
+	if xerr != nil {
+		return nil, xerrors.ErrSnapshot.Wrapf("not found governance parameters: %v", xerr)
+	}
+	bz, xerr := params.Encode()
+	if xerr != nil {
+		return nil, xerr
+	}
+	if xerr := ctrler.GovParams.Decode(bz); xerr != nil {
+		return nil, xerr
+	}
+	ctrler.newGovParams = nil
+
+	return rootHashes(h0, h1, h2, h3).Hash(), nil
+}
+
+var _ ctrlertypes.ISnapshotHandler = (*GovCtrler)(nil)
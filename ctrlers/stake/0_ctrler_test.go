@@ -123,6 +123,7 @@ func TestTrxStakingToSelf(t *testing.T) {
 		require.NoError(t, err)
 		err = stakeCtrler.ExecuteTrx(txctx)
 		require.NoError(t, err)
+		require.NotNil(t, findEvent(txctx.Events, ctrlertypes.EVENT_TYPE_STAKE_CREATED, ctrlertypes.EVENT_ATTR_TXHASH, txctx.TxHash.String()))
 
 		_ = sumAmt.Add(sumAmt, txctx.Tx.Amount)
 		sumPower += ctrlertypes.AmountToPower(txctx.Tx.Amount)
@@ -330,6 +331,10 @@ func TestUnstakingByTx(t *testing.T) {
 		err = stakeCtrler.ExecuteTrx(txctx)
 		require.NoError(t, err)
 
+		evt := findEvent(txctx.Events, ctrlertypes.EVENT_TYPE_STAKE_FROZEN, ctrlertypes.EVENT_ATTR_TXHASH, stakingTxHash.String())
+		require.NotNil(t, evt)
+		require.NotNil(t, findEvent([]abcitypes.Event{*evt}, ctrlertypes.EVENT_TYPE_STAKE_FROZEN, ctrlertypes.EVENT_ATTR_REASON, ctrlertypes.EVENT_REASON_UNSTAKING))
+
 		sumUnstakingPower += s0.Power
 	}
 
@@ -346,6 +351,8 @@ func TestUnstakingByTx(t *testing.T) {
 		sumFrozenPower += s.Power
 	}
 	require.Equal(t, sumFrozenPower, sumUnstakingPower)
+	require.Equal(t, ctrlertypes.PowerToAmount(sumFrozenPower), stakeCtrler.TotalFrozenAmount())
+	require.Equal(t, stakeCtrler.ReadTotalRewards(), stakeCtrler.TotalRewardAmount())
 }
 
 func TestUnfreezing(t *testing.T) {
@@ -381,10 +388,16 @@ func TestUnfreezing(t *testing.T) {
 		//new(uint256.Int).Add(s0.Amount, s0.ReceivedReward))
 	}
 
+	// the events of the refunded stakes
+	var evts []abcitypes.Event
+
 	toBlockHeight := mocks.LastBlockHeight() + govParams00.LazyRewardBlocks()
 	for mocks.LastBlockHeight() < toBlockHeight {
-		require.NoError(t, doEndBlockCommit())
+		_evts, xerr := stakeCtrler.EndBlock(mocks.LastBlockCtx())
+		require.NoError(t, xerr)
+		require.NoError(t, doCommitBlock())
 		require.NoError(t, doBeginBlock())
+		evts = append(evts, _evts...)
 	}
 
 	// execute block at lastHeight
@@ -398,10 +411,19 @@ func TestUnfreezing(t *testing.T) {
 
 	mocks.InitBlockCtx(bctx)
 
-	require.NoError(t, doEndBlockCommit())
+	_evts, xerr := stakeCtrler.EndBlock(bctx)
+	require.NoError(t, xerr)
+	require.NoError(t, doCommitBlock())
+	evts = append(evts, _evts...)
+
+	for _, s0 := range frozenStakes {
+		require.NotNil(t, findEvent(evts, ctrlertypes.EVENT_TYPE_STAKE_REFUNDED, ctrlertypes.EVENT_ATTR_TXHASH, s0.TxHash.String()))
+	}
 
 	frozenStakes = stakeCtrler.ReadFrozenStakes()
 	require.Equal(t, 0, len(frozenStakes))
+	require.True(t, stakeCtrler.TotalFrozenAmount().IsZero())
+	require.Equal(t, stakeCtrler.ReadTotalRewards(), stakeCtrler.TotalRewardAmount())
 
 	for _, er := range expectedRefunds {
 		acct1 := acctMock00.FindAccount(er.addr, true)
@@ -442,3 +464,18 @@ func doEndBlockCommit() error {
 	}
 	return nil
 }
+
+// findEvent returns the event of `evtType` which has the attribute `key` of `value`.
+func findEvent(evts []abcitypes.Event, evtType, key, value string) *abcitypes.Event {
+	for i, evt := range evts {
+		if evt.Type != evtType {
+			continue
+		}
+		for _, attr := range evt.Attributes {
+			if string(attr.Key) == key && string(attr.Value) == value {
+				return &evts[i]
+			}
+		}
+	}
+	return nil
+}
@@ -19,6 +19,12 @@ func randMakeStakingToSelfTrxContext() (*ctrlertypes.TrxContext, error) {
 	if txCtx, err := makeStakingTrxContext(from, to, power); err != nil {
 		return nil, err
 	} else {
+		// `RandWallet` may return the same wallet again, but a delegatee is listed only once.
+		for _, w := range DelegateeWallets {
+			if bytes.Compare(w.Address(), to.Address()) == 0 {
+				return txCtx, nil
+			}
+		}
 		DelegateeWallets = append(DelegateeWallets, to)
 		return txCtx, nil
 	}
@@ -0,0 +1,127 @@
+package stake
+
+import (
+	"bytes"
+	"github.com/holiman/uint256"
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/ledger"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"strconv"
+)
+
+// commissionChangeInterval is the minimum interval(seconds) between the changes of a commission rate.
+const commissionChangeInterval = int64(24 * 60 * 60)
+
+// Commission is the share(%) of the rewards of the stakes delegated to a validator, which is given to the validator.
+// `MaxRate` and `MaxChangeRate` are never changed after the commission is set first,
+// and `Rate` is changed by up to `MaxChangeRate` once in `commissionChangeInterval`.
+type Commission struct {
+	Rate          int64 `json:"rate"`
+	MaxRate       int64 `json:"maxRate"`
+	MaxChangeRate int64 `json:"maxChangeRate"`
+	// the block time(seconds) when `Rate` is changed last.
+	UpdateTime int64 `json:"updateTime"`
+}
+
+// Split returns the commission taken from `rwd`.
+func (c *Commission) Split(rwd *uint256.Int) *uint256.Int {
+	if c == nil || c.Rate <= 0 {
+		return uint256.NewInt(0)
+	}
+	commission := new(uint256.Int).Mul(rwd, uint256.NewInt(uint64(c.Rate)))
+	return commission.Div(commission, uint256.NewInt(100))
+}
+
+// validateCommission checks the new commission of `payload` against the current commission `c`, which may be nil.
+func validateCommission(c *Commission, payload *ctrlertypes.TrxPayloadCommission, now int64) xerrors.XError {
+	if c == nil {
+		if payload.MaxRate < 0 || payload.MaxRate > 100 {
+			return xerrors.ErrInvalidTrxPayloadParams.Wrapf("the max rate(%v) should be in [0, 100]", payload.MaxRate)
+		}
+		if payload.MaxChangeRate < 0 || payload.MaxChangeRate > payload.MaxRate {
+			return xerrors.ErrInvalidTrxPayloadParams.Wrapf("the max change rate(%v) should be in [0, %v]", payload.MaxChangeRate, payload.MaxRate)
+		}
+		if payload.Rate < 0 || payload.Rate > payload.MaxRate {
+			return xerrors.ErrInvalidTrxPayloadParams.Wrapf("the rate(%v) should be in [0, %v]", payload.Rate, payload.MaxRate)
+		}
+		return nil
+	}
+
+	if payload.MaxRate != c.MaxRate || payload.MaxChangeRate != c.MaxChangeRate {
+		return xerrors.ErrInvalidTrxPayloadParams.Wrapf("the max rate and the max change rate can not be changed")
+	}
+	if payload.Rate < 0 || payload.Rate > c.MaxRate {
+		return xerrors.ErrInvalidTrxPayloadParams.Wrapf("the rate(%v) should be in [0, %v]", payload.Rate, c.MaxRate)
+	}
+	diff := payload.Rate - c.Rate
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff > c.MaxChangeRate {
+		return xerrors.ErrInvalidTrxPayloadParams.Wrapf("the rate can be changed by up to %v", c.MaxChangeRate)
+	}
+	if now < c.UpdateTime+commissionChangeInterval {
+		return xerrors.ErrInvalidTrxPayloadParams.Wrapf("the rate can not be changed until %v", c.UpdateTime+commissionChangeInterval)
+	}
+	return nil
+}
+
+func (ctrler *StakeCtrler) validateCommissionTrx(ctx *ctrlertypes.TrxContext) xerrors.XError {
+	if ctx.Tx.Amount.Sign() != 0 {
+		return xerrors.ErrInvalidTrx.Wrapf("amount must be 0")
+	}
+	payload, ok := ctx.Tx.Payload.(*ctrlertypes.TrxPayloadCommission)
+	if !ok {
+		return xerrors.ErrInvalidTrxPayloadType
+	}
+	if bytes.Compare(ctx.Tx.From, ctx.Tx.To) != 0 {
+		return xerrors.ErrInvalidTrx.Wrapf("the commission is set by the validator itself")
+	}
+
+	getDelegatee := ctrler.delegateeLedger.Get
+	if ctx.Exec {
+		getDelegatee = ctrler.delegateeLedger.GetFinality
+	}
+	delegatee, xerr := getDelegatee(ledger.ToLedgerKey(ctx.Tx.From))
+	if xerr == xerrors.ErrNotFoundResult {
+		return xerrors.ErrNotFoundDelegatee.Wrapf("address(%v)", ctx.Tx.From)
+	} else if xerr != nil {
+		return xerr
+	}
+	return validateCommission(delegatee.GetCommission(), payload, ctx.BlockTime)
+}
+
+func (ctrler *StakeCtrler) exeCommission(ctx *ctrlertypes.TrxContext) xerrors.XError {
+	getDelegatee := ctrler.delegateeLedger.Get
+	setUpdateDelegatee := ctrler.delegateeLedger.Set
+	if ctx.Exec {
+		getDelegatee = ctrler.delegateeLedger.GetFinality
+		setUpdateDelegatee = ctrler.delegateeLedger.SetFinality
+	}
+
+	delegatee, xerr := getDelegatee(ledger.ToLedgerKey(ctx.Tx.From))
+	if xerr != nil {
+		return xerr
+	}
+
+	payload := ctx.Tx.Payload.(*ctrlertypes.TrxPayloadCommission)
+	delegatee.SetCommission(&Commission{
+		Rate:          payload.Rate,
+		MaxRate:       payload.MaxRate,
+		MaxChangeRate: payload.MaxChangeRate,
+		UpdateTime:    ctx.BlockTime,
+	})
+	if xerr := setUpdateDelegatee(delegatee); xerr != nil {
+		return xerr
+	}
+
+	ctx.Events = append(ctx.Events, abcitypes.Event{
+		Type: ctrlertypes.EVENT_TYPE_COMMISSION,
+		Attributes: []abcitypes.EventAttribute{
+			{Key: []byte(ctrlertypes.EVENT_ATTR_DELEGATEE), Value: []byte(delegatee.Addr.String()), Index: true},
+			{Key: []byte(ctrlertypes.EVENT_ATTR_RATE), Value: []byte(strconv.FormatInt(payload.Rate, 10)), Index: false},
+		},
+	})
+	return nil
+}
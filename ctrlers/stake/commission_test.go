@@ -0,0 +1,46 @@
+package stake
+
+import (
+	"github.com/holiman/uint256"
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCommission_Split(t *testing.T) {
+	var c *Commission
+	require.Equal(t, uint256.NewInt(0), c.Split(uint256.NewInt(1000)))
+
+	c = &Commission{Rate: 7, MaxRate: 20, MaxChangeRate: 1}
+	require.Equal(t, uint256.NewInt(70), c.Split(uint256.NewInt(1000)))
+	require.Equal(t, uint256.NewInt(0), c.Split(uint256.NewInt(14)))
+}
+
+func TestValidateCommission(t *testing.T) {
+	cases := []struct {
+		payload ctrlertypes.TrxPayloadCommission
+		ok      bool
+	}{
+		{ctrlertypes.TrxPayloadCommission{Rate: 10, MaxRate: 20, MaxChangeRate: 5}, true},
+		{ctrlertypes.TrxPayloadCommission{Rate: 0, MaxRate: 0, MaxChangeRate: 0}, true},
+		{ctrlertypes.TrxPayloadCommission{Rate: 100, MaxRate: 100, MaxChangeRate: 100}, true},
+		{ctrlertypes.TrxPayloadCommission{Rate: 10, MaxRate: 101, MaxChangeRate: 5}, false},
+		{ctrlertypes.TrxPayloadCommission{Rate: 21, MaxRate: 20, MaxChangeRate: 5}, false},
+		{ctrlertypes.TrxPayloadCommission{Rate: -1, MaxRate: 20, MaxChangeRate: 5}, false},
+		{ctrlertypes.TrxPayloadCommission{Rate: 10, MaxRate: 20, MaxChangeRate: 21}, false},
+	}
+	for i, c := range cases {
+		xerr := validateCommission(nil, &c.payload, 0)
+		require.Equal(t, c.ok, xerr == nil, "case %d: %v", i, xerr)
+	}
+
+	now := int64(1_000_000)
+	current := &Commission{Rate: 10, MaxRate: 20, MaxChangeRate: 5, UpdateTime: now}
+	next := now + commissionChangeInterval
+	require.Error(t, validateCommission(current, &ctrlertypes.TrxPayloadCommission{Rate: 15, MaxRate: 20, MaxChangeRate: 5}, next-1))
+	require.NoError(t, validateCommission(current, &ctrlertypes.TrxPayloadCommission{Rate: 15, MaxRate: 20, MaxChangeRate: 5}, next))
+	require.NoError(t, validateCommission(current, &ctrlertypes.TrxPayloadCommission{Rate: 5, MaxRate: 20, MaxChangeRate: 5}, next))
+	require.Error(t, validateCommission(current, &ctrlertypes.TrxPayloadCommission{Rate: 16, MaxRate: 20, MaxChangeRate: 5}, next))
+	require.Error(t, validateCommission(current, &ctrlertypes.TrxPayloadCommission{Rate: 10, MaxRate: 30, MaxChangeRate: 5}, next))
+	require.Error(t, validateCommission(current, &ctrlertypes.TrxPayloadCommission{Rate: 10, MaxRate: 20, MaxChangeRate: 6}, next))
+}
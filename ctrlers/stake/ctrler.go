@@ -9,7 +9,6 @@ import (
 	"github.com/rigochain/rigo-go/libs"
 	"github.com/rigochain/rigo-go/types"
 	"github.com/rigochain/rigo-go/types/bytes"
-	"github.com/rigochain/rigo-go/types/crypto"
 	"github.com/rigochain/rigo-go/types/xerrors"
 	abcitypes "github.com/tendermint/tendermint/abci/types"
 	tmlog "github.com/tendermint/tendermint/libs/log"
@@ -31,10 +30,13 @@ type StakeCtrler struct {
 	delegateeLedger   ledger.IFinalityLedger[*Delegatee]
 	frozenLedger      ledger.IFinalityLedger[*Stake]
 	rewardLedger      ledger.IFinalityLedger[*Reward]
+	slashLedger       ledger.IFinalityLedger[*SlashHistory]
+	pastRootLedger    ledger.IFinalityLedger[*PastRoots]
 	rwdLedgUpInterval int64
 	lastRwdHash       []byte
 	stakeLimiter      *StakeLimiter
 	govParams         ctrlertypes.IGovHandler
+	totals            totals
 
 	logger tmlog.Logger
 	mtx    sync.RWMutex
@@ -49,19 +51,34 @@ func NewStakeCtrler(config *cfg.Config, govHandler ctrlertypes.IGovHandler, logg
 	newDelegateeProvider := func() *Delegatee { return &Delegatee{} }
 	newStakeProvider := func() *Stake { return &Stake{} }
 	newRewardProvider := func() *Reward { return &Reward{} }
+	newSlashHistoryProvider := func() *SlashHistory { return &SlashHistory{} }
+
+	pruning := ctrlertypes.PruningOptionOf(config.App)
 
 	// for all delegatees
-	delegateeLedger, xerr := ledger.NewFinalityLedger[*Delegatee]("delegatees", config.DBDir(), 128, newDelegateeProvider)
+	delegateeLedger, xerr := ledger.NewFinalityLedger[*Delegatee]("delegatees", config.DBDir(), 128, pruning, newDelegateeProvider)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	frozenLedger, xerr := ledger.NewFinalityLedger[*Stake]("frozen", config.DBDir(), 128, pruning, newStakeProvider)
 	if xerr != nil {
 		return nil, xerr
 	}
 
-	frozenLedger, xerr := ledger.NewFinalityLedger[*Stake]("frozen", config.DBDir(), 128, newStakeProvider)
+	rewardLedger, xerr := ledger.NewFinalityLedger[*Reward]("rewards", config.DBDir(), 2048, pruning, newRewardProvider)
 	if xerr != nil {
 		return nil, xerr
 	}
 
-	rewardLedger, xerr := ledger.NewFinalityLedger[*Reward]("rewards", config.DBDir(), 2048, newRewardProvider)
+	// for the slashing history of each validator
+	slashLedger, xerr := ledger.NewFinalityLedger[*SlashHistory]("slashes", config.DBDir(), 128, pruning, newSlashHistoryProvider)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	// for the root hashes of the past versions of `delegateeLedger`
+	pastRootLedger, xerr := ledger.NewFinalityLedger[*PastRoots]("delegatee_roots", config.DBDir(), 1, pruning, func() *PastRoots { return &PastRoots{} })
 	if xerr != nil {
 		return nil, xerr
 	}
@@ -71,7 +88,9 @@ func NewStakeCtrler(config *cfg.Config, govHandler ctrlertypes.IGovHandler, logg
 		delegateeLedger:   delegateeLedger,
 		frozenLedger:      frozenLedger,
 		rewardLedger:      rewardLedger,
-		rwdLedgUpInterval: int64(10),
+		slashLedger:       slashLedger,
+		pastRootLedger:    pastRootLedger,
+		rwdLedgUpInterval: cfg.RewardHashInterval,
 		lastRwdHash:       rwdHashDB.LastRewardHash(),
 		stakeLimiter:      NewStakeLimiter(nil, govHandler.MaxValidatorCnt(), govHandler.MaxIndividualStakeRatio(), govHandler.MaxUpdatableStakeRatio()),
 		govParams:         govHandler,
@@ -89,9 +108,14 @@ func (ctrler *StakeCtrler) InitLedger(req interface{}) xerrors.XError {
 	ctrler.mtx.Lock()
 	defer ctrler.mtx.Unlock()
 
+	if state, ok := req.(*GenesisState); ok {
+		// the state exported from a running network
+		return ctrler.importGenesis(state)
+	}
+
 	initStakes, ok := req.([]*InitStake)
 	if !ok {
-		return xerrors.ErrInitChain.Wrapf("wrong parameter: StakeCtrler::InitLedger() requires []*InitStake")
+		return xerrors.ErrInitChain.Wrapf("wrong parameter: StakeCtrler::InitLedger() requires []*InitStake or *GenesisState")
 	}
 
 	for _, initS0 := range initStakes {
@@ -126,8 +150,9 @@ func (ctrler *StakeCtrler) BeginBlock(blockCtx *ctrlertypes.BlockContext) ([]abc
 	if xerr := ctrler.delegateeLedger.IterateReadAllFinalityItems(func(d *Delegatee) xerrors.XError {
 		// issue #59
 		// Only delegatee who have deposited more than `MinValidatorStake` can become validator.
+		// A jailed delegatee can not be a validator until it is released.
 		minPower := ctrlertypes.AmountToPower(ctrler.govParams.MinValidatorStake())
-		if d.SelfPower >= minPower {
+		if d.SelfPower >= minPower && !d.Jailed {
 			ctrler.allDelegatees = append(ctrler.allDelegatees, d)
 		}
 		return nil
@@ -147,16 +172,19 @@ func (ctrler *StakeCtrler) BeginBlock(blockCtx *ctrlertypes.BlockContext) ([]abc
 	var evts []abcitypes.Event
 
 	// Slashing
-	byzantines := blockCtx.BlockInfo().ByzantineValidators
+	byzantines := blockCtx.ByzantineValidators()
 	if byzantines != nil && len(byzantines) > 0 {
 		ctrler.logger.Info("StakeCtrler: Byzantine validators is found", "count", len(byzantines))
 		for _, evi := range byzantines {
-			if slashed, xerr := ctrler.doPunish(
-				&evi, blockCtx.GovHandler.SlashRatio()); xerr != nil {
+			if slashed, removed, xerr := ctrler.doPunish(
+				&evi, blockCtx.GovHandler.SlashRatio(), blockCtx.Height()); xerr != nil {
 				ctrler.logger.Error("Error when punishing",
 					"byzantine", types.Address(evi.Validator.Address),
-					"evidenceType", abcitypes.EvidenceType_name[int32(evi.Type)])
+					"evidenceType", abcitypes.EvidenceType_name[int32(evi.Type)],
+					"error", xerr)
 			} else {
+				// the power taken from the stakes is removed from the supply.
+				blockCtx.AddBurned(ctrlertypes.PowerToAmount(removed))
 				evts = append(evts, abcitypes.Event{
 					Type: "punishment.stake",
 					Attributes: []abcitypes.EventAttribute{
@@ -238,28 +266,38 @@ func (ctrler *StakeCtrler) BeginBlock(blockCtx *ctrlertypes.BlockContext) ([]abc
 			}
 			notSigned := delegatee.GetNotSignedBlockCount(s, signedHeight)
 
-			if ctrler.govParams.SignedBlocksWindow()-int64(notSigned) < ctrler.govParams.MinSignedBlocks() {
-				// Stop validator: do un-staking all
+			if !delegatee.IsJailed() &&
+				ctrler.govParams.SignedBlocksWindow()-int64(notSigned) < ctrler.govParams.MinSignedBlocks() {
+				// Jail validator: it is excluded from the validators, but the stakes delegated to it are kept.
+				releaseHeight := blockCtx.Height() + ctrler.govParams.JailBlocks()
 
-				ctrler.logger.Info("Validator stop",
+				ctrler.logger.Info("Validator jailed",
 					"address", types.Address(vote.Validator.Address),
 					"power", vote.Validator.Power,
 					"from", s, "to", signedHeight,
 					"signed_blocks_window", ctrler.govParams.SignedBlocksWindow(),
 					"signed_blocks", ctrler.govParams.SignedBlocksWindow()-int64(notSigned),
-					"missed_blocks", notSigned)
+					"missed_blocks", notSigned,
+					"release_height", releaseHeight)
 
-				stakes := delegatee.DelAllStakes()
-				for _, _s0 := range stakes {
-					_s0.RefundHeight = blockCtx.Height() + ctrler.govParams.LazyRewardBlocks()
-					_ = ctrler.frozenLedger.SetFinality(_s0) // add s0 to frozen ledger
-				}
+				delegatee.Jail(releaseHeight)
+				_ = ctrler.delegateeLedger.SetFinality(delegatee)
 
-				_, _ = ctrler.delegateeLedger.DelFinality(delegatee.Key())
+				evts = append(evts, abcitypes.Event{
+					Type: ctrlertypes.EVENT_TYPE_VALIDATOR_JAILED,
+					Attributes: []abcitypes.EventAttribute{
+						{Key: []byte(ctrlertypes.EVENT_ATTR_DELEGATEE), Value: []byte(delegatee.Addr.String()), Index: true},
+						{Key: []byte(ctrlertypes.EVENT_ATTR_POWER), Value: []byte(strconv.FormatInt(delegatee.TotalPower, 10)), Index: false},
+						{Key: []byte(ctrlertypes.EVENT_ATTR_MISSED_BLOCKS), Value: []byte(strconv.Itoa(notSigned)), Index: false},
+						{Key: []byte(ctrlertypes.EVENT_ATTR_RELEASE_HEIGHT), Value: []byte(strconv.FormatInt(releaseHeight, 10)), Index: false},
+					},
+				})
 			}
 		}
 	}
 
+	blockCtx.AddIssued(issuedReward)
+
 	evts = append(evts, abcitypes.Event{
 		Type: "reward",
 		Attributes: []abcitypes.EventAttribute{
@@ -270,24 +308,57 @@ func (ctrler *StakeCtrler) BeginBlock(blockCtx *ctrlertypes.BlockContext) ([]abc
 	return evts, nil
 }
 
+// DoPunish slashes the stakes for `evi` out of a block.
+// Without the current height, the redelegated stakes are checked against the unbonding window at `evi.Height`.
 func (ctrler *StakeCtrler) DoPunish(evi *abcitypes.Evidence, slashRatio int64) (int64, xerrors.XError) {
 	ctrler.mtx.Lock()
 	defer ctrler.mtx.Unlock()
 
-	return ctrler.doPunish(evi, slashRatio)
+	slashed, _, xerr := ctrler.doPunish(evi, slashRatio, evi.Height)
+	return slashed, xerr
 }
 
-func (ctrler *StakeCtrler) doPunish(evi *abcitypes.Evidence, slashRatio int64) (int64, xerrors.XError) {
+// doPunish slashes the stakes delegated to the byzantine validator
+// and the stakes redelegated from it within the unbonding window.
+// It returns the slashed power and the power removed from the delegatees.
+// The latter includes the power of the stakes, which are too small to be slashed and are removed entirely.
+// The slashing is recorded in `slashLedger`, and the same misbehavior is not punished again.
+func (ctrler *StakeCtrler) doPunish(evi *abcitypes.Evidence, slashRatio, height int64) (int64, int64, xerrors.XError) {
+	if ctrler.isPunished(evi) {
+		return 0, 0, xerrors.ErrDuplicatedKey.Wrapf("the evidence(%v) at %v has been already handled",
+			abcitypes.EvidenceType_name[int32(evi.Type)], evi.Height)
+	}
+
+	slashed, removed := int64(0), int64(0)
+	slashing := newSlashing(evi, height, slashRatio)
+
 	delegatee, xerr := ctrler.delegateeLedger.GetFinality(ledger.ToLedgerKey(evi.Validator.Address))
-	if xerr != nil {
-		return 0, xerr
+	if xerr != nil && xerr != xerrors.ErrNotFoundResult {
+		return 0, 0, xerr
+	}
+	if delegatee != nil {
+		// Punish the delegators as well as validator. issue #51
+		power, before := delegatee.TotalPower, stakePowers(delegatee)
+		slashed = delegatee.DoSlash(slashRatio)
+		_ = ctrler.delegateeLedger.SetFinality(delegatee)
+		removed = power - delegatee.TotalPower
+		slashing.Stakes = slashedStakes(before, delegatee)
 	}
 
-	// Punish the delegators as well as validator. issue #51
-	slashed := delegatee.DoSlash(slashRatio)
-	_ = ctrler.delegateeLedger.SetFinality(delegatee)
+	slashed0, removed0, stakes0, xerr0 := ctrler.slashRedelegated(evi.Validator.Address, evi.Height, height, slashRatio)
+	if xerr0 != nil {
+		return 0, 0, xerr0
+	}
+	if delegatee == nil && removed0 == 0 {
+		// the validator has been removed and no stake has been redelegated from it.
+		return 0, 0, xerr
+	}
+	slashing.Stakes = append(slashing.Stakes, stakes0...)
 
-	return slashed, nil
+	if xerr := ctrler.addSlashing(evi.Validator.Address, slashing); xerr != nil {
+		return 0, 0, xerr
+	}
+	return slashed + slashed0, removed + removed0, nil
 }
 
 func (ctrler *StakeCtrler) DoReward(height int64, votes []abcitypes.VoteInfo) (*uint256.Int, xerrors.XError) {
@@ -335,6 +406,7 @@ func (ctrler *StakeCtrler) DoReward(height int64, votes []abcitypes.VoteInfo) (*
 func (ctrler *StakeCtrler) doRewardTo(delegatee *Delegatee, height int64) (*uint256.Int, xerrors.XError) {
 
 	issuedReward := uint256.NewInt(0)
+	commission := uint256.NewInt(0)
 
 	for _, s0 := range delegatee.Stakes {
 		rwdObj, xerr := ctrler.rewardLedger.GetFinality(ledger.ToLedgerKey(s0.From))
@@ -347,6 +419,12 @@ func (ctrler *StakeCtrler) doRewardTo(delegatee *Delegatee, height int64) (*uint
 
 		power := uint256.NewInt(uint64(s0.Power))
 		rwd := new(uint256.Int).Mul(power, ctrler.govParams.RewardPerPower())
+		if bytes.Compare(s0.From, delegatee.Addr) != 0 {
+			// the validator takes its commission from the rewards of the delegators.
+			c := delegatee.Commission.Split(rwd)
+			_ = rwd.Sub(rwd, c)
+			_ = commission.Add(commission, c)
+		}
 		_ = rwdObj.Issue(rwd, height)
 
 		if xerr := ctrler.rewardLedger.SetFinality(rwdObj); xerr != nil {
@@ -357,6 +435,22 @@ func (ctrler *StakeCtrler) doRewardTo(delegatee *Delegatee, height int64) (*uint
 		_ = issuedReward.Add(issuedReward, rwd)
 	}
 
+	if commission.Sign() > 0 {
+		rwdObj, xerr := ctrler.rewardLedger.GetFinality(ledger.ToLedgerKey(delegatee.Addr))
+		if xerr == xerrors.ErrNotFoundResult {
+			rwdObj = NewReward(delegatee.Addr)
+		} else if xerr != nil {
+			ctrler.logger.Error("fail to find reward object of", delegatee.Addr)
+			return issuedReward, xerr
+		}
+		_ = rwdObj.IssueCommission(commission, height)
+		if xerr := ctrler.rewardLedger.SetFinality(rwdObj); xerr != nil {
+			ctrler.logger.Error("fail to give commission to", delegatee.Addr, "err:", xerr)
+			return issuedReward, xerr
+		}
+		_ = issuedReward.Add(issuedReward, commission)
+	}
+
 	return issuedReward, nil
 }
 
@@ -391,6 +485,12 @@ func (ctrler *StakeCtrler) ValidateTrx(ctx *ctrlertypes.TrxContext) xerrors.XErr
 		if bytes.Compare(ctx.Tx.From, ctx.Tx.To) == 0 {
 			// self staking
 
+			// a validator is the account of its consensus key,
+			// so a multi-signature account, which has no single key, can only delegate.
+			if delegatee == nil && ctx.Sender.GetMultiSig() != nil {
+				return xerrors.ErrInvalidTrx.Wrapf("a multi-signature account can not be a validator")
+			}
+
 			// isseu #59
 			// check MinValidatorStake
 
@@ -457,8 +557,13 @@ func (ctrler *StakeCtrler) ValidateTrx(ctx *ctrlertypes.TrxContext) xerrors.XErr
 			return xerr
 		}
 
+		payload := ctx.Tx.Payload.(*ctrlertypes.TrxPayloadUnstaking)
+		if payload.Amount != nil {
+			return ctrler.validateUnstakingAmount(ctx, delegatee, payload)
+		}
+
 		// find the stake from a delegatee
-		txhash := ctx.Tx.Payload.(*ctrlertypes.TrxPayloadUnstaking).TxHash
+		txhash := payload.TxHash
 		if txhash == nil || len(txhash) != 32 {
 			return xerrors.ErrInvalidTrxPayloadParams
 		}
@@ -500,6 +605,12 @@ func (ctrler *StakeCtrler) ValidateTrx(ctx *ctrlertypes.TrxContext) xerrors.XErr
 		if txpayload.ReqAmt.Cmp(rwd.cumulated) > 0 {
 			return xerrors.ErrInvalidTrx.Wrapf("insufficient reward")
 		}
+	case ctrlertypes.TRX_COMMISSION:
+		return ctrler.validateCommissionTrx(ctx)
+	case ctrlertypes.TRX_REDELEGATE:
+		return ctrler.validateRedelegate(ctx)
+	case ctrlertypes.TRX_UNJAIL:
+		return ctrler.validateUnjail(ctx)
 	default:
 		return xerrors.ErrUnknownTrxType
 	}
@@ -520,6 +631,12 @@ func (ctrler *StakeCtrler) ExecuteTrx(ctx *ctrlertypes.TrxContext) xerrors.XErro
 		return ctrler.exeUnstaking(ctx)
 	case ctrlertypes.TRX_WITHDRAW:
 		return ctrler.exeWithdraw(ctx)
+	case ctrlertypes.TRX_COMMISSION:
+		return ctrler.exeCommission(ctx)
+	case ctrlertypes.TRX_REDELEGATE:
+		return ctrler.exeRedelegate(ctx)
+	case ctrlertypes.TRX_UNJAIL:
+		return ctrler.exeUnjail(ctx)
 	default:
 		return xerrors.ErrUnknownTrxType
 	}
@@ -566,6 +683,7 @@ func (ctrler *StakeCtrler) exeStaking(ctx *ctrlertypes.TrxContext) xerrors.XErro
 		return xerr
 	}
 
+	ctx.Events = append(ctx.Events, stakeCreatedEvent(s0))
 	return nil
 }
 
@@ -587,33 +705,42 @@ func (ctrler *StakeCtrler) exeUnstaking(ctx *ctrlertypes.TrxContext) xerrors.XEr
 		return xerr
 	}
 
-	// delete the stake from a delegatee
-	txhash := ctx.Tx.Payload.(*ctrlertypes.TrxPayloadUnstaking).TxHash
-	if txhash == nil || len(txhash) != 32 {
-		return xerrors.ErrInvalidTrxPayloadParams
-	}
+	payload := ctx.Tx.Payload.(*ctrlertypes.TrxPayloadUnstaking)
+	if payload.Amount != nil {
+		if xerr := ctrler.exeUnstakingAmount(ctx, delegatee, payload, setUpdateFrozen); xerr != nil {
+			return xerr
+		}
+	} else {
+		// delete the stake from a delegatee
+		txhash := payload.TxHash
+		if txhash == nil || len(txhash) != 32 {
+			return xerrors.ErrInvalidTrxPayloadParams
+		}
 
-	_, s0 := delegatee.FindStake(txhash)
-	if s0 == nil {
-		return xerrors.ErrNotFoundStake
-	}
+		_, s0 := delegatee.FindStake(txhash)
+		if s0 == nil {
+			return xerrors.ErrNotFoundStake
+		}
 
-	// issue #43
-	// check that tx's sender is stake's owner
-	if ctx.Tx.From.Compare(s0.From) != 0 {
-		return xerrors.ErrNotFoundStake.Wrapf("you not stake owner")
-	}
+		// issue #43
+		// check that tx's sender is stake's owner
+		if ctx.Tx.From.Compare(s0.From) != 0 {
+			return xerrors.ErrNotFoundStake.Wrapf("you not stake owner")
+		}
 
-	_ = delegatee.DelStake(txhash)
+		_ = delegatee.DelStake(txhash)
 
-	s0.RefundHeight = ctx.Height + ctx.GovHandler.LazyRewardBlocks()
-	_ = setUpdateFrozen(s0) // add s0 to frozen ledger
+		s0.RefundHeight = ctx.Height + ctx.GovHandler.LazyRewardBlocks()
+		_ = setUpdateFrozen(s0) // add s0 to frozen ledger
+		ctx.Events = append(ctx.Events, stakeFrozenEvent(s0, ctrlertypes.EVENT_REASON_UNSTAKING))
+	}
 
 	if delegatee.SelfPower == 0 {
 		stakes := delegatee.DelAllStakes()
 		for _, _s0 := range stakes {
 			_s0.RefundHeight = ctx.Height + ctx.GovHandler.LazyRewardBlocks()
 			_ = setUpdateFrozen(_s0) // add s0 to frozen ledger
+			ctx.Events = append(ctx.Events, stakeFrozenEvent(_s0, ctrlertypes.EVENT_REASON_DELEGATEE_UNSTAKED))
 		}
 	}
 
@@ -668,6 +795,13 @@ func (ctrler *StakeCtrler) exeWithdraw(ctx *ctrlertypes.TrxContext) xerrors.XErr
 		return xerr
 	}
 
+	ctx.Events = append(ctx.Events, abcitypes.Event{
+		Type: ctrlertypes.EVENT_TYPE_WITHDRAW,
+		Attributes: []abcitypes.EventAttribute{
+			{Key: []byte(ctrlertypes.EVENT_ATTR_OWNER), Value: []byte(ctx.Tx.From.String()), Index: true},
+			{Key: []byte(ctrlertypes.EVENT_ATTR_AMOUNT), Value: []byte(txpayload.ReqAmt.Dec()), Index: false},
+		},
+	})
 	return nil
 }
 
@@ -675,17 +809,19 @@ func (ctrler *StakeCtrler) EndBlock(ctx *ctrlertypes.BlockContext) ([]abcitypes.
 	ctrler.mtx.Lock()
 	defer ctrler.mtx.Unlock()
 
-	if xerr := ctrler.unfreezingStakes(ctx.Height(), ctx.AcctHandler); xerr != nil {
+	evts, xerr := ctrler.unfreezingStakes(ctx.Height(), ctx.AcctHandler)
+	if xerr != nil {
 		return nil, xerr
 	}
 
 	ctx.SetValUpdates(ctrler.updateValidators(int(ctx.GovHandler.MaxValidatorCnt())))
 
-	return nil, nil
+	return evts, nil
 }
 
-func (ctrler *StakeCtrler) unfreezingStakes(height int64, acctHandler ctrlertypes.IAccountHandler) xerrors.XError {
-	return ctrler.frozenLedger.IterateReadAllFinalityItems(func(s0 *Stake) xerrors.XError {
+func (ctrler *StakeCtrler) unfreezingStakes(height int64, acctHandler ctrlertypes.IAccountHandler) ([]abcitypes.Event, xerrors.XError) {
+	var evts []abcitypes.Event
+	xerr := ctrler.frozenLedger.IterateReadAllFinalityItems(func(s0 *Stake) xerrors.XError {
 		if s0.RefundHeight <= height {
 			// un-freezing s0
 			// return s0. not only s0.ReceivedReward but also s0.Amount
@@ -697,9 +833,15 @@ func (ctrler *StakeCtrler) unfreezingStakes(height int64, acctHandler ctrlertype
 			}
 
 			_, _ = ctrler.frozenLedger.DelFinality(ledger.ToLedgerKey(s0.TxHash))
+			ctrler.totals.unfrozenKeys = append(ctrler.totals.unfrozenKeys, ledger.ToLedgerKey(s0.TxHash))
+			evts = append(evts, stakeRefundedEvent(s0))
 		}
 		return nil
 	})
+	if xerr != nil {
+		return nil, xerr
+	}
+	return evts, nil
 }
 
 func (ctrler *StakeCtrler) UpdateValidators(maxVals int) []abcitypes.ValidatorUpdate {
@@ -787,6 +929,13 @@ func (ctrler *StakeCtrler) Commit() ([]byte, int64, xerrors.XError) {
 	ctrler.mtx.Lock()
 	defer ctrler.mtx.Unlock()
 
+	if xerr := ctrler.updateTotals(); xerr != nil {
+		return nil, -1, xerr
+	}
+	if xerr := ctrler.setPastRoots(ctrler.delegateeLedger.Version() + 1); xerr != nil {
+		return nil, -1, xerr
+	}
+
 	h0, v0, xerr := ctrler.delegateeLedger.Commit()
 	if xerr != nil {
 		return nil, -1, xerr
@@ -799,16 +948,68 @@ func (ctrler *StakeCtrler) Commit() ([]byte, int64, xerrors.XError) {
 	if xerr != nil {
 		return nil, -1, xerr
 	}
-	if v0 != v1 || v1 != v2 {
-		return nil, -1, xerrors.ErrCommit.Wrapf("error: StakeCtrler.Commit() has wrong version number - v0:%v, v1:%v, v2:%v", v0, v1, v2)
+	h3, v3, xerr := ctrler.slashLedger.Commit()
+	if xerr != nil {
+		return nil, -1, xerr
+	}
+	h4, v4, xerr := ctrler.pastRootLedger.Commit()
+	if xerr != nil {
+		return nil, -1, xerr
 	}
+	if v0 != v1 || v1 != v2 || v2 != v3 || v3 != v4 {
+		return nil, -1, xerrors.ErrCommit.Wrapf("error: StakeCtrler.Commit() has wrong version number - v0:%v, v1:%v, v2:%v, v3:%v, v4:%v", v0, v1, v2, v3, v4)
+	}
+	ctrler.loadTotals()
 
 	if v0%ctrler.rwdLedgUpInterval == 0 {
 		_ = ctrler.rwdHashDB.PutLastRewardHash(h2)
 		ctrler.lastRwdHash = h2
 	}
 
-	return crypto.DefaultHash(h0, h1, ctrler.lastRwdHash), v0, nil
+	return rootHashes(h0, h1, ctrler.lastRwdHash, h3, h4).Hash(), v0, nil
+}
+
+// Rollback discards the ledgers' versions newer than `height`.
+// The reward hash is restored to the one of the last reward ledger update at or before `height`.
+func (ctrler *StakeCtrler) Rollback(height int64) xerrors.XError {
+	ctrler.mtx.Lock()
+	defer ctrler.mtx.Unlock()
+
+	if xerr := ctrler.delegateeLedger.Rollback(height); xerr != nil {
+		return xerr
+	}
+	if xerr := ctrler.frozenLedger.Rollback(height); xerr != nil {
+		return xerr
+	}
+	if xerr := ctrler.rewardLedger.Rollback(height); xerr != nil {
+		return xerr
+	}
+	if xerr := ctrler.slashLedger.Rollback(height); xerr != nil {
+		return xerr
+	}
+	if xerr := ctrler.pastRootLedger.Rollback(height); xerr != nil {
+		return xerr
+	}
+	ctrler.resetTotals()
+
+	rwdHeight := height - height%ctrler.rwdLedgUpInterval
+	if rwdHeight == 0 {
+		if err := ctrler.rwdHashDB.DelLastRewardHash(); err != nil {
+			return xerrors.From(err)
+		}
+		ctrler.lastRwdHash = nil
+		return nil
+	}
+
+	h2, xerr := ctrler.rewardLedger.RootHashAt(rwdHeight)
+	if xerr != nil {
+		return xerr
+	}
+	if err := ctrler.rwdHashDB.PutLastRewardHash(h2); err != nil {
+		return xerrors.From(err)
+	}
+	ctrler.lastRwdHash = h2
+	return nil
 }
 
 func (ctrler *StakeCtrler) Close() xerrors.XError {
@@ -824,6 +1025,30 @@ func (ctrler *StakeCtrler) Close() xerrors.XError {
 		}
 		ctrler.frozenLedger = nil
 	}
+	if ctrler.rewardLedger != nil {
+		if xerr := ctrler.rewardLedger.Close(); xerr != nil {
+			ctrler.logger.Error("rewardLedger.Close()", "error", xerr.Error())
+		}
+		ctrler.rewardLedger = nil
+	}
+	if ctrler.slashLedger != nil {
+		if xerr := ctrler.slashLedger.Close(); xerr != nil {
+			ctrler.logger.Error("slashLedger.Close()", "error", xerr.Error())
+		}
+		ctrler.slashLedger = nil
+	}
+	if ctrler.pastRootLedger != nil {
+		if xerr := ctrler.pastRootLedger.Close(); xerr != nil {
+			ctrler.logger.Error("pastRootLedger.Close()", "error", xerr.Error())
+		}
+		ctrler.pastRootLedger = nil
+	}
+	if ctrler.rwdHashDB != nil {
+		if err := ctrler.rwdHashDB.Close(); err != nil {
+			ctrler.logger.Error("rwdHashDB.Close()", "error", err.Error())
+		}
+		ctrler.rwdHashDB = nil
+	}
 	return nil
 }
 
@@ -944,6 +1169,19 @@ func (ctrler *StakeCtrler) ReadFrozenStakes() []*Stake {
 	return ret
 }
 
+// ReadTotalRewards returns the sum of the rewards which are not withdrawn yet.
+func (ctrler *StakeCtrler) ReadTotalRewards() *uint256.Int {
+	ctrler.mtx.RLock()
+	defer ctrler.mtx.RUnlock()
+
+	ret := uint256.NewInt(0)
+	_ = ctrler.rewardLedger.IterateReadAllFinalityItems(func(rwd *Reward) xerrors.XError {
+		_ = ret.Add(ret, rwd.GetCumulated())
+		return nil
+	})
+	return ret
+}
+
 func (ctrler *StakeCtrler) RewardOf(addr types.Address) *Reward {
 	ctrler.mtx.RLock()
 	defer ctrler.mtx.RUnlock()
@@ -22,6 +22,14 @@ type Delegatee struct {
 
 	Stakes []*Stake `json:"stakes"`
 
+	// it is nil until the validator sets its commission.
+	Commission *Commission `json:"commission,omitempty"`
+
+	// a jailed delegatee is excluded from the validators with its stakes kept,
+	// until it is released by TRX_UNJAIL at or after `ReleaseHeight`.
+	Jailed        bool  `json:"jailed,omitempty"`
+	ReleaseHeight int64 `json:"releaseHeight,omitempty,string"`
+
 	NotSignedHeights *BlockMarker
 
 	mtx sync.RWMutex
@@ -74,6 +82,45 @@ func (delegatee *Delegatee) GetAddress() types.Address {
 	return delegatee.Addr
 }
 
+func (delegatee *Delegatee) SetCommission(c *Commission) {
+	delegatee.mtx.Lock()
+	defer delegatee.mtx.Unlock()
+
+	delegatee.Commission = c
+}
+
+func (delegatee *Delegatee) GetCommission() *Commission {
+	delegatee.mtx.RLock()
+	defer delegatee.mtx.RUnlock()
+
+	return delegatee.Commission
+}
+
+func (delegatee *Delegatee) Jail(releaseHeight int64) {
+	delegatee.mtx.Lock()
+	defer delegatee.mtx.Unlock()
+
+	delegatee.Jailed = true
+	delegatee.ReleaseHeight = releaseHeight
+}
+
+// Unjail releases the delegatee and clears the blocks missed before being jailed.
+func (delegatee *Delegatee) Unjail() {
+	delegatee.mtx.Lock()
+	defer delegatee.mtx.Unlock()
+
+	delegatee.Jailed = false
+	delegatee.ReleaseHeight = 0
+	delegatee.NotSignedHeights = &BlockMarker{}
+}
+
+func (delegatee *Delegatee) IsJailed() bool {
+	delegatee.mtx.RLock()
+	defer delegatee.mtx.RUnlock()
+
+	return delegatee.Jailed
+}
+
 func (delegatee *Delegatee) AddStake(stakes ...*Stake) xerrors.XError {
 	delegatee.mtx.Lock()
 	defer delegatee.mtx.Unlock()
@@ -154,6 +201,63 @@ func (delegatee *Delegatee) DelAllStakes() []*Stake {
 	return stakes
 }
 
+// TakePower takes `power` from the stakes of `owner` and returns the taken stakes.
+// If `txhash` is not nil, the power is taken only from the stake of `txhash`,
+// otherwise it is taken from the stakes of `owner`, the most recent one first.
+// If only a part of a stake is taken, the stake is split and the taken part has the same tx hash as the stake.
+func (delegatee *Delegatee) TakePower(owner types.Address, txhash bytes2.HexBytes, power int64) ([]*Stake, xerrors.XError) {
+	delegatee.mtx.Lock()
+	defer delegatee.mtx.Unlock()
+
+	var stakes []*Stake
+	if txhash != nil {
+		if _, s0 := delegatee.findStake(txhash); s0 != nil && bytes.Compare(owner, s0.From) == 0 {
+			stakes = append(stakes, s0)
+		}
+	} else {
+		for _, s0 := range delegatee.Stakes {
+			if bytes.Compare(owner, s0.From) == 0 {
+				stakes = append(stakes, s0)
+			}
+		}
+		sort.Stable(sort.Reverse(startHeightOrder(stakes)))
+	}
+	if len(stakes) == 0 {
+		return nil, xerrors.ErrNotFoundStake
+	}
+
+	sum := int64(0)
+	for _, s0 := range stakes {
+		sum += s0.Power
+	}
+	if power <= 0 || power > sum {
+		return nil, xerrors.ErrInvalidAmount.Wrapf("the power to take(%v) should be in (0, %v]", power, sum)
+	}
+
+	var taken []*Stake
+	for _, s0 := range stakes {
+		if power == 0 {
+			break
+		}
+		if s0.Power <= power {
+			_ = delegatee.delStakeByHash(s0.TxHash)
+			taken = append(taken, s0)
+			power -= s0.Power
+		} else {
+			s1 := s0.Clone()
+			s1.Power = power
+			s0.Power -= power
+			taken = append(taken, s1)
+			power = 0
+		}
+	}
+
+	delegatee.SelfPower = delegatee.sumPowerOf(delegatee.Addr)
+	delegatee.TotalPower = delegatee.sumPowerOf(nil)
+
+	return taken, nil
+}
+
 func (delegatee *Delegatee) GetAllStakes() []*Stake {
 	delegatee.mtx.RLock()
 	defer delegatee.mtx.RUnlock()
@@ -279,6 +383,31 @@ func (delegatee *Delegatee) doSlashAll(ratio int64) int64 {
 	return sumSlashedPower
 }
 
+// DoSlashStake slashes only the stake of `txhash` and returns the slashed power.
+// Like DoSlash, the stake is removed if it is too small to be slashed.
+func (delegatee *Delegatee) DoSlashStake(txhash bytes2.HexBytes, ratio int64) int64 {
+	delegatee.mtx.Lock()
+	defer delegatee.mtx.Unlock()
+
+	_, s0 := delegatee.findStake(txhash)
+	if s0 == nil {
+		return 0
+	}
+
+	slashedPower := (s0.Power * ratio) / int64(100)
+	if slashedPower < 1 {
+		_ = delegatee.delStakeByHash(s0.TxHash)
+		slashedPower = 0
+	} else {
+		s0.Power -= slashedPower
+	}
+
+	delegatee.SelfPower = delegatee.sumPowerOf(delegatee.Addr)
+	delegatee.TotalPower = delegatee.sumPowerOf(nil)
+
+	return slashedPower
+}
+
 func (delegatee *Delegatee) String() string {
 	bz, err := json.MarshalIndent(delegatee, "", "  ")
 	if err != nil {
@@ -0,0 +1,46 @@
+package stake
+
+import (
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"strconv"
+)
+
+// stakeEvent returns the event of `evtType` which has the owner, the delegatee, the tx hash and the power of `s0`.
+func stakeEvent(evtType string, s0 *Stake, attrs ...abcitypes.EventAttribute) abcitypes.Event {
+	return abcitypes.Event{
+		Type: evtType,
+		Attributes: append([]abcitypes.EventAttribute{
+			{Key: []byte(ctrlertypes.EVENT_ATTR_OWNER), Value: []byte(s0.From.String()), Index: true},
+			{Key: []byte(ctrlertypes.EVENT_ATTR_DELEGATEE), Value: []byte(s0.To.String()), Index: true},
+			{Key: []byte(ctrlertypes.EVENT_ATTR_TXHASH), Value: []byte(s0.TxHash.String()), Index: true},
+			{Key: []byte(ctrlertypes.EVENT_ATTR_POWER), Value: []byte(strconv.FormatInt(s0.Power, 10)), Index: false},
+		}, attrs...),
+	}
+}
+
+func stakeCreatedEvent(s0 *Stake) abcitypes.Event {
+	return stakeEvent(ctrlertypes.EVENT_TYPE_STAKE_CREATED, s0,
+		abcitypes.EventAttribute{Key: []byte(ctrlertypes.EVENT_ATTR_START_HEIGHT), Value: []byte(strconv.FormatInt(s0.StartHeight, 10)), Index: false},
+	)
+}
+
+func stakeFrozenEvent(s0 *Stake, reason string) abcitypes.Event {
+	return stakeEvent(ctrlertypes.EVENT_TYPE_STAKE_FROZEN, s0,
+		abcitypes.EventAttribute{Key: []byte(ctrlertypes.EVENT_ATTR_REFUND_HEIGHT), Value: []byte(strconv.FormatInt(s0.RefundHeight, 10)), Index: false},
+		abcitypes.EventAttribute{Key: []byte(ctrlertypes.EVENT_ATTR_REASON), Value: []byte(reason), Index: true},
+	)
+}
+
+func stakeRedelegatedEvent(s0 *Stake) abcitypes.Event {
+	return stakeEvent(ctrlertypes.EVENT_TYPE_STAKE_REDELEGATED, s0,
+		abcitypes.EventAttribute{Key: []byte(ctrlertypes.EVENT_ATTR_SRC), Value: []byte(s0.RedelegatedFrom.String()), Index: true},
+		abcitypes.EventAttribute{Key: []byte(ctrlertypes.EVENT_ATTR_START_HEIGHT), Value: []byte(strconv.FormatInt(s0.StartHeight, 10)), Index: false},
+	)
+}
+
+func stakeRefundedEvent(s0 *Stake) abcitypes.Event {
+	return stakeEvent(ctrlertypes.EVENT_TYPE_STAKE_REFUNDED, s0,
+		abcitypes.EventAttribute{Key: []byte(ctrlertypes.EVENT_ATTR_AMOUNT), Value: []byte(ctrlertypes.PowerToAmount(s0.Power).Dec()), Index: false},
+	)
+}
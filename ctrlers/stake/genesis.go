@@ -0,0 +1,130 @@
+package stake
+
+import (
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	"sort"
+)
+
+// GenesisState is the state of StakeCtrler which is exported to or imported from a genesis document.
+type GenesisState struct {
+	Delegatees []*Delegatee
+	Frozen     []*Stake
+	Rewards    []*Reward
+	Slashes    []*SlashHistory `json:",omitempty"`
+}
+
+// ExportGenesis returns the delegatees, the frozen stakes, the rewards and the slashing histories at `height`.
+// Their heights are rebased to the new network, which starts after `height`.
+func (ctrler *StakeCtrler) ExportGenesis(height int64) (*GenesisState, xerrors.XError) {
+	ctrler.mtx.RLock()
+	defer ctrler.mtx.RUnlock()
+
+	ret := &GenesisState{}
+
+	delegateeLedger, xerr := ctrler.delegateeLedger.ImmutableLedgerAt(height, 0)
+	if xerr != nil {
+		return nil, xerr
+	}
+	if xerr := delegateeLedger.IterateReadAllItems(func(d *Delegatee) xerrors.XError {
+		for _, s0 := range d.Stakes {
+			rebaseStake(s0, height)
+		}
+		if d.NotSignedHeights != nil {
+			for i := range d.NotSignedHeights.BlockHeights {
+				d.NotSignedHeights.BlockHeights[i] -= height
+			}
+		}
+		ret.Delegatees = append(ret.Delegatees, d)
+		return nil
+	}); xerr != nil {
+		return nil, xerr
+	}
+
+	frozenLedger, xerr := ctrler.frozenLedger.ImmutableLedgerAt(height, 0)
+	if xerr != nil {
+		return nil, xerr
+	}
+	if xerr := frozenLedger.IterateReadAllItems(func(s0 *Stake) xerrors.XError {
+		ret.Frozen = append(ret.Frozen, rebaseStake(s0, height))
+		return nil
+	}); xerr != nil {
+		return nil, xerr
+	}
+
+	rewardLedger, xerr := ctrler.rewardLedger.ImmutableLedgerAt(height, 0)
+	if xerr != nil {
+		return nil, xerr
+	}
+	if xerr := rewardLedger.IterateReadAllItems(func(rwd *Reward) xerrors.XError {
+		rwd.height -= height
+		ret.Rewards = append(ret.Rewards, rwd)
+		return nil
+	}); xerr != nil {
+		return nil, xerr
+	}
+
+	slashLedger, xerr := ctrler.slashLedger.ImmutableLedgerAt(height, 0)
+	if xerr != nil {
+		return nil, xerr
+	}
+	if xerr := slashLedger.IterateReadAllItems(func(h *SlashHistory) xerrors.XError {
+		// the evidences of the new network are not confused with the old ones.
+		for _, s := range h.Slashings {
+			s.EvidenceHeight -= height
+			s.Height -= height
+		}
+		ret.Slashes = append(ret.Slashes, h)
+		return nil
+	}); xerr != nil {
+		return nil, xerr
+	}
+
+	return ret, nil
+}
+
+func rebaseStake(s0 *Stake, height int64) *Stake {
+	s0.StartHeight -= height
+	if s0.RefundHeight > 0 {
+		s0.RefundHeight -= height
+	}
+	return s0
+}
+
+func (ctrler *StakeCtrler) importGenesis(state *GenesisState) xerrors.XError {
+	for _, d := range state.Delegatees {
+		if xerr := ctrler.delegateeLedger.SetFinality(d); xerr != nil {
+			return xerr
+		}
+	}
+	for _, s0 := range state.Frozen {
+		if xerr := ctrler.frozenLedger.SetFinality(s0); xerr != nil {
+			return xerr
+		}
+	}
+	for _, rwd := range state.Rewards {
+		if xerr := ctrler.rewardLedger.SetFinality(rwd); xerr != nil {
+			return xerr
+		}
+	}
+	for _, h := range state.Slashes {
+		if xerr := ctrler.slashLedger.SetFinality(h); xerr != nil {
+			return xerr
+		}
+	}
+	return nil
+}
+
+// Validators returns the validators selected from `Delegatees` in the same way as in BeginBlock and EndBlock.
+func (state *GenesisState) Validators(govParams ctrlertypes.IGovHandler) DelegateeArray {
+	minPower := ctrlertypes.AmountToPower(govParams.MinValidatorStake())
+
+	var delegatees DelegateeArray
+	for _, d := range state.Delegatees {
+		if d.SelfPower >= minPower {
+			delegatees = append(delegatees, d)
+		}
+	}
+	sort.Sort(PowerOrderDelegatees(delegatees))
+	return selectValidators(PowerOrderDelegatees(delegatees), int(govParams.MaxValidatorCnt()))
+}
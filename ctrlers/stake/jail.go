@@ -0,0 +1,65 @@
+package stake
+
+import (
+	"bytes"
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/ledger"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+)
+
+func (ctrler *StakeCtrler) validateUnjail(ctx *ctrlertypes.TrxContext) xerrors.XError {
+	if ctx.Tx.Amount.Sign() != 0 {
+		return xerrors.ErrInvalidTrx.Wrapf("amount must be 0")
+	}
+	if bytes.Compare(ctx.Tx.From, ctx.Tx.To) != 0 {
+		return xerrors.ErrInvalidTrx.Wrapf("the validator should be released by itself")
+	}
+
+	getDelegatee := ctrler.delegateeLedger.Get
+	if ctx.Exec {
+		getDelegatee = ctrler.delegateeLedger.GetFinality
+	}
+	delegatee, xerr := getDelegatee(ledger.ToLedgerKey(ctx.Tx.From))
+	if xerr == xerrors.ErrNotFoundResult {
+		return xerrors.ErrNotFoundDelegatee.Wrapf("address(%v)", ctx.Tx.From)
+	} else if xerr != nil {
+		return xerr
+	}
+
+	if !delegatee.IsJailed() {
+		return xerrors.ErrInvalidTrx.Wrapf("the validator(%v) is not jailed", delegatee.Addr)
+	}
+	if ctx.Height < delegatee.ReleaseHeight {
+		return xerrors.ErrInvalidTrx.Wrapf("the validator(%v) is jailed until %v", delegatee.Addr, delegatee.ReleaseHeight)
+	}
+	return nil
+}
+
+func (ctrler *StakeCtrler) exeUnjail(ctx *ctrlertypes.TrxContext) xerrors.XError {
+	getDelegatee := ctrler.delegateeLedger.Get
+	setUpdateDelegatee := ctrler.delegateeLedger.Set
+	if ctx.Exec {
+		getDelegatee = ctrler.delegateeLedger.GetFinality
+		setUpdateDelegatee = ctrler.delegateeLedger.SetFinality
+	}
+
+	delegatee, xerr := getDelegatee(ledger.ToLedgerKey(ctx.Tx.From))
+	if xerr != nil {
+		return xerr
+	}
+
+	// it becomes a validator again at the next block, if it still has enough power.
+	delegatee.Unjail()
+	if xerr := setUpdateDelegatee(delegatee); xerr != nil {
+		return xerr
+	}
+
+	ctx.Events = append(ctx.Events, abcitypes.Event{
+		Type: ctrlertypes.EVENT_TYPE_VALIDATOR_UNJAILED,
+		Attributes: []abcitypes.EventAttribute{
+			{Key: []byte(ctrlertypes.EVENT_ATTR_DELEGATEE), Value: []byte(delegatee.Addr.String()), Index: true},
+		},
+	})
+	return nil
+}
@@ -0,0 +1,55 @@
+package stake
+
+import (
+	"github.com/rigochain/rigo-go/ledger"
+	abytes "github.com/rigochain/rigo-go/types/bytes"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+)
+
+var pastRootsKey = ledger.ToLedgerKey(abytes.ZeroBytes(32))
+
+// PastRoots is the root hashes of the past versions of `delegateeLedger`, which BeginBlock reads to reward validators.
+// It is kept in `pastRootLedger` and included in the app hash,
+// so that the past versions imported from a snapshot are verified against the app hash.
+type PastRoots struct {
+	Height int64             `json:"height,string"`
+	From   int64             `json:"from,string"`
+	Roots  []abytes.HexBytes `json:"roots"`
+}
+
+func (roots *PastRoots) Key() ledger.LedgerKey {
+	return pastRootsKey
+}
+
+func (roots *PastRoots) Encode() ([]byte, xerrors.XError) {
+	if bz, err := tmjson.Marshal(roots); err != nil {
+		return nil, xerrors.From(err)
+	} else {
+		return bz, nil
+	}
+}
+
+func (roots *PastRoots) Decode(bz []byte) xerrors.XError {
+	if err := tmjson.Unmarshal(bz, roots); err != nil {
+		return xerrors.From(err)
+	}
+	return nil
+}
+
+var _ ledger.ILedgerItem = (*PastRoots)(nil)
+
+// setPastRoots records the root hashes of the versions of `delegateeLedger` read by the next blocks of `height`.
+// It is called in Commit before the ledgers are committed, so the last committed version is `height - 1`.
+func (ctrler *StakeCtrler) setPastRoots(height int64) xerrors.XError {
+	from, to := pastDelegateesRange(height)
+	roots := &PastRoots{Height: height, From: from}
+	for ver := from; ver <= to; ver++ {
+		h, xerr := ctrler.delegateeLedger.RootHashAt(ver)
+		if xerr != nil {
+			return xerr
+		}
+		roots.Roots = append(roots.Roots, h)
+	}
+	return ctrler.pastRootLedger.SetFinality(roots)
+}
@@ -0,0 +1,123 @@
+package stake
+
+import (
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/ledger"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	tmcrypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
+)
+
+const (
+	proofLedgerDelegatees = "delegatees"
+	proofLedgerFrozen     = "frozen"
+	proofLedgerRewards    = "rewards"
+	proofLedgerSlashes    = "slashes"
+	proofLedgerPastRoots  = "delegatee_roots"
+)
+
+func rootHashes(delegateesHash, frozenHash, rwdHash, slashesHash, pastRootsHash []byte) ctrlertypes.MerkleRoots {
+	return ctrlertypes.MerkleRoots{
+		proofLedgerDelegatees: delegateesHash,
+		proofLedgerFrozen:     frozenHash,
+		proofLedgerRewards:    rwdHash,
+		proofLedgerSlashes:    slashesHash,
+		proofLedgerPastRoots:  pastRootsHash,
+	}
+}
+
+// rewardHeight returns the version of `rewardLedger` which root hash is included in the app hash of `height`.
+// It is 0 if no version is included yet.
+func (ctrler *StakeCtrler) rewardHeight(height int64) int64 {
+	return height - height%ctrler.rwdLedgUpInterval
+}
+
+func (ctrler *StakeCtrler) rootHashesAt(height int64) (ctrlertypes.MerkleRoots, xerrors.XError) {
+	h0, xerr := ctrler.delegateeLedger.RootHashAt(height)
+	if xerr != nil {
+		return nil, xerr
+	}
+	h1, xerr := ctrler.frozenLedger.RootHashAt(height)
+	if xerr != nil {
+		return nil, xerr
+	}
+	h3, xerr := ctrler.slashLedger.RootHashAt(height)
+	if xerr != nil {
+		return nil, xerr
+	}
+	h4, xerr := ctrler.pastRootLedger.RootHashAt(height)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	// the version of `lastRwdHash` may not exist in `rewardLedger` when the state is restored from a snapshot.
+	h2 := ctrler.lastRwdHash
+	if height != ctrler.rewardLedger.Version() {
+		h2 = nil
+		if rwdHeight := ctrler.rewardHeight(height); rwdHeight > 0 {
+			if h2, xerr = ctrler.rewardLedger.RootHashAt(rwdHeight); xerr != nil {
+				return nil, xerr
+			}
+		}
+	}
+	return rootHashes(h0, h1, h2, h3, h4), nil
+}
+
+func (ctrler *StakeCtrler) RootHashAt(height int64) ([]byte, xerrors.XError) {
+	ctrler.mtx.RLock()
+	defer ctrler.mtx.RUnlock()
+
+	roots, xerr := ctrler.rootHashesAt(height)
+	if xerr != nil {
+		return nil, xerr
+	}
+	return roots.Hash(), nil
+}
+
+// Prove returns the delegatee, the reward or the slashing history of `req.Data` at `req.Height`.
+// If the item does not exist, the returned item is nil and the proof is the proof of absence.
+// The reward is read from the last version of `rewardLedger` included in the app hash,
+// because the app hash includes the root hash of `rewardLedger` only every `rwdLedgUpInterval` blocks.
+func (ctrler *StakeCtrler) Prove(req abcitypes.RequestQuery) ([]byte, *tmcrypto.ProofOps, xerrors.XError) {
+	ctrler.mtx.RLock()
+	defer ctrler.mtx.RUnlock()
+
+	var name string
+	var bz []byte
+	var op *tmcrypto.ProofOp
+	var xerr xerrors.XError
+
+	switch req.Path {
+	case "delegatee":
+		name = proofLedgerDelegatees
+		bz, op, xerr = ctrler.delegateeLedger.ProveAt(req.Height, ledger.ToLedgerKey(req.Data))
+	case "reward":
+		rwdHeight := ctrler.rewardHeight(req.Height)
+		if rwdHeight == 0 {
+			return nil, nil, xerrors.ErrQuery.Wrapf("the rewards are not included in the app hash until the height %v", ctrler.rwdLedgUpInterval)
+		}
+		name = proofLedgerRewards
+		bz, op, xerr = ctrler.rewardLedger.ProveAt(rwdHeight, ledger.ToLedgerKey(req.Data))
+	case "slashes":
+		name = proofLedgerSlashes
+		bz, op, xerr = ctrler.slashLedger.ProveAt(req.Height, ledger.ToLedgerKey(req.Data))
+	default:
+		// the stakes are proven by the proof of the delegatee which has them.
+		return nil, nil, xerrors.ErrQuery.Wrapf("the query path '%v' does not support proofs", req.Path)
+	}
+	if xerr != nil {
+		return nil, nil, xerrors.ErrQuery.Wrap(xerr)
+	}
+
+	roots, xerr := ctrler.rootHashesAt(req.Height)
+	if xerr != nil {
+		return nil, nil, xerrors.ErrQuery.Wrap(xerr)
+	}
+	rootOp, xerr := roots.ProofOp(name)
+	if xerr != nil {
+		return nil, nil, xerrors.ErrQuery.Wrap(xerr)
+	}
+	return bz, &tmcrypto.ProofOps{Ops: []tmcrypto.ProofOp{*op, *rootOp}}, nil
+}
+
+var _ ctrlertypes.IProofHandler = (*StakeCtrler)(nil)
@@ -69,6 +69,38 @@ func (ctrler *StakeCtrler) Query(req abcitypes.RequestQuery) ([]byte, xerrors.XE
 		} else {
 			return v, nil
 		}
+	case "slashes":
+		atledger, xerr := ctrler.slashLedger.ImmutableLedgerAt(req.Height, 0)
+		if xerr != nil {
+			return nil, xerrors.ErrQuery.Wrap(xerr)
+		}
+
+		if history, xerr := atledger.Read(ledger.ToLedgerKey(req.Data)); xerr != nil {
+			return nil, xerrors.ErrQuery.Wrap(xerr)
+		} else if v, err := tmjson.Marshal(history); err != nil {
+			return nil, xerrors.ErrQuery.Wrap(err)
+		} else {
+			return v, nil
+		}
+	case "slashes/delegator":
+		atledger, xerr := ctrler.slashLedger.ImmutableLedgerAt(req.Height, 0)
+		if xerr != nil {
+			return nil, xerrors.ErrQuery.Wrap(xerr)
+		}
+
+		var histories []*SlashHistory
+		if err := atledger.IterateReadAllItems(func(h *SlashHistory) xerrors.XError {
+			if slashings := h.SlashingsOf(req.Data); slashings != nil {
+				histories = append(histories, &SlashHistory{Addr: h.Addr, Slashings: slashings})
+			}
+			return nil
+		}); err != nil {
+			return nil, xerrors.ErrQuery.Wrap(err)
+		} else if bz, err := tmjson.Marshal(histories); err != nil {
+			return nil, xerrors.ErrQuery.Wrap(err)
+		} else {
+			return bz, nil
+		}
 	case "stakes/total_power":
 		atledger, xerr := ctrler.delegateeLedger.ImmutableLedgerAt(req.Height, 0)
 		if xerr != nil {
@@ -94,7 +126,7 @@ func (ctrler *StakeCtrler) Query(req abcitypes.RequestQuery) ([]byte, xerrors.XE
 		var delegatees PowerOrderDelegatees
 		xerr = atledger.IterateReadAllItems(func(d *Delegatee) xerrors.XError {
 			minPower := types2.AmountToPower(ctrler.govParams.MinValidatorStake())
-			if d.SelfPower >= minPower {
+			if d.SelfPower >= minPower && !d.Jailed {
 				delegatees = append(delegatees, d)
 			}
 			return nil
@@ -0,0 +1,181 @@
+package stake
+
+import (
+	"bytes"
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/ledger"
+	"github.com/rigochain/rigo-go/types"
+	"github.com/rigochain/rigo-go/types/xerrors"
+)
+
+// isRedelegatedFrom returns true if `s` has been redelegated from `addr` at or after `evidenceHeight`
+// and it is still in the unbonding window at `height`.
+func (s *Stake) isRedelegatedFrom(addr types.Address, evidenceHeight, height, window int64) bool {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	return s.RedelegatedFrom != nil &&
+		bytes.Compare(s.RedelegatedFrom, addr) == 0 &&
+		s.RedelegatedHeight >= evidenceHeight &&
+		s.RedelegatedHeight+window >= height
+}
+
+func (ctrler *StakeCtrler) validateRedelegate(ctx *ctrlertypes.TrxContext) xerrors.XError {
+	if ctx.Tx.Amount.Sign() != 0 {
+		return xerrors.ErrInvalidTrx.Wrapf("amount must be 0")
+	}
+	payload, ok := ctx.Tx.Payload.(*ctrlertypes.TrxPayloadRedelegate)
+	if !ok {
+		return xerrors.ErrInvalidTrxPayloadType
+	}
+	if payload.TxHash == nil || len(payload.TxHash) != 32 {
+		return xerrors.ErrInvalidTrxPayloadParams
+	}
+	if bytes.Compare(payload.Src, ctx.Tx.To) == 0 {
+		return xerrors.ErrInvalidTrxPayloadParams.Wrapf("the stake is already delegated to %v", ctx.Tx.To)
+	}
+	if bytes.Compare(ctx.Tx.From, ctx.Tx.To) == 0 {
+		return xerrors.ErrInvalidTrxPayloadParams.Wrapf("a stake can not be redelegated to its owner")
+	}
+
+	getDelegatee := ctrler.delegateeLedger.Get
+	if ctx.Exec {
+		getDelegatee = ctrler.delegateeLedger.GetFinality
+	}
+
+	src, xerr := getDelegatee(ledger.ToLedgerKey(payload.Src))
+	if xerr == xerrors.ErrNotFoundResult {
+		return xerrors.ErrNotFoundDelegatee.Wrapf("address(%v)", payload.Src)
+	} else if xerr != nil {
+		return xerr
+	}
+
+	_, s0 := src.FindStake(payload.TxHash)
+	if s0 == nil {
+		return xerrors.ErrNotFoundStake
+	}
+	if ctx.Tx.From.Compare(s0.From) != 0 {
+		return xerrors.ErrNotFoundStake.Wrapf("you not stake owner")
+	}
+	if s0.IsSelfStake() {
+		return xerrors.ErrInvalidTrx.Wrapf("the self stake of a validator can not be redelegated")
+	}
+	if s0.RedelegatedFrom != nil && s0.RedelegatedHeight+ctx.GovHandler.LazyRewardBlocks() >= ctx.Height {
+		// the stake should remain slashable for `RedelegatedFrom`.
+		return xerrors.ErrInvalidTrx.Wrapf("the stake has been redelegated at %v: it can be redelegated after %v",
+			s0.RedelegatedHeight, s0.RedelegatedHeight+ctx.GovHandler.LazyRewardBlocks())
+	}
+
+	dst, xerr := getDelegatee(ledger.ToLedgerKey(ctx.Tx.To))
+	if xerr == xerrors.ErrNotFoundResult {
+		return xerrors.ErrNotFoundDelegatee.Wrapf("address(%v)", ctx.Tx.To)
+	} else if xerr != nil {
+		return xerr
+	}
+
+	// it's delegating to `dst`. check minSelfStakeRatio
+	if dst.SelfStakeRatio(s0.Power) < ctx.GovHandler.MinSelfStakeRatio() {
+		return xerrors.ErrInvalidTrx.Wrapf("not enough self power - validator: %v, self power: %v, total power: %v", dst.Addr, dst.GetSelfPower(), dst.GetTotalPower())
+	}
+
+	// issue #34: check updatable stake ratio
+	if len(ctrler.lastValidators) >= 3 {
+		if xerr := ctrler.stakeLimiter.CheckLimit(dst, s0.Power); xerr != nil {
+			return xerrors.ErrUpdatableStakeRatio.Wrap(xerr)
+		}
+		if xerr := ctrler.stakeLimiter.CheckLimit(src, -1*s0.Power); xerr != nil {
+			return xerrors.ErrUpdatableStakeRatio.Wrap(xerr)
+		}
+	}
+	return nil
+}
+
+// exeRedelegate moves the stake from the source delegatee to `ctx.Tx.To` without freezing it.
+// The stake keeps its tx hash and its reward is started again at `ctx.Height + 1`.
+func (ctrler *StakeCtrler) exeRedelegate(ctx *ctrlertypes.TrxContext) xerrors.XError {
+	getDelegatee := ctrler.delegateeLedger.Get
+	setUpdateDelegatee := ctrler.delegateeLedger.Set
+	if ctx.Exec {
+		getDelegatee = ctrler.delegateeLedger.GetFinality
+		setUpdateDelegatee = ctrler.delegateeLedger.SetFinality
+	}
+
+	payload := ctx.Tx.Payload.(*ctrlertypes.TrxPayloadRedelegate)
+	src, xerr := getDelegatee(ledger.ToLedgerKey(payload.Src))
+	if xerr != nil {
+		return xerr
+	}
+	dst, xerr := getDelegatee(ledger.ToLedgerKey(ctx.Tx.To))
+	if xerr != nil {
+		return xerr
+	}
+
+	_, s0 := src.FindStake(payload.TxHash)
+	if s0 == nil {
+		return xerrors.ErrNotFoundStake
+	}
+	if ctx.Tx.From.Compare(s0.From) != 0 {
+		return xerrors.ErrNotFoundStake.Wrapf("you not stake owner")
+	}
+
+	_ = src.DelStake(payload.TxHash)
+
+	s0.To = dst.Addr
+	s0.StartHeight = ctx.Height + 1
+	s0.RedelegatedFrom = src.Addr
+	s0.RedelegatedHeight = ctx.Height
+	if xerr := dst.AddStake(s0); xerr != nil {
+		return xerr
+	}
+
+	// `src` still has its self stakes.
+	if xerr := setUpdateDelegatee(src); xerr != nil {
+		return xerr
+	}
+	if xerr := setUpdateDelegatee(dst); xerr != nil {
+		return xerr
+	}
+
+	ctx.Events = append(ctx.Events, stakeRedelegatedEvent(s0))
+	return nil
+}
+
+// slashRedelegated slashes the stakes redelegated from the byzantine validator `addr`,
+// which were bonded to it at `evidenceHeight` and are still in the unbonding window at `height`.
+// It returns the slashed power, the power removed from the delegatees and the power lost by each stake.
+func (ctrler *StakeCtrler) slashRedelegated(addr types.Address, evidenceHeight, height, slashRatio int64) (int64, int64, []*SlashedStake, xerrors.XError) {
+	window := ctrler.govParams.LazyRewardBlocks()
+
+	var targets []types.Address
+	if xerr := ctrler.delegateeLedger.IterateReadAllFinalityItems(func(d *Delegatee) xerrors.XError {
+		for _, s0 := range d.Stakes {
+			if s0.isRedelegatedFrom(addr, evidenceHeight, height, window) {
+				targets = append(targets, d.Addr)
+				break
+			}
+		}
+		return nil
+	}); xerr != nil {
+		return 0, 0, nil, xerr
+	}
+
+	slashed, removed := int64(0), int64(0)
+	var stakes []*SlashedStake
+	for _, dAddr := range targets {
+		delegatee, xerr := ctrler.delegateeLedger.GetFinality(ledger.ToLedgerKey(dAddr))
+		if xerr != nil {
+			return 0, 0, nil, xerr
+		}
+
+		power, before := delegatee.GetTotalPower(), stakePowers(delegatee)
+		for _, s0 := range delegatee.GetAllStakes() {
+			if s0.isRedelegatedFrom(addr, evidenceHeight, height, window) {
+				slashed += delegatee.DoSlashStake(s0.TxHash, slashRatio)
+			}
+		}
+		_ = ctrler.delegateeLedger.SetFinality(delegatee)
+		removed += power - delegatee.GetTotalPower()
+		stakes = append(stakes, slashedStakes(before, delegatee)...)
+	}
+	return slashed, removed, stakes, nil
+}
@@ -18,18 +18,22 @@ type Reward struct {
 	slashed   *uint256.Int
 	cumulated *uint256.Int
 	height    int64
+	// the total commission which the validator has earned from the rewards of its delegators.
+	// it is also included in `issued` and `cumulated`.
+	commission *uint256.Int
 
 	mtx sync.RWMutex
 }
 
 func NewReward(addr types.Address) *Reward {
 	return &Reward{
-		address:   addr,
-		issued:    uint256.NewInt(0),
-		withdrawn: uint256.NewInt(0),
-		slashed:   uint256.NewInt(0),
-		cumulated: uint256.NewInt(0),
-		height:    0,
+		address:    addr,
+		issued:     uint256.NewInt(0),
+		withdrawn:  uint256.NewInt(0),
+		slashed:    uint256.NewInt(0),
+		cumulated:  uint256.NewInt(0),
+		height:     0,
+		commission: uint256.NewInt(0),
 	}
 }
 
@@ -51,6 +55,19 @@ func (rwd *Reward) Issue(r *uint256.Int, h int64) xerrors.XError {
 	return nil
 }
 
+// IssueCommission issues `r` as the commission taken from the rewards of the delegators.
+func (rwd *Reward) IssueCommission(r *uint256.Int, h int64) xerrors.XError {
+	if xerr := rwd.Issue(r, h); xerr != nil {
+		return xerr
+	}
+
+	rwd.mtx.Lock()
+	defer rwd.mtx.Unlock()
+
+	_ = rwd.commission.Add(rwd.commission, r)
+	return nil
+}
+
 func (rwd *Reward) Withdraw(r *uint256.Int, h int64) xerrors.XError {
 	rwd.mtx.Lock()
 	defer rwd.mtx.Unlock()
@@ -95,12 +112,13 @@ func (rwd *Reward) Encode() ([]byte, xerrors.XError) {
 	defer rwd.mtx.RUnlock()
 
 	m := &RewardProto{
-		Address:    rwd.address,
-		XIssued:    rwd.issued.Bytes(),
-		XWithdrawn: rwd.withdrawn.Bytes(),
-		XSlashed:   rwd.slashed.Bytes(),
-		XCumulated: rwd.cumulated.Bytes(),
-		Height:     rwd.height,
+		Address:     rwd.address,
+		XIssued:     rwd.issued.Bytes(),
+		XWithdrawn:  rwd.withdrawn.Bytes(),
+		XSlashed:    rwd.slashed.Bytes(),
+		XCumulated:  rwd.cumulated.Bytes(),
+		Height:      rwd.height,
+		XCommission: rwd.commission.Bytes(),
 	}
 
 	if bz, err := proto.Marshal(m); err != nil {
@@ -125,6 +143,7 @@ func (rwd *Reward) Decode(d []byte) xerrors.XError {
 	rwd.slashed = new(uint256.Int).SetBytes(m.XSlashed)
 	rwd.cumulated = new(uint256.Int).SetBytes(m.XCumulated)
 	rwd.height = m.Height
+	rwd.commission = new(uint256.Int).SetBytes(m.XCommission)
 	return nil
 }
 
@@ -133,31 +152,34 @@ func (rwd *Reward) MarshalJSON() ([]byte, error) {
 	defer rwd.mtx.RUnlock()
 
 	_tmp := &struct {
-		Address   types.Address `json:"address,omitempty"`
-		Issued    string        `json:"issued,omitempty"`
-		Withdrawn string        `json:"withdrawn,omitempty"`
-		Slashed   string        `json:"slashed,omitempty"`
-		Cumulated string        `json:"cumulated,omitempty"`
-		Height    int64         `json:"height,omitempty"`
+		Address    types.Address `json:"address,omitempty"`
+		Issued     string        `json:"issued,omitempty"`
+		Withdrawn  string        `json:"withdrawn,omitempty"`
+		Slashed    string        `json:"slashed,omitempty"`
+		Cumulated  string        `json:"cumulated,omitempty"`
+		Height     int64         `json:"height,omitempty"`
+		Commission string        `json:"commission,omitempty"`
 	}{
-		Address:   rwd.address,
-		Issued:    rwd.issued.Dec(),
-		Withdrawn: rwd.withdrawn.Dec(),
-		Slashed:   rwd.slashed.Dec(),
-		Cumulated: rwd.cumulated.Dec(),
-		Height:    rwd.height,
+		Address:    rwd.address,
+		Issued:     rwd.issued.Dec(),
+		Withdrawn:  rwd.withdrawn.Dec(),
+		Slashed:    rwd.slashed.Dec(),
+		Cumulated:  rwd.cumulated.Dec(),
+		Height:     rwd.height,
+		Commission: rwd.commission.Dec(),
 	}
 	return json.Marshal(_tmp)
 }
 
 func (rwd *Reward) UnmarshalJSON(d []byte) error {
 	tmp := &struct {
-		Address   types.Address `json:"address,omitempty"`
-		Issued    string        `json:"issued,omitempty"`
-		Withdrawn string        `json:"withdrawn,omitempty"`
-		Slashed   string        `json:"slashed,omitempty"`
-		Cumulated string        `json:"cumulated,omitempty"`
-		Height    int64         `json:"height,omitempty"`
+		Address    types.Address `json:"address,omitempty"`
+		Issued     string        `json:"issued,omitempty"`
+		Withdrawn  string        `json:"withdrawn,omitempty"`
+		Slashed    string        `json:"slashed,omitempty"`
+		Cumulated  string        `json:"cumulated,omitempty"`
+		Height     int64         `json:"height,omitempty"`
+		Commission string        `json:"commission,omitempty"`
 	}{}
 
 	if err := json.Unmarshal(d, tmp); err != nil {
@@ -173,6 +195,10 @@ func (rwd *Reward) UnmarshalJSON(d []byte) error {
 	rwd.slashed = uint256.MustFromDecimal(tmp.Slashed)
 	rwd.cumulated = uint256.MustFromDecimal(tmp.Cumulated)
 	rwd.height = tmp.Height
+	rwd.commission = uint256.NewInt(0)
+	if tmp.Commission != "" {
+		rwd.commission = uint256.MustFromDecimal(tmp.Commission)
+	}
 	return nil
 }
 
@@ -211,6 +237,13 @@ func (rwd *Reward) GetCumulated() *uint256.Int {
 	return new(uint256.Int).Set(rwd.cumulated)
 }
 
+func (rwd *Reward) GetCommission() *uint256.Int {
+	rwd.mtx.RLock()
+	defer rwd.mtx.RUnlock()
+
+	return new(uint256.Int).Set(rwd.commission)
+}
+
 func (rwd *Reward) Height() int64 {
 	rwd.mtx.RLock()
 	defer rwd.mtx.RUnlock()
@@ -25,12 +25,13 @@ type RewardProto struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Address    []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
-	XIssued    []byte `protobuf:"bytes,2,opt,name=_issued,json=Issued,proto3" json:"_issued,omitempty"`
-	XWithdrawn []byte `protobuf:"bytes,3,opt,name=_withdrawn,json=Withdrawn,proto3" json:"_withdrawn,omitempty"`
-	XSlashed   []byte `protobuf:"bytes,4,opt,name=_slashed,json=Slashed,proto3" json:"_slashed,omitempty"`
-	XCumulated []byte `protobuf:"bytes,5,opt,name=_cumulated,json=Cumulated,proto3" json:"_cumulated,omitempty"`
-	Height     int64  `protobuf:"varint,6,opt,name=height,proto3" json:"height,omitempty"`
+	Address     []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	XIssued     []byte `protobuf:"bytes,2,opt,name=_issued,json=Issued,proto3" json:"_issued,omitempty"`
+	XWithdrawn  []byte `protobuf:"bytes,3,opt,name=_withdrawn,json=Withdrawn,proto3" json:"_withdrawn,omitempty"`
+	XSlashed    []byte `protobuf:"bytes,4,opt,name=_slashed,json=Slashed,proto3" json:"_slashed,omitempty"`
+	XCumulated  []byte `protobuf:"bytes,5,opt,name=_cumulated,json=Cumulated,proto3" json:"_cumulated,omitempty"`
+	Height      int64  `protobuf:"varint,6,opt,name=height,proto3" json:"height,omitempty"`
+	XCommission []byte `protobuf:"bytes,7,opt,name=_commission,json=Commission,proto3" json:"_commission,omitempty"`
 }
 
 func (x *RewardProto) Reset() {
@@ -107,11 +108,18 @@ func (x *RewardProto) GetHeight() int64 {
 	return 0
 }
 
+func (x *RewardProto) GetXCommission() []byte {
+	if x != nil {
+		return x.XCommission
+	}
+	return nil
+}
+
 var File_reward_proto protoreflect.FileDescriptor
 
 var file_reward_proto_rawDesc = []byte{
 	0x0a, 0x0c, 0x72, 0x65, 0x77, 0x61, 0x72, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05,
-	0x74, 0x79, 0x70, 0x65, 0x73, 0x22, 0xb1, 0x01, 0x0a, 0x0b, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x22, 0xd2, 0x01, 0x0a, 0x0b, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64,
 	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
 	0x17, 0x0a, 0x07, 0x5f, 0x69, 0x73, 0x73, 0x75, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
@@ -122,10 +130,12 @@ var file_reward_proto_rawDesc = []byte{
 	0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x5f, 0x63, 0x75, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x64,
 	0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x43, 0x75, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65,
 	0x64, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x69, 0x67, 0x6f, 0x63, 0x68, 0x61, 0x69,
-	0x6e, 0x2f, 0x72, 0x69, 0x67, 0x6f, 0x2d, 0x67, 0x6f, 0x2f, 0x63, 0x74, 0x72, 0x6c, 0x65, 0x72,
-	0x73, 0x2f, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x03, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x5f, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a,
+	0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x69, 0x67, 0x6f, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x2f, 0x72, 0x69, 0x67, 0x6f, 0x2d, 0x67, 0x6f, 0x2f, 0x63, 0x74, 0x72, 0x6c, 0x65,
+	0x72, 0x73, 0x2f, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
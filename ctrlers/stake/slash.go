@@ -0,0 +1,188 @@
+package stake
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/rigochain/rigo-go/ledger"
+	"github.com/rigochain/rigo-go/types"
+	abytes "github.com/rigochain/rigo-go/types/bytes"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"sync"
+)
+
+// SlashedStake is the power which the stake of `TxHash` has lost by a slashing.
+// The stake may have been delegated to another delegatee than the punished validator by TRX_REDELEGATE.
+type SlashedStake struct {
+	Owner  types.Address   `json:"owner"`
+	To     types.Address   `json:"to"`
+	TxHash abytes.HexBytes `json:"txhash"`
+	Power  int64           `json:"power,string"`
+}
+
+// Slashing is the record of the evidence handled at `Height`.
+type Slashing struct {
+	Type           string          `json:"type"`
+	EvidenceHeight int64           `json:"evidenceHeight,string"`
+	Height         int64           `json:"height,string"`
+	Ratio          int64           `json:"ratio"`
+	Stakes         []*SlashedStake `json:"stakes"`
+}
+
+func newSlashing(evi *abcitypes.Evidence, height, ratio int64) *Slashing {
+	return &Slashing{
+		Type:           abcitypes.EvidenceType_name[int32(evi.Type)],
+		EvidenceHeight: evi.Height,
+		Height:         height,
+		Ratio:          ratio,
+	}
+}
+
+// SlashHistory has all the slashings of the validator `Addr`.
+type SlashHistory struct {
+	Addr      types.Address `json:"address"`
+	Slashings []*Slashing   `json:"slashings"`
+
+	mtx sync.RWMutex
+}
+
+func NewSlashHistory(addr types.Address) *SlashHistory {
+	return &SlashHistory{Addr: addr}
+}
+
+func (h *SlashHistory) Key() ledger.LedgerKey {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	return ledger.ToLedgerKey(h.Addr)
+}
+
+func (h *SlashHistory) Encode() ([]byte, xerrors.XError) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	if bz, err := json.Marshal(h); err != nil {
+		return nil, xerrors.From(err)
+	} else {
+		return bz, nil
+	}
+}
+
+func (h *SlashHistory) Decode(d []byte) xerrors.XError {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	if err := json.Unmarshal(d, h); err != nil {
+		return xerrors.From(err)
+	}
+	return nil
+}
+
+func (h *SlashHistory) AddSlashing(s *Slashing) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	h.Slashings = append(h.Slashings, s)
+}
+
+// HasEvidence returns true if the misbehavior of `evi` has been already punished.
+// The evidences of the same type at the same height are regarded as the same misbehavior.
+func (h *SlashHistory) HasEvidence(evi *abcitypes.Evidence) bool {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	typ := abcitypes.EvidenceType_name[int32(evi.Type)]
+	for _, s := range h.Slashings {
+		if s.Type == typ && s.EvidenceHeight == evi.Height {
+			return true
+		}
+	}
+	return false
+}
+
+// SlashingsOf returns the slashings which have slashed the stakes of `owner`.
+// The stakes of the returned slashings are only the ones of `owner`.
+func (h *SlashHistory) SlashingsOf(owner types.Address) []*Slashing {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	var ret []*Slashing
+	for _, s := range h.Slashings {
+		var stakes []*SlashedStake
+		for _, s0 := range s.Stakes {
+			if bytes.Compare(s0.Owner, owner) == 0 {
+				stakes = append(stakes, s0)
+			}
+		}
+		if stakes != nil {
+			ret = append(ret, &Slashing{
+				Type:           s.Type,
+				EvidenceHeight: s.EvidenceHeight,
+				Height:         s.Height,
+				Ratio:          s.Ratio,
+				Stakes:         stakes,
+			})
+		}
+	}
+	return ret
+}
+
+// stakePowers returns the current power of each stake of `delegatee`.
+// It is compared with the power after slashing by slashedStakes.
+func stakePowers(delegatee *Delegatee) []*SlashedStake {
+	var ret []*SlashedStake
+	for _, s0 := range delegatee.GetAllStakes() {
+		ret = append(ret, &SlashedStake{
+			Owner:  s0.From,
+			To:     delegatee.Addr,
+			TxHash: s0.TxHash,
+			Power:  s0.Power,
+		})
+	}
+	return ret
+}
+
+// slashedStakes returns the power which each of `before` has lost in `delegatee`.
+// The stake removed from `delegatee` has lost all its power.
+func slashedStakes(before []*SlashedStake, delegatee *Delegatee) []*SlashedStake {
+	var ret []*SlashedStake
+	for _, s0 := range before {
+		power := int64(0)
+		if _, s1 := delegatee.FindStake(s0.TxHash); s1 != nil {
+			power = s1.Power
+		}
+		if s0.Power > power {
+			s0.Power -= power
+			ret = append(ret, s0)
+		}
+	}
+	return ret
+}
+
+// isPunished returns true if the misbehavior of `evi` is found in `slashLedger`.
+func (ctrler *StakeCtrler) isPunished(evi *abcitypes.Evidence) bool {
+	history, xerr := ctrler.slashLedger.GetFinality(ledger.ToLedgerKey(evi.Validator.Address))
+	if xerr != nil {
+		return false
+	}
+	return history.HasEvidence(evi)
+}
+
+// IsPunished is used by GovCtrler not to punish the same misbehavior twice.
+func (ctrler *StakeCtrler) IsPunished(evi *abcitypes.Evidence) bool {
+	ctrler.mtx.RLock()
+	defer ctrler.mtx.RUnlock()
+
+	return ctrler.isPunished(evi)
+}
+
+func (ctrler *StakeCtrler) addSlashing(addr types.Address, slashing *Slashing) xerrors.XError {
+	history, xerr := ctrler.slashLedger.GetFinality(ledger.ToLedgerKey(addr))
+	if xerr == xerrors.ErrNotFoundResult {
+		history = NewSlashHistory(addr)
+	} else if xerr != nil {
+		return xerr
+	}
+	history.AddSlashing(slashing)
+	return ctrler.slashLedger.SetFinality(history)
+}
@@ -0,0 +1,59 @@
+package stake
+
+import (
+	"github.com/rigochain/rigo-go/types"
+	"github.com/rigochain/rigo-go/types/bytes"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"testing"
+)
+
+func TestSlashHistory_HasEvidence(t *testing.T) {
+	addr := types.RandAddress()
+	evi := &abcitypes.Evidence{
+		Type:      abcitypes.EvidenceType_DUPLICATE_VOTE,
+		Validator: abcitypes.Validator{Address: addr},
+		Height:    10,
+	}
+
+	h := NewSlashHistory(addr)
+	require.False(t, h.HasEvidence(evi))
+	h.AddSlashing(newSlashing(evi, 12, 50))
+	require.True(t, h.HasEvidence(evi))
+
+	bz, xerr := h.Encode()
+	require.NoError(t, xerr)
+	h1 := &SlashHistory{}
+	require.NoError(t, h1.Decode(bz))
+	require.True(t, h1.HasEvidence(evi))
+
+	evi.Height = 11
+	require.False(t, h1.HasEvidence(evi))
+	evi.Height = 10
+	evi.Type = abcitypes.EvidenceType_LIGHT_CLIENT_ATTACK
+	require.False(t, h1.HasEvidence(evi))
+}
+
+func TestSlashedStakes(t *testing.T) {
+	owner0, owner1 := types.RandAddress(), types.RandAddress()
+	delegatee := NewDelegatee(types.RandAddress(), bytes.RandBytes(33))
+	require.NoError(t, delegatee.AddStake(NewStakeWithPower(owner0, delegatee.Addr, 1000, 1, bytes.RandBytes(32))))
+	require.NoError(t, delegatee.AddStake(NewStakeWithPower(owner1, delegatee.Addr, 1, 1, bytes.RandBytes(32))))
+
+	before := stakePowers(delegatee)
+	delegatee.DoSlash(50)
+	slashed := slashedStakes(before, delegatee)
+
+	// the stake too small to be slashed is removed, and it has lost all its power.
+	require.Len(t, slashed, 2)
+	require.Equal(t, owner0, slashed[0].Owner)
+	require.EqualValues(t, 500, slashed[0].Power)
+	require.Equal(t, owner1, slashed[1].Owner)
+	require.EqualValues(t, 1, slashed[1].Power)
+
+	h := NewSlashHistory(delegatee.Addr)
+	h.AddSlashing(&Slashing{Stakes: slashed})
+	require.Len(t, h.SlashingsOf(owner1), 1)
+	require.Len(t, h.SlashingsOf(owner1)[0].Stakes, 1)
+	require.Nil(t, h.SlashingsOf(types.RandAddress()))
+}
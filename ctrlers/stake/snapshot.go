@@ -0,0 +1,148 @@
+package stake
+
+import (
+	"bytes"
+	"fmt"
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/types/xerrors"
+)
+
+const (
+	snapshotStoreDelegatees = "delegatees"
+	snapshotStoreFrozen     = "frozen"
+	snapshotStoreRewards    = "rewards"
+	snapshotStoreRwdHash    = "rwd_hash"
+	snapshotStoreSlashes    = "slashes"
+	snapshotStorePastRoots  = "delegatee_roots"
+
+	// BeginBlock reads the delegatees of `height - 4` to reward validators.
+	// So, the past versions of `delegateeLedger` are also included in a snapshot
+	// and they are verified against the root hashes kept in `pastRootLedger`.
+	pastDelegateesVersions = 3
+)
+
+func pastDelegateesStore(ver int64) string {
+	return fmt.Sprintf("%s_%d", snapshotStoreDelegatees, ver)
+}
+
+func pastDelegateesRange(height int64) (int64, int64) {
+	from := height - pastDelegateesVersions
+	if from < 1 {
+		from = 1
+	}
+	return from, height - 1
+}
+
+func (ctrler *StakeCtrler) Snapshot(height int64) (ctrlertypes.SnapshotExporter, xerrors.XError) {
+	ctrler.mtx.RLock()
+	defer ctrler.mtx.RUnlock()
+
+	// The root hash of `rewardLedger` is included in the app hash only every `rwdLedgUpInterval` blocks,
+	// so a snapshot of the other heights can not be verified.
+	if height%ctrler.rwdLedgUpInterval != 0 {
+		return nil, xerrors.ErrSnapshot.Wrapf("the height(%v) is not a multiple of %v", height, ctrler.rwdLedgUpInterval)
+	}
+
+	delegateeLedger, frozenLedger, rewardLedger, slashLedger, pastRootLedger := ctrler.delegateeLedger, ctrler.frozenLedger, ctrler.rewardLedger, ctrler.slashLedger, ctrler.pastRootLedger
+
+	rwdHashItems, err := ctrler.rwdHashDB.Items(snapshotStoreRwdHash)
+	if err != nil {
+		return nil, xerrors.ErrSnapshot.Wrap(err)
+	}
+
+	return func(cb func(*ctrlertypes.SnapshotItemProto) xerrors.XError) xerrors.XError {
+		if xerr := ctrlertypes.ExportLedgerSnapshot(snapshotStoreDelegatees, height, delegateeLedger, cb); xerr != nil {
+			return xerr
+		}
+		from, to := pastDelegateesRange(height)
+		for ver := from; ver <= to; ver++ {
+			if xerr := ctrlertypes.ExportLedgerSnapshot(pastDelegateesStore(ver), ver, delegateeLedger, cb); xerr != nil {
+				return xerr
+			}
+		}
+		if xerr := ctrlertypes.ExportLedgerSnapshot(snapshotStoreFrozen, height, frozenLedger, cb); xerr != nil {
+			return xerr
+		}
+		if xerr := ctrlertypes.ExportLedgerSnapshot(snapshotStoreRewards, height, rewardLedger, cb); xerr != nil {
+			return xerr
+		}
+		if xerr := ctrlertypes.ExportLedgerSnapshot(snapshotStoreSlashes, height, slashLedger, cb); xerr != nil {
+			return xerr
+		}
+		if xerr := ctrlertypes.ExportLedgerSnapshot(snapshotStorePastRoots, height, pastRootLedger, cb); xerr != nil {
+			return xerr
+		}
+		for _, item := range rwdHashItems {
+			if xerr := cb(item); xerr != nil {
+				return xerr
+			}
+		}
+		return nil
+	}, nil
+}
+
+func (ctrler *StakeCtrler) RestoreSnapshot(height int64, items ctrlertypes.SnapshotItems) ([]byte, xerrors.XError) {
+	ctrler.mtx.Lock()
+	defer ctrler.mtx.Unlock()
+
+	if height%ctrler.rwdLedgUpInterval != 0 {
+		return nil, xerrors.ErrSnapshot.Wrapf("the height(%v) is not a multiple of %v", height, ctrler.rwdLedgUpInterval)
+	}
+
+	h0, xerr := ctrlertypes.ImportLedgerSnapshot(snapshotStoreDelegatees, height, ctrler.delegateeLedger, items)
+	if xerr != nil {
+		return nil, xerr
+	}
+	h1, xerr := ctrlertypes.ImportLedgerSnapshot(snapshotStoreFrozen, height, ctrler.frozenLedger, items)
+	if xerr != nil {
+		return nil, xerr
+	}
+	h2, xerr := ctrlertypes.ImportLedgerSnapshot(snapshotStoreRewards, height, ctrler.rewardLedger, items)
+	if xerr != nil {
+		return nil, xerr
+	}
+	h3, xerr := ctrlertypes.ImportLedgerSnapshot(snapshotStoreSlashes, height, ctrler.slashLedger, items)
+	if xerr != nil {
+		return nil, xerr
+	}
+	h4, xerr := ctrlertypes.ImportLedgerSnapshot(snapshotStorePastRoots, height, ctrler.pastRootLedger, items)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	// The past versions of `delegateeLedger` are not in the app hash directly.
+	// They are verified against the root hashes in `pastRootLedger`, which is in the app hash.
+	pastRoots, xerr := ctrler.pastRootLedger.Get(pastRootsKey)
+	if xerr != nil {
+		return nil, xerr
+	}
+	from, to := pastDelegateesRange(height)
+	if pastRoots.Height != height || pastRoots.From != from || int64(len(pastRoots.Roots)) != to-from+1 {
+		return nil, xerrors.ErrSnapshot.Wrapf("wrong root hashes of the past delegatees - height:%v, from:%v, count:%v", pastRoots.Height, pastRoots.From, len(pastRoots.Roots))
+	}
+	for ver := from; ver <= to; ver++ {
+		if xerr := ctrlertypes.ImportPastLedgerSnapshot(pastDelegateesStore(ver), ver, ctrler.delegateeLedger, items); xerr != nil {
+			return nil, xerr
+		}
+		h, xerr := ctrler.delegateeLedger.RootHashAt(ver)
+		if xerr != nil {
+			return nil, xerr
+		}
+		if !bytes.Equal(h, pastRoots.Roots[ver-from]) {
+			return nil, xerrors.ErrSnapshot.Wrapf("wrong root hash of the delegatees at %v - expected:%x, actual:%x", ver, pastRoots.Roots[ver-from], h)
+		}
+	}
+
+	if err := ctrler.rwdHashDB.PutItems(items[snapshotStoreRwdHash]); err != nil {
+		return nil, xerrors.ErrSnapshot.Wrap(err)
+	}
+	ctrler.lastRwdHash = ctrler.rwdHashDB.LastRewardHash()
+	if !bytes.Equal(h2, ctrler.lastRwdHash) {
+		return nil, xerrors.ErrSnapshot.Wrapf("wrong reward hash - expected:%x, actual:%x", ctrler.lastRwdHash, h2)
+	}
+	ctrler.resetTotals()
+
+	return rootHashes(h0, h1, h2, h3, h4).Hash(), nil
+}
+
+var _ ctrlertypes.ISnapshotHandler = (*StakeCtrler)(nil)
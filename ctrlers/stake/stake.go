@@ -24,6 +24,12 @@ type Stake struct {
 
 	Power int64 `json:"power,string"`
 
+	// the delegatee from which the stake has been moved by TRX_REDELEGATE and the height when it has been moved.
+	// until `RedelegatedHeight + LazyRewardBlocks`, the stake is slashed for the misbehavior of `RedelegatedFrom`
+	// committed at or before `RedelegatedHeight`.
+	RedelegatedFrom   types.Address `json:"redelegatedFrom,omitempty"`
+	RedelegatedHeight int64         `json:"redelegatedHeight,omitempty,string"`
+
 	mtx sync.RWMutex
 }
 
@@ -95,6 +101,9 @@ func (s *Stake) Clone() *Stake {
 		StartHeight:  s.StartHeight,
 		RefundHeight: s.RefundHeight,
 		Power:        s.Power,
+
+		RedelegatedFrom:   append(s.RedelegatedFrom, nil...),
+		RedelegatedHeight: s.RedelegatedHeight,
 	}
 }
 
@@ -0,0 +1,113 @@
+package stake
+
+import (
+	"github.com/holiman/uint256"
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/ledger"
+	"github.com/rigochain/rigo-go/types/xerrors"
+)
+
+// totals has the sum of the frozen stakes and the sum of the rewards in the committed ledgers.
+// They are updated by the changes of each block at Commit, so the ledgers are not scanned every block.
+type totals struct {
+	frozen  *uint256.Int
+	rewards *uint256.Int
+
+	// the keys of the frozen stakes removed in the current block.
+	unfrozenKeys []ledger.LedgerKey
+}
+
+// updateTotals applies the changes of the current block to `ctrler.totals`.
+// It MUST be called before frozenLedger and rewardLedger are committed,
+// because the previous value of an updated item is read from the committed ledger.
+func (ctrler *StakeCtrler) updateTotals() xerrors.XError {
+	unfrozenKeys := ctrler.totals.unfrozenKeys
+	ctrler.totals.unfrozenKeys = nil
+
+	if ctrler.totals.frozen == nil || ctrler.totals.rewards == nil {
+		// not loaded yet. they are loaded from the committed ledgers after Commit.
+		return nil
+	}
+
+	frozen := new(uint256.Int).Set(ctrler.totals.frozen)
+	rewards := new(uint256.Int).Set(ctrler.totals.rewards)
+
+	for _, k := range unfrozenKeys {
+		s0, xerr := ctrler.frozenLedger.Read(k)
+		if xerr == xerrors.ErrNotFoundResult {
+			// frozen and unfrozen in the same block
+			continue
+		} else if xerr != nil {
+			return xerr
+		}
+		_ = frozen.Sub(frozen, ctrlertypes.PowerToAmount(s0.Power))
+	}
+	if xerr := ctrler.frozenLedger.IterateFinalityUpdatedItems(func(s0 *Stake) xerrors.XError {
+		if prev, xerr := ctrler.frozenLedger.Read(s0.Key()); xerr == nil {
+			_ = frozen.Sub(frozen, ctrlertypes.PowerToAmount(prev.Power))
+		} else if xerr != xerrors.ErrNotFoundResult {
+			return xerr
+		}
+		_ = frozen.Add(frozen, ctrlertypes.PowerToAmount(s0.Power))
+		return nil
+	}); xerr != nil {
+		return xerr
+	}
+
+	if xerr := ctrler.rewardLedger.IterateFinalityUpdatedItems(func(rwd *Reward) xerrors.XError {
+		if prev, xerr := ctrler.rewardLedger.Read(rwd.Key()); xerr == nil {
+			_ = rewards.Sub(rewards, prev.GetCumulated())
+		} else if xerr != xerrors.ErrNotFoundResult {
+			return xerr
+		}
+		_ = rewards.Add(rewards, rwd.GetCumulated())
+		return nil
+	}); xerr != nil {
+		return xerr
+	}
+
+	ctrler.totals.frozen, ctrler.totals.rewards = frozen, rewards
+	return nil
+}
+
+// loadTotals computes `ctrler.totals` from the committed ledgers, if they are not loaded.
+// It is called only after the ledgers are opened, rolled back or restored.
+func (ctrler *StakeCtrler) loadTotals() {
+	if ctrler.totals.frozen != nil && ctrler.totals.rewards != nil {
+		return
+	}
+
+	frozen, rewards := uint256.NewInt(0), uint256.NewInt(0)
+	_ = ctrler.frozenLedger.IterateReadAllFinalityItems(func(s0 *Stake) xerrors.XError {
+		_ = frozen.Add(frozen, ctrlertypes.PowerToAmount(s0.Power))
+		return nil
+	})
+	_ = ctrler.rewardLedger.IterateReadAllFinalityItems(func(rwd *Reward) xerrors.XError {
+		_ = rewards.Add(rewards, rwd.GetCumulated())
+		return nil
+	})
+	ctrler.totals.frozen, ctrler.totals.rewards = frozen, rewards
+}
+
+// resetTotals makes `ctrler.totals` be loaded again at the next Commit.
+func (ctrler *StakeCtrler) resetTotals() {
+	ctrler.totals = totals{}
+}
+
+// TotalFrozenAmount returns the sum of the frozen stakes at the last committed height.
+func (ctrler *StakeCtrler) TotalFrozenAmount() *uint256.Int {
+	ctrler.mtx.Lock()
+	defer ctrler.mtx.Unlock()
+
+	ctrler.loadTotals()
+	return new(uint256.Int).Set(ctrler.totals.frozen)
+}
+
+// TotalRewardAmount returns the sum of the rewards which are not withdrawn at the last committed height.
+func (ctrler *StakeCtrler) TotalRewardAmount() *uint256.Int {
+	ctrler.mtx.Lock()
+	defer ctrler.mtx.Unlock()
+
+	ctrler.loadTotals()
+	return new(uint256.Int).Set(ctrler.totals.rewards)
+}
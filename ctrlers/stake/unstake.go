@@ -0,0 +1,83 @@
+package stake
+
+import (
+	"encoding/json"
+	"github.com/holiman/uint256"
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/types/bytes"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+// validateUnstakingAmount checks the un-staking of `payload.Amount`,
+// which is taken from the stake of `payload.TxHash` or from all the stakes of the sender on `delegatee`.
+func (ctrler *StakeCtrler) validateUnstakingAmount(ctx *ctrlertypes.TrxContext, delegatee *Delegatee, payload *ctrlertypes.TrxPayloadUnstaking) xerrors.XError {
+	if payload.TxHash != nil && len(payload.TxHash) != 32 {
+		return xerrors.ErrInvalidTrxPayloadParams
+	}
+
+	q, r := new(uint256.Int).DivMod(payload.Amount, ctrlertypes.AmountPerPower(), new(uint256.Int))
+	if q.Sign() <= 0 || r.Sign() != 0 {
+		return xerrors.ErrInvalidAmount.Wrapf("wrong amount: it should be multiple of %v", ctrlertypes.AmountPerPower())
+	}
+	power := ctrlertypes.AmountToPower(payload.Amount)
+
+	availablePower := int64(0)
+	if payload.TxHash != nil {
+		_, s0 := delegatee.FindStake(payload.TxHash)
+		if s0 == nil {
+			return xerrors.ErrNotFoundStake
+		}
+		if ctx.Tx.From.Compare(s0.From) != 0 {
+			return xerrors.ErrNotFoundStake.Wrapf("you not stake owner")
+		}
+		availablePower = s0.Power
+	} else {
+		availablePower = delegatee.SumPowerOf(ctx.Tx.From)
+		if availablePower == 0 {
+			return xerrors.ErrNotFoundStake
+		}
+	}
+	if power > availablePower {
+		return xerrors.ErrInvalidAmount.Wrapf("insufficient stake: the power to un-stake is %v, but the staked power is %v", power, availablePower)
+	}
+
+	// issue #34: check updatable stake ratio
+	if len(ctrler.lastValidators) >= 3 {
+		if xerr := ctrler.stakeLimiter.CheckLimit(delegatee, -1*power); xerr != nil {
+			return xerrors.ErrUpdatableStakeRatio.Wrap(xerr)
+		}
+	}
+	return nil
+}
+
+// exeUnstakingAmount freezes only the power of `payload.Amount`, and the rest of the stakes keeps being rewarded.
+// The frozen stakes are returned as the JSON array in `ctx.RetData`.
+func (ctrler *StakeCtrler) exeUnstakingAmount(ctx *ctrlertypes.TrxContext, delegatee *Delegatee, payload *ctrlertypes.TrxPayloadUnstaking, setUpdateFrozen func(*Stake) xerrors.XError) xerrors.XError {
+	frozen, xerr := delegatee.TakePower(ctx.Tx.From, payload.TxHash, ctrlertypes.AmountToPower(payload.Amount))
+	if xerr != nil {
+		return xerr
+	}
+
+	for _, s0 := range frozen {
+		if _, s1 := delegatee.FindStake(s0.TxHash); s1 != nil {
+			// the rest of the split stake is still delegated with the tx hash,
+			// so the frozen part is identified by the hash of the un-staking tx and the stake.
+			s0.TxHash = frozenStakeHash(ctx.TxHash, s0.TxHash)
+		}
+		s0.RefundHeight = ctx.Height + ctx.GovHandler.LazyRewardBlocks()
+		_ = setUpdateFrozen(s0) // add s0 to frozen ledger
+		ctx.Events = append(ctx.Events, stakeFrozenEvent(s0, ctrlertypes.EVENT_REASON_UNSTAKING))
+	}
+
+	bz, err := json.Marshal(frozen)
+	if err != nil {
+		return xerrors.From(err)
+	}
+	ctx.RetData = bz
+	return nil
+}
+
+func frozenStakeHash(unstakingTxHash, stakeTxHash bytes.HexBytes) bytes.HexBytes {
+	return tmhash.Sum(append(append([]byte{}, unstakingTxHash...), stakeTxHash...))
+}
@@ -25,7 +25,11 @@ type Account struct {
 	Balance *uint256.Int  `json:"balance"`
 	Code    []byte        `json:"code,omitempty"`
 	DocURL  string        `json:"docURL,omitempty"`
-	mtx     sync.RWMutex
+	// it is not nil, if the account is a multi-signature account.
+	MultiSig *MultiSig `json:"multiSig,omitempty"`
+	// it is not empty, if the account is a vesting account.
+	Vestings []*Vesting `json:"vestings,omitempty"`
+	mtx      sync.RWMutex
 }
 
 func NewAccount(addr types.Address) *Account {
@@ -47,11 +51,14 @@ func (acct *Account) Clone() *Account {
 	defer acct.mtx.RUnlock()
 
 	return &Account{
-		Address: acct.Address,
-		Name:    acct.Name,
-		Nonce:   acct.Nonce,
-		Balance: acct.Balance.Clone(),
-		Code:    acct.Code,
+		Address:  acct.Address,
+		Name:     acct.Name,
+		Nonce:    acct.Nonce,
+		Balance:  acct.Balance.Clone(),
+		Code:     acct.Code,
+		DocURL:   acct.DocURL,
+		MultiSig: acct.MultiSig,
+		Vestings: acct.Vestings,
 	}
 }
 
@@ -163,13 +170,42 @@ func (acct *Account) GetBalance() *uint256.Int {
 	return new(uint256.Int).Set(acct.Balance)
 }
 
-func (acct *Account) CheckBalance(amt *uint256.Int) xerrors.XError {
+// CheckBalance returns an error if `amt` is more than the balance spendable at `now`.
+// The balance locked by the vesting schedules is not spendable.
+func (acct *Account) CheckBalance(amt *uint256.Int, now int64) xerrors.XError {
+	acct.mtx.RLock()
+	defer acct.mtx.RUnlock()
+
 	if amt.Cmp(acct.Balance) > 0 {
 		return xerrors.ErrInsufficientFund
 	}
+	if locked := acct.lockedBalance(now); locked.Sign() > 0 &&
+		amt.Cmp(new(uint256.Int).Sub(acct.Balance, locked)) > 0 {
+		return xerrors.ErrInsufficientFund.Wrapf("locked balance: %v", locked.Dec())
+	}
 	return nil
 }
 
+// LockedBalance returns the balance locked by the vesting schedules at `now`.
+// It may be less than the locked amount of the schedules, if the locked amount has been staked.
+func (acct *Account) LockedBalance(now int64) *uint256.Int {
+	acct.mtx.RLock()
+	defer acct.mtx.RUnlock()
+
+	return acct.lockedBalance(now)
+}
+
+func (acct *Account) lockedBalance(now int64) *uint256.Int {
+	locked := uint256.NewInt(0)
+	for _, v := range acct.Vestings {
+		_ = locked.Add(locked, v.Locked(now))
+	}
+	if locked.Gt(acct.Balance) {
+		return locked.Set(acct.Balance)
+	}
+	return locked
+}
+
 func (acct *Account) SetCode(c []byte) {
 	acct.mtx.Lock()
 	defer acct.mtx.Unlock()
@@ -184,6 +220,47 @@ func (acct *Account) GetCode() []byte {
 	return acct.Code
 }
 
+// SetMultiSig makes the account a multi-signature account.
+// `ms` is never changed after it is set, so it is shared by the clones of the account.
+func (acct *Account) SetMultiSig(ms *MultiSig) {
+	acct.mtx.Lock()
+	defer acct.mtx.Unlock()
+
+	acct.MultiSig = ms
+}
+
+func (acct *Account) GetMultiSig() *MultiSig {
+	acct.mtx.RLock()
+	defer acct.mtx.RUnlock()
+
+	return acct.MultiSig
+}
+
+// AddVesting adds the vesting schedule `v` to the account, which makes the account a vesting account.
+// Each schedule locks its own amount independently, so a new schedule never changes the existing ones.
+// The schedules all unlocked at `now` are removed.
+// `v` is never changed after it is added and the slice is never modified in place,
+// so they are shared by the clones of the account.
+func (acct *Account) AddVesting(v *Vesting, now int64) {
+	acct.mtx.Lock()
+	defer acct.mtx.Unlock()
+
+	vestings := make([]*Vesting, 0, len(acct.Vestings)+1)
+	for _, v0 := range acct.Vestings {
+		if v0.Locked(now).Sign() > 0 {
+			vestings = append(vestings, v0)
+		}
+	}
+	acct.Vestings = append(vestings, v)
+}
+
+func (acct *Account) GetVestings() []*Vesting {
+	acct.mtx.RLock()
+	defer acct.mtx.RUnlock()
+
+	return acct.Vestings
+}
+
 func (acct *Account) Type() int16 {
 	return types.ACCT_COMMON_TYPE
 }
@@ -196,14 +273,24 @@ func (acct *Account) Key() ledger.LedgerKey {
 }
 
 func (acct *Account) Encode() ([]byte, xerrors.XError) {
-	if bz, err := proto.Marshal(&AcctProto{
+	pm := &AcctProto{
 		Address:  acct.Address,
 		Name:     acct.Name,
 		Nonce:    acct.Nonce,
 		XBalance: acct.Balance.Bytes(),
 		XCode:    acct.Code,
 		DocUrl:   acct.DocURL,
-	}); err != nil {
+	}
+	if acct.MultiSig != nil {
+		pm.MultisigThreshold = acct.MultiSig.Threshold
+		for _, k := range acct.MultiSig.PubKeys {
+			pm.MultisigPubKeys = append(pm.MultisigPubKeys, k)
+		}
+	}
+	for _, v := range acct.Vestings {
+		pm.Vestings = append(pm.Vestings, v.toProto())
+	}
+	if bz, err := proto.Marshal(pm); err != nil {
 		return nil, xerrors.From(err)
 	} else {
 		return bz, nil
@@ -222,6 +309,19 @@ func (acct *Account) Decode(d []byte) xerrors.XError {
 	acct.Balance = new(uint256.Int).SetBytes(pm.XBalance)
 	acct.Code = pm.XCode
 	acct.DocURL = pm.DocUrl
+	acct.MultiSig = nil
+	if len(pm.MultisigPubKeys) > 0 {
+		acct.MultiSig = &MultiSig{Threshold: pm.MultisigThreshold}
+		for _, k := range pm.MultisigPubKeys {
+			acct.MultiSig.PubKeys = append(acct.MultiSig.PubKeys, k)
+		}
+	}
+	acct.Vestings = nil
+	for _, vm := range pm.Vestings {
+		v := &Vesting{}
+		v.fromProto(vm)
+		acct.Vestings = append(acct.Vestings, v)
+	}
 	return nil
 }
 
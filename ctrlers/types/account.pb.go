@@ -25,12 +25,15 @@ type AcctProto struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Address  []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
-	Name     string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Nonce    uint64 `protobuf:"varint,3,opt,name=nonce,proto3" json:"nonce,omitempty"`
-	XBalance []byte `protobuf:"bytes,4,opt,name=_balance,json=Balance,proto3" json:"_balance,omitempty"`
-	XCode    []byte `protobuf:"bytes,5,opt,name=_code,json=Code,proto3" json:"_code,omitempty"`
-	DocUrl   string `protobuf:"bytes,6,opt,name=doc_url,json=docUrl,proto3" json:"doc_url,omitempty"`
+	Address           []byte          `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Name              string          `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Nonce             uint64          `protobuf:"varint,3,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	XBalance          []byte          `protobuf:"bytes,4,opt,name=_balance,json=Balance,proto3" json:"_balance,omitempty"`
+	XCode             []byte          `protobuf:"bytes,5,opt,name=_code,json=Code,proto3" json:"_code,omitempty"`
+	DocUrl            string          `protobuf:"bytes,6,opt,name=doc_url,json=docUrl,proto3" json:"doc_url,omitempty"`
+	MultisigThreshold uint32          `protobuf:"varint,7,opt,name=multisig_threshold,json=multisigThreshold,proto3" json:"multisig_threshold,omitempty"`
+	MultisigPubKeys   [][]byte        `protobuf:"bytes,8,rep,name=multisig_pub_keys,json=multisigPubKeys,proto3" json:"multisig_pub_keys,omitempty"`
+	Vestings          []*VestingProto `protobuf:"bytes,9,rep,name=vestings,proto3" json:"vestings,omitempty"`
 }
 
 func (x *AcctProto) Reset() {
@@ -107,11 +110,111 @@ func (x *AcctProto) GetDocUrl() string {
 	return ""
 }
 
+func (x *AcctProto) GetMultisigThreshold() uint32 {
+	if x != nil {
+		return x.MultisigThreshold
+	}
+	return 0
+}
+
+func (x *AcctProto) GetMultisigPubKeys() [][]byte {
+	if x != nil {
+		return x.MultisigPubKeys
+	}
+	return nil
+}
+
+func (x *AcctProto) GetVestings() []*VestingProto {
+	if x != nil {
+		return x.Vestings
+	}
+	return nil
+}
+
+type VestingProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	XAmount []byte `protobuf:"bytes,1,opt,name=_amount,json=Amount,proto3" json:"_amount,omitempty"`
+	Start   int64  `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	Cliff   int64  `protobuf:"varint,3,opt,name=cliff,proto3" json:"cliff,omitempty"`
+	End     int64  `protobuf:"varint,4,opt,name=end,proto3" json:"end,omitempty"`
+	Period  int64  `protobuf:"varint,5,opt,name=period,proto3" json:"period,omitempty"`
+}
+
+func (x *VestingProto) Reset() {
+	*x = VestingProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_account_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VestingProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VestingProto) ProtoMessage() {}
+
+func (x *VestingProto) ProtoReflect() protoreflect.Message {
+	mi := &file_account_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VestingProto.ProtoReflect.Descriptor instead.
+func (*VestingProto) Descriptor() ([]byte, []int) {
+	return file_account_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *VestingProto) GetXAmount() []byte {
+	if x != nil {
+		return x.XAmount
+	}
+	return nil
+}
+
+func (x *VestingProto) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *VestingProto) GetCliff() int64 {
+	if x != nil {
+		return x.Cliff
+	}
+	return 0
+}
+
+func (x *VestingProto) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *VestingProto) GetPeriod() int64 {
+	if x != nil {
+		return x.Period
+	}
+	return 0
+}
+
 var File_account_proto protoreflect.FileDescriptor
 
 var file_account_proto_rawDesc = []byte{
 	0x0a, 0x0d, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
-	0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x22, 0x98, 0x01, 0x0a, 0x09, 0x41, 0x63, 0x63, 0x74, 0x50,
+	0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x22, 0xa4, 0x02, 0x0a, 0x09, 0x41, 0x63, 0x63, 0x74, 0x50,
 	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12,
 	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
@@ -121,10 +224,27 @@ var file_account_proto_rawDesc = []byte{
 	0x6e, 0x63, 0x65, 0x12, 0x13, 0x0a, 0x05, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01,
 	0x28, 0x0c, 0x52, 0x04, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x6f, 0x63, 0x5f,
 	0x75, 0x72, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x6f, 0x63, 0x55, 0x72,
-	0x6c, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x72, 0x69, 0x67, 0x6f, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x2f, 0x72, 0x69, 0x67, 0x6f, 0x2d, 0x67,
-	0x6f, 0x2f, 0x63, 0x74, 0x72, 0x6c, 0x65, 0x72, 0x73, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6c, 0x12, 0x2d, 0x0a, 0x12, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x73, 0x69, 0x67, 0x5f, 0x74, 0x68,
+	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x6d,
+	0x75, 0x6c, 0x74, 0x69, 0x73, 0x69, 0x67, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
+	0x12, 0x2a, 0x0a, 0x11, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x73, 0x69, 0x67, 0x5f, 0x70, 0x75, 0x62,
+	0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0f, 0x6d, 0x75, 0x6c,
+	0x74, 0x69, 0x73, 0x69, 0x67, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x2f, 0x0a, 0x08,
+	0x76, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x56, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x52, 0x08, 0x76, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x7d, 0x0a,
+	0x0c, 0x56, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17, 0x0a,
+	0x07, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
+	0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x63, 0x6c, 0x69, 0x66, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6c, 0x69,
+	0x66, 0x66, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x03, 0x65, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x42, 0x2c, 0x5a, 0x2a,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x69, 0x67, 0x6f, 0x63,
+	0x68, 0x61, 0x69, 0x6e, 0x2f, 0x72, 0x69, 0x67, 0x6f, 0x2d, 0x67, 0x6f, 0x2f, 0x63, 0x74, 0x72,
+	0x6c, 0x65, 0x72, 0x73, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -139,16 +259,18 @@ func file_account_proto_rawDescGZIP() []byte {
 	return file_account_proto_rawDescData
 }
 
-var file_account_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_account_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_account_proto_goTypes = []interface{}{
-	(*AcctProto)(nil), // 0: types.AcctProto
+	(*AcctProto)(nil),    // 0: types.AcctProto
+	(*VestingProto)(nil), // 1: types.VestingProto
 }
 var file_account_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	1, // 0: types.AcctProto.vestings:type_name -> types.VestingProto
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_account_proto_init() }
@@ -169,6 +291,18 @@ func file_account_proto_init() {
 				return nil
 			}
 		}
+		file_account_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VestingProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -176,7 +310,7 @@ func file_account_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_account_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
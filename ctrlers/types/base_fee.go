@@ -0,0 +1,48 @@
+package types
+
+import (
+	"github.com/holiman/uint256"
+)
+
+// NextBaseFee returns the base fee of the block following the block, which has `baseFee` and used `gasUsed`.
+// Like EIP-1559, the base fee goes up when the gas used is more than `TargetBlockGas`, and goes down otherwise.
+// The change is proportional to the difference from the target, and is at most `BaseFeeChangeRatio`(%) of `baseFee`.
+// The base fee is never less than `GasPrice`, which is the minimum gas price of the network.
+func NextBaseFee(baseFee *uint256.Int, gasUsed uint64, govHandler IGovHandler) *uint256.Int {
+	minFee := govHandler.GasPrice()
+	target := govHandler.TargetBlockGas()
+	ratio := govHandler.BaseFeeChangeRatio()
+	if target == 0 || baseFee == nil || baseFee.Lt(minFee) {
+		return minFee.Clone()
+	}
+	if gasUsed == target || ratio <= 0 {
+		return baseFee.Clone()
+	}
+
+	diff := target - gasUsed
+	if gasUsed > target {
+		diff = gasUsed - target
+	}
+	if diff > target {
+		diff = target
+	}
+
+	// delta = baseFee * diff / target * ratio / 100
+	delta := new(uint256.Int).Mul(baseFee, uint256.NewInt(diff))
+	_ = delta.Mul(delta, uint256.NewInt(uint64(ratio)))
+	_ = delta.Div(delta, uint256.NewInt(target))
+	_ = delta.Div(delta, uint256.NewInt(100))
+
+	if gasUsed > target {
+		if delta.IsZero() {
+			delta = uint256.NewInt(1)
+		}
+		return new(uint256.Int).Add(baseFee, delta)
+	}
+
+	ret := new(uint256.Int).Sub(baseFee, delta)
+	if ret.Lt(minFee) {
+		return minFee.Clone()
+	}
+	return ret
+}
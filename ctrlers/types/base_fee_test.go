@@ -0,0 +1,43 @@
+package types
+
+import (
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNextBaseFee(t *testing.T) {
+	params := Test8GovParams_BaseFee()
+	minFee := params.GasPrice()
+	target := params.TargetBlockGas()
+	percentOf := func(fee *uint256.Int, ratio uint64) *uint256.Int {
+		ret := new(uint256.Int).Mul(fee, uint256.NewInt(ratio))
+		return ret.Div(ret, uint256.NewInt(100))
+	}
+
+	// the base fee of the block context saved before the base fee is introduced.
+	require.Equal(t, minFee, NextBaseFee(nil, target*2, params))
+
+	// at the target, the base fee is not changed.
+	baseFee := new(uint256.Int).Mul(minFee, uint256.NewInt(2))
+	require.Equal(t, baseFee, NextBaseFee(baseFee, target, params))
+
+	// the change is at most 12% of the base fee.
+	require.Equal(t, percentOf(baseFee, 112), NextBaseFee(baseFee, target*2, params))
+	require.Equal(t, percentOf(baseFee, 112), NextBaseFee(baseFee, target*10, params))
+	require.Equal(t, percentOf(baseFee, 106), NextBaseFee(baseFee, target+target/2, params))
+	require.Equal(t, percentOf(baseFee, 94), NextBaseFee(baseFee, target/2, params))
+	require.Equal(t, percentOf(baseFee, 88), NextBaseFee(baseFee, 0, params))
+
+	// it is never less than the gas price.
+	require.Equal(t, minFee, NextBaseFee(minFee, 0, params))
+
+	// it is raised by 1 at least.
+	one := uint256.NewInt(1)
+	params.gasPrice = one
+	require.Equal(t, uint256.NewInt(2), NextBaseFee(one, target+1, params))
+
+	// if the target is 0, the base fee is always the gas price.
+	params.targetBlockGas = 0
+	require.Equal(t, one, NextBaseFee(uint256.NewInt(100), target*2, params))
+}
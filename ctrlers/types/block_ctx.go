@@ -13,6 +13,10 @@ import (
 type BlockContext struct {
 	blockInfo abcitypes.RequestBeginBlock
 	feeSum    *uint256.Int
+	issued    *uint256.Int
+	burned    *uint256.Int
+	baseFee   *uint256.Int
+	gasUsed   uint64
 	txsCnt    int
 	appHash   bytes.HexBytes
 
@@ -29,6 +33,8 @@ func NewBlockContext(bi abcitypes.RequestBeginBlock, g IGovHandler, a IAccountHa
 	return &BlockContext{
 		blockInfo:    bi,
 		feeSum:       uint256.NewInt(0),
+		issued:       uint256.NewInt(0),
+		burned:       uint256.NewInt(0),
 		txsCnt:       0,
 		appHash:      nil,
 		GovHandler:   g,
@@ -45,6 +51,32 @@ func (bctx *BlockContext) BlockInfo() abcitypes.RequestBeginBlock {
 	return bctx.blockInfo
 }
 
+// ByzantineValidators returns the evidences of the block without duplicates.
+// The evidences having the same type, validator and height are the same misbehavior,
+// so only the first of them is returned not to be punished more than once.
+func (bctx *BlockContext) ByzantineValidators() []abcitypes.Evidence {
+	bctx.mtx.RLock()
+	defer bctx.mtx.RUnlock()
+
+	type misbehavior struct {
+		typ    abcitypes.EvidenceType
+		addr   string
+		height int64
+	}
+
+	var evis []abcitypes.Evidence
+	found := make(map[misbehavior]struct{})
+	for _, evi := range bctx.blockInfo.ByzantineValidators {
+		k := misbehavior{typ: evi.Type, addr: string(evi.Validator.Address), height: evi.Height}
+		if _, ok := found[k]; ok {
+			continue
+		}
+		found[k] = struct{}{}
+		evis = append(evis, evi)
+	}
+	return evis
+}
+
 func (bctx *BlockContext) SetHeight(h int64) {
 	bctx.mtx.Lock()
 	defer bctx.mtx.Unlock()
@@ -119,6 +151,88 @@ func (bctx *BlockContext) AddFee(fee *uint256.Int) {
 	_ = bctx.feeSum.Add(bctx.feeSum, fee)
 }
 
+// Issued returns the amount newly issued in the block (e.g. the staking rewards).
+func (bctx *BlockContext) Issued() *uint256.Int {
+	bctx.mtx.RLock()
+	defer bctx.mtx.RUnlock()
+
+	if bctx.issued == nil {
+		return uint256.NewInt(0)
+	}
+	return bctx.issued.Clone()
+}
+
+func (bctx *BlockContext) AddIssued(amt *uint256.Int) {
+	bctx.mtx.Lock()
+	defer bctx.mtx.Unlock()
+
+	if bctx.issued == nil {
+		bctx.issued = uint256.NewInt(0)
+	}
+	_ = bctx.issued.Add(bctx.issued, amt)
+}
+
+// Burned returns the amount removed from the supply in the block (e.g. the burned fee and the slashed stakes).
+func (bctx *BlockContext) Burned() *uint256.Int {
+	bctx.mtx.RLock()
+	defer bctx.mtx.RUnlock()
+
+	if bctx.burned == nil {
+		return uint256.NewInt(0)
+	}
+	return bctx.burned.Clone()
+}
+
+func (bctx *BlockContext) AddBurned(amt *uint256.Int) {
+	bctx.mtx.Lock()
+	defer bctx.mtx.Unlock()
+
+	if bctx.burned == nil {
+		bctx.burned = uint256.NewInt(0)
+	}
+	_ = bctx.burned.Add(bctx.burned, amt)
+}
+
+// BaseFee returns the base fee of the block.
+// It is nil for the block context saved before the base fee is introduced.
+func (bctx *BlockContext) BaseFee() *uint256.Int {
+	bctx.mtx.RLock()
+	defer bctx.mtx.RUnlock()
+
+	if bctx.baseFee == nil {
+		return nil
+	}
+	return bctx.baseFee.Clone()
+}
+
+func (bctx *BlockContext) SetBaseFee(baseFee *uint256.Int) {
+	bctx.mtx.Lock()
+	defer bctx.mtx.Unlock()
+
+	bctx.baseFee = baseFee
+}
+
+func (bctx *BlockContext) GasUsed() uint64 {
+	bctx.mtx.RLock()
+	defer bctx.mtx.RUnlock()
+
+	return bctx.gasUsed
+}
+
+func (bctx *BlockContext) SetGasUsed(gas uint64) {
+	bctx.mtx.Lock()
+	defer bctx.mtx.Unlock()
+
+	bctx.gasUsed = gas
+}
+
+func (bctx *BlockContext) AddGasUsed(gas uint64) {
+	bctx.mtx.Lock()
+	defer bctx.mtx.Unlock()
+
+	bctx.gasUsed += gas
+}
+
 func (bctx *BlockContext) TxsCnt() int {
 	bctx.mtx.RLock()
 	defer bctx.mtx.RUnlock()
@@ -154,11 +268,15 @@ func (bctx *BlockContext) MarshalJSON() ([]byte, error) {
 	_bctx := &struct {
 		BlockInfo abcitypes.RequestBeginBlock `json:"blockInfo"`
 		GasSum    *uint256.Int                `json:"feeSum"`
+		BaseFee   *uint256.Int                `json:"baseFee,omitempty"`
+		GasUsed   uint64                      `json:"gasUsed"`
 		TxsCnt    int                         `json:"txsCnt"`
 		AppHash   []byte                      `json:"appHash"`
 	}{
 		BlockInfo: bctx.blockInfo,
 		GasSum:    bctx.feeSum,
+		BaseFee:   bctx.baseFee,
+		GasUsed:   bctx.gasUsed,
 		TxsCnt:    bctx.txsCnt,
 		AppHash:   bctx.appHash,
 	}
@@ -173,6 +291,8 @@ func (bctx *BlockContext) UnmarshalJSON(bz []byte) error {
 	_bctx := &struct {
 		BlockInfo abcitypes.RequestBeginBlock `json:"blockInfo"`
 		GasSum    *uint256.Int                `json:"feeSum"`
+		BaseFee   *uint256.Int                `json:"baseFee,omitempty"`
+		GasUsed   uint64                      `json:"gasUsed"`
 		TxsCnt    int                         `json:"txsCnt"`
 		AppHash   []byte                      `json:"appHash"`
 	}{}
@@ -182,6 +302,8 @@ func (bctx *BlockContext) UnmarshalJSON(bz []byte) error {
 	}
 	bctx.blockInfo = _bctx.BlockInfo
 	bctx.feeSum = _bctx.GasSum
+	bctx.baseFee = _bctx.BaseFee
+	bctx.gasUsed = _bctx.GasUsed
 	bctx.txsCnt = _bctx.TxsCnt
 	bctx.appHash = _bctx.AppHash
 	return nil
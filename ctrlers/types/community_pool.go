@@ -0,0 +1,98 @@
+package types
+
+import (
+	"encoding/json"
+	"github.com/holiman/uint256"
+	"github.com/rigochain/rigo-go/ledger"
+	"github.com/rigochain/rigo-go/types"
+	"github.com/rigochain/rigo-go/types/bytes"
+	"github.com/rigochain/rigo-go/types/crypto"
+	"github.com/rigochain/rigo-go/types/xerrors"
+)
+
+// CommunityPoolAddress is the address of the account owned by the protocol, which receives the share of fees for the community.
+// It is derived from a hash, so nobody has the private key of it,
+// and the funds of it are spent only by the community spend proposals.
+var CommunityPoolAddress = types.Address(crypto.DefaultHash([]byte("rigo_community_pool"))[:types.AddrSize])
+
+// CommunitySpend is the option of the community spend proposal.
+// When the proposal is applied, `Amount` is transferred from the community pool to `Recipient`,
+// and `Height` and `TxHash` are set to the applying height and the hash of the proposal tx.
+// The applied spends are kept in the ledger of the governance controller with `TxHash` as the key.
+type CommunitySpend struct {
+	Recipient types.Address
+	Amount    *uint256.Int
+	Height    int64
+	TxHash    bytes.HexBytes
+}
+
+func DecodeCommunitySpend(bz []byte) (*CommunitySpend, xerrors.XError) {
+	spend := &CommunitySpend{}
+	if err := json.Unmarshal(bz, spend); err != nil {
+		return nil, xerrors.From(err)
+	}
+	if len(spend.Recipient) != types.AddrSize {
+		return nil, xerrors.NewOrdinary("wrong recipient of community spend")
+	}
+	if spend.Amount == nil || spend.Amount.IsZero() {
+		return nil, xerrors.NewOrdinary("the amount of community spend is zero")
+	}
+	return spend, nil
+}
+
+func (spend *CommunitySpend) Encode() ([]byte, xerrors.XError) {
+	if bz, err := json.Marshal(spend); err != nil {
+		return nil, xerrors.From(err)
+	} else {
+		return bz, nil
+	}
+}
+
+func (spend *CommunitySpend) Key() ledger.LedgerKey {
+	return ledger.ToLedgerKey(spend.TxHash)
+}
+
+func (spend *CommunitySpend) Decode(bz []byte) xerrors.XError {
+	if err := json.Unmarshal(bz, spend); err != nil {
+		return xerrors.From(err)
+	}
+	return nil
+}
+
+var _ ledger.ILedgerItem = (*CommunitySpend)(nil)
+
+func (spend *CommunitySpend) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Recipient types.Address  `json:"recipient"`
+		Amount    string         `json:"amount"`
+		Height    int64          `json:"height,omitempty"`
+		TxHash    bytes.HexBytes `json:"txhash,omitempty"`
+	}{
+		Recipient: spend.Recipient,
+		Amount:    uint256ToString(spend.Amount),
+		Height:    spend.Height,
+		TxHash:    spend.TxHash,
+	})
+}
+
+func (spend *CommunitySpend) UnmarshalJSON(bz []byte) error {
+	tm := &struct {
+		Recipient types.Address  `json:"recipient"`
+		Amount    string         `json:"amount"`
+		Height    int64          `json:"height,omitempty"`
+		TxHash    bytes.HexBytes `json:"txhash,omitempty"`
+	}{}
+	if err := json.Unmarshal(bz, tm); err != nil {
+		return err
+	}
+
+	amt, err := stringToUint256(tm.Amount)
+	if err != nil {
+		return err
+	}
+	spend.Recipient = tm.Recipient
+	spend.Amount = amt
+	spend.Height = tm.Height
+	spend.TxHash = tm.TxHash
+	return nil
+}
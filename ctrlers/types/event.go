@@ -0,0 +1,58 @@
+package types
+
+// The types and the attributes of the events emitted by the controllers.
+// The addresses and the tx hashes are indexed,
+// so that the lifecycle of a stake is tracked by `tx_search` and `subscribe`. (e.g. "stake_frozen.owner='<address>'")
+// The events of txs are found by `tx_search` and the events of BeginBlock and EndBlock are found by `block_search`.
+const (
+	EVENT_TYPE_STAKE_CREATED      = "stake_created"
+	EVENT_TYPE_STAKE_FROZEN       = "stake_frozen"
+	EVENT_TYPE_STAKE_REFUNDED     = "stake_refunded"
+	EVENT_TYPE_WITHDRAW           = "withdraw"
+	EVENT_TYPE_SETDOC             = "setdoc"
+	EVENT_TYPE_FEE_REWARD         = "fee_reward"
+	EVENT_TYPE_FEE_BURNED         = "fee_burned"
+	EVENT_TYPE_COMMUNITY_SPEND    = "community_spend"
+	EVENT_TYPE_MULTISIG           = "multisig"
+	EVENT_TYPE_BATCH_TRANSFER     = "batch_transfer"
+	EVENT_TYPE_VESTING            = "vesting"
+	EVENT_TYPE_COMMISSION         = "commission"
+	EVENT_TYPE_STAKE_REDELEGATED  = "stake_redelegated"
+	EVENT_TYPE_VALIDATOR_JAILED   = "validator_jailed"
+	EVENT_TYPE_VALIDATOR_UNJAILED = "validator_unjailed"
+	EVENT_TYPE_UPGRADE            = "upgrade"
+
+	EVENT_ATTR_OWNER          = "owner"
+	EVENT_ATTR_DELEGATEE      = "delegatee"
+	EVENT_ATTR_TXHASH         = "txhash"
+	EVENT_ATTR_POWER          = "power"
+	EVENT_ATTR_START_HEIGHT   = "startHeight"
+	EVENT_ATTR_REFUND_HEIGHT  = "refundHeight"
+	EVENT_ATTR_REASON         = "reason"
+	EVENT_ATTR_MISSED_BLOCKS  = "missedBlocks"
+	EVENT_ATTR_ADDRESS        = "address"
+	EVENT_ATTR_NAME           = "name"
+	EVENT_ATTR_URL            = "url"
+	EVENT_ATTR_ROLE           = "role"
+	EVENT_ATTR_HEIGHT         = "height"
+	EVENT_ATTR_THRESHOLD      = "threshold"
+	EVENT_ATTR_END            = "end"
+	EVENT_ATTR_RATE           = "rate"
+	EVENT_ATTR_SRC            = "src"
+	EVENT_ATTR_RELEASE_HEIGHT = "releaseHeight"
+)
+
+// The reasons why a stake is frozen.
+const (
+	// the owner un-stakes the stake.
+	EVENT_REASON_UNSTAKING = "unstaking"
+	// the delegatee has un-staked all its own stakes, so the stakes delegated to it are frozen too.
+	EVENT_REASON_DELEGATEE_UNSTAKED = "delegatee_unstaked"
+)
+
+// The roles of the accounts receiving the fee of a block.
+const (
+	EVENT_ROLE_PROPOSER  = "proposer"
+	EVENT_ROLE_VALIDATOR = "validator"
+	EVENT_ROLE_COMMUNITY = "community"
+)
@@ -40,6 +40,29 @@ type GovParams struct {
 	signedBlocksWindow      int64
 	minSignedBlocks         int64
 
+	// the ratios(%) of the fee of a block, which are given to the proposer, the validators signing the block and
+	// the community pool, and are burned. the sum of them should be 100.
+	// if all of them are 0, the whole fee is given to the proposer.
+	feeProposerRatio   int64
+	feeValidatorsRatio int64
+	feeCommunityRatio  int64
+	feeBurnRatio       int64
+
+	// the base fee of a block is adjusted by the gas used in the previous block.
+	// it increases if the gas used is more than `targetBlockGas`, and decreases otherwise,
+	// by up to `baseFeeChangeRatio`(%) of it. it is never less than `gasPrice`.
+	// if `targetBlockGas` is 0, the base fee is always `gasPrice`.
+	targetBlockGas     uint64
+	baseFeeChangeRatio int64
+
+	// a tx is valid only when its `Time` is within `txTimeWindow` seconds of the block time.
+	// if it is 0, the time of a tx is not checked.
+	txTimeWindow int64
+
+	// a validator missing too many blocks in `signedBlocksWindow` is jailed for `jailBlocks` blocks,
+	// and then it can be released by TRX_UNJAIL.
+	jailBlocks int64
+
 	mtx sync.RWMutex
 }
 
@@ -89,6 +112,14 @@ func DefaultGovParams() *GovParams {
 		slashRatio:              50,      // 50%
 		signedBlocksWindow:      10000,   // 10000 blocks
 		minSignedBlocks:         500,     // 500 blocks
+		feeProposerRatio:        100,     // 100%
+		feeValidatorsRatio:      0,
+		feeCommunityRatio:       0,
+		feeBurnRatio:            0,
+		targetBlockGas:          12_500_000, // the half of the gas limit of EVM
+		baseFeeChangeRatio:      12,         // 12%
+		txTimeWindow:            3600,       // 1 hour
+		jailBlocks:              28800,      // = 60 * 60 * 24 / 3 => 1 day(3s block interval)
 	}
 }
 
@@ -113,6 +144,7 @@ func Test1GovParams() *GovParams {
 		slashRatio:              50, // 50%
 		signedBlocksWindow:      30,
 		minSignedBlocks:         3,
+		jailBlocks:              10,
 	}
 }
 
@@ -223,6 +255,24 @@ func Test6GovParams_NoStakeLimiter() *GovParams {
 	}
 }
 
+func Test7GovParams_FeeDistribution() *GovParams {
+	params := DefaultGovParams()
+	params.feeProposerRatio = 40   // 40%
+	params.feeValidatorsRatio = 30 // 30%
+	params.feeCommunityRatio = 20  // 20%
+	params.feeBurnRatio = 10       // 10%
+	return params
+}
+
+func Test8GovParams_BaseFee() *GovParams {
+	params := DefaultGovParams()
+	params.targetBlockGas = params.minTrxGas // a block with more than one tx raises the base fee.
+	params.baseFeeChangeRatio = 12           // 12%
+	params.feeProposerRatio = 0
+	params.feeBurnRatio = 100 // the base fee is burned, and only the tip is given to the proposer.
+	return params
+}
+
 func DecodeGovParams(bz []byte) (*GovParams, xerrors.XError) {
 	ret := &GovParams{}
 	if xerr := ret.Decode(bz); xerr != nil {
@@ -275,6 +325,14 @@ func (r *GovParams) fromProto(pm *GovParamsProto) {
 	r.slashRatio = pm.SlashRatio
 	r.signedBlocksWindow = pm.SignedBlocksWindow
 	r.minSignedBlocks = pm.MinSignedBlocks
+	r.feeProposerRatio = pm.FeeProposerRatio
+	r.feeValidatorsRatio = pm.FeeValidatorsRatio
+	r.feeCommunityRatio = pm.FeeCommunityRatio
+	r.feeBurnRatio = pm.FeeBurnRatio
+	r.targetBlockGas = pm.TargetBlockGas
+	r.baseFeeChangeRatio = pm.BaseFeeChangeRatio
+	r.txTimeWindow = pm.TxTimeWindow
+	r.jailBlocks = pm.JailBlocks
 }
 
 func (r *GovParams) toProto() *GovParamsProto {
@@ -301,6 +359,14 @@ func (r *GovParams) toProto() *GovParamsProto {
 		SlashRatio:              r.slashRatio,
 		SignedBlocksWindow:      r.signedBlocksWindow,
 		MinSignedBlocks:         r.minSignedBlocks,
+		FeeProposerRatio:        r.feeProposerRatio,
+		FeeValidatorsRatio:      r.feeValidatorsRatio,
+		FeeCommunityRatio:       r.feeCommunityRatio,
+		FeeBurnRatio:            r.feeBurnRatio,
+		TargetBlockGas:          r.targetBlockGas,
+		BaseFeeChangeRatio:      r.baseFeeChangeRatio,
+		TxTimeWindow:            r.txTimeWindow,
+		JailBlocks:              r.jailBlocks,
 	}
 	return a
 }
@@ -329,6 +395,14 @@ func (r *GovParams) MarshalJSON() ([]byte, error) {
 		SlashRatio              int64  `json:"slashRatio"`
 		SignedBlocksWindow      int64  `json:"signedBlocksWindow"`
 		MinSignedBlocks         int64  `json:"minSignedBlocks"`
+		FeeProposerRatio        int64  `json:"feeProposerRatio"`
+		FeeValidatorsRatio      int64  `json:"feeValidatorsRatio"`
+		FeeCommunityRatio       int64  `json:"feeCommunityRatio"`
+		FeeBurnRatio            int64  `json:"feeBurnRatio"`
+		TargetBlockGas          uint64 `json:"targetBlockGas"`
+		BaseFeeChangeRatio      int64  `json:"baseFeeChangeRatio"`
+		TxTimeWindow            int64  `json:"txTimeWindow"`
+		JailBlocks              int64  `json:"jailBlocks"`
 	}{
 		Version:                 r.version,
 		MaxValidatorCnt:         r.maxValidatorCnt,
@@ -349,6 +423,14 @@ func (r *GovParams) MarshalJSON() ([]byte, error) {
 		SlashRatio:              r.slashRatio,
 		SignedBlocksWindow:      r.signedBlocksWindow,
 		MinSignedBlocks:         r.minSignedBlocks,
+		FeeProposerRatio:        r.feeProposerRatio,
+		FeeValidatorsRatio:      r.feeValidatorsRatio,
+		FeeCommunityRatio:       r.feeCommunityRatio,
+		FeeBurnRatio:            r.feeBurnRatio,
+		TargetBlockGas:          r.targetBlockGas,
+		BaseFeeChangeRatio:      r.baseFeeChangeRatio,
+		TxTimeWindow:            r.txTimeWindow,
+		JailBlocks:              r.jailBlocks,
 	}
 	return tmjson.Marshal(tm)
 }
@@ -381,6 +463,14 @@ func (r *GovParams) UnmarshalJSON(bz []byte) error {
 		SlashRatio              int64  `json:"slashRatio"`
 		SignedBlocksWindow      int64  `json:"signedBlocksWindow"`
 		MinSignedBlocks         int64  `json:"minSignedBlocks"`
+		FeeProposerRatio        int64  `json:"feeProposerRatio"`
+		FeeValidatorsRatio      int64  `json:"feeValidatorsRatio"`
+		FeeCommunityRatio       int64  `json:"feeCommunityRatio"`
+		FeeBurnRatio            int64  `json:"feeBurnRatio"`
+		TargetBlockGas          uint64 `json:"targetBlockGas"`
+		BaseFeeChangeRatio      int64  `json:"baseFeeChangeRatio"`
+		TxTimeWindow            int64  `json:"txTimeWindow"`
+		JailBlocks              int64  `json:"jailBlocks"`
 	}{}
 
 	err := tmjson.Unmarshal(bz, tm)
@@ -426,6 +516,14 @@ func (r *GovParams) UnmarshalJSON(bz []byte) error {
 	r.slashRatio = tm.SlashRatio
 	r.signedBlocksWindow = tm.SignedBlocksWindow
 	r.minSignedBlocks = tm.MinSignedBlocks
+	r.feeProposerRatio = tm.FeeProposerRatio
+	r.feeValidatorsRatio = tm.FeeValidatorsRatio
+	r.feeCommunityRatio = tm.FeeCommunityRatio
+	r.feeBurnRatio = tm.FeeBurnRatio
+	r.targetBlockGas = tm.TargetBlockGas
+	r.baseFeeChangeRatio = tm.BaseFeeChangeRatio
+	r.txTimeWindow = tm.TxTimeWindow
+	r.jailBlocks = tm.JailBlocks
 	return nil
 }
 
@@ -588,6 +686,78 @@ func (r *GovParams) MinSignedBlocks() int64 {
 	return r.minSignedBlocks
 }
 
+func (r *GovParams) FeeProposerRatio() int64 {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	return r.feeProposerRatio
+}
+
+func (r *GovParams) FeeValidatorsRatio() int64 {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	return r.feeValidatorsRatio
+}
+
+func (r *GovParams) FeeCommunityRatio() int64 {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	return r.feeCommunityRatio
+}
+
+func (r *GovParams) FeeBurnRatio() int64 {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	return r.feeBurnRatio
+}
+
+func (r *GovParams) TargetBlockGas() uint64 {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	return r.targetBlockGas
+}
+
+func (r *GovParams) BaseFeeChangeRatio() int64 {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	return r.baseFeeChangeRatio
+}
+
+func (r *GovParams) TxTimeWindow() int64 {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	return r.txTimeWindow
+}
+
+func (r *GovParams) JailBlocks() int64 {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	return r.jailBlocks
+}
+
+// CheckFeeRatios returns an error if the fee ratios are negative or their sum is not 100.
+// All ratios being 0 is allowed; it means the whole fee is given to the proposer.
+func (r *GovParams) CheckFeeRatios() xerrors.XError {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	if r.feeProposerRatio < 0 || r.feeValidatorsRatio < 0 || r.feeCommunityRatio < 0 || r.feeBurnRatio < 0 {
+		return xerrors.ErrInvalidTrxPayloadParams.Wrapf("negative fee ratio")
+	}
+	sum := r.feeProposerRatio + r.feeValidatorsRatio + r.feeCommunityRatio + r.feeBurnRatio
+	if sum != 0 && sum != 100 {
+		return xerrors.ErrInvalidTrxPayloadParams.Wrapf("the sum of fee ratios should be 100: %v", sum)
+	}
+	return nil
+}
+
 func (r *GovParams) String() string {
 	r.mtx.RLock()
 	defer r.mtx.RUnlock()
@@ -708,6 +878,31 @@ func MergeGovParams(oldParams, newParams *GovParams) {
 	if newParams.minSignedBlocks == 0 {
 		newParams.minSignedBlocks = oldParams.minSignedBlocks
 	}
+
+	// the fee ratios are merged together, because some of them may be 0 intentionally.
+	if newParams.feeProposerRatio == 0 && newParams.feeValidatorsRatio == 0 &&
+		newParams.feeCommunityRatio == 0 && newParams.feeBurnRatio == 0 {
+		newParams.feeProposerRatio = oldParams.feeProposerRatio
+		newParams.feeValidatorsRatio = oldParams.feeValidatorsRatio
+		newParams.feeCommunityRatio = oldParams.feeCommunityRatio
+		newParams.feeBurnRatio = oldParams.feeBurnRatio
+	}
+
+	if newParams.targetBlockGas == 0 {
+		newParams.targetBlockGas = oldParams.targetBlockGas
+	}
+
+	if newParams.baseFeeChangeRatio == 0 {
+		newParams.baseFeeChangeRatio = oldParams.baseFeeChangeRatio
+	}
+
+	if newParams.txTimeWindow == 0 {
+		newParams.txTimeWindow = oldParams.txTimeWindow
+	}
+
+	if newParams.jailBlocks == 0 {
+		newParams.jailBlocks = oldParams.jailBlocks
+	}
 }
 
 var _ ledger.ILedgerItem = (*GovParams)(nil)
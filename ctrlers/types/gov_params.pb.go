@@ -44,6 +44,14 @@ type GovParamsProto struct {
 	XMinDelegatorStake      []byte `protobuf:"bytes,19,opt,name=_min_delegator_stake,json=MinDelegatorStake,proto3" json:"_min_delegator_stake,omitempty"`
 	SignedBlocksWindow      int64  `protobuf:"varint,17,opt,name=signed_blocks_window,json=signedBlocksWindow,proto3" json:"signed_blocks_window,omitempty"`
 	MinSignedBlocks         int64  `protobuf:"varint,18,opt,name=min_signed_blocks,json=minSignedBlocks,proto3" json:"min_signed_blocks,omitempty"`
+	FeeProposerRatio        int64  `protobuf:"varint,20,opt,name=fee_proposer_ratio,json=feeProposerRatio,proto3" json:"fee_proposer_ratio,omitempty"`
+	FeeValidatorsRatio      int64  `protobuf:"varint,21,opt,name=fee_validators_ratio,json=feeValidatorsRatio,proto3" json:"fee_validators_ratio,omitempty"`
+	FeeCommunityRatio       int64  `protobuf:"varint,22,opt,name=fee_community_ratio,json=feeCommunityRatio,proto3" json:"fee_community_ratio,omitempty"`
+	FeeBurnRatio            int64  `protobuf:"varint,23,opt,name=fee_burn_ratio,json=feeBurnRatio,proto3" json:"fee_burn_ratio,omitempty"`
+	TargetBlockGas          uint64 `protobuf:"varint,24,opt,name=target_block_gas,json=targetBlockGas,proto3" json:"target_block_gas,omitempty"`
+	BaseFeeChangeRatio      int64  `protobuf:"varint,25,opt,name=base_fee_change_ratio,json=baseFeeChangeRatio,proto3" json:"base_fee_change_ratio,omitempty"`
+	TxTimeWindow            int64  `protobuf:"varint,26,opt,name=tx_time_window,json=txTimeWindow,proto3" json:"tx_time_window,omitempty"`
+	JailBlocks              int64  `protobuf:"varint,27,opt,name=jail_blocks,json=jailBlocks,proto3" json:"jail_blocks,omitempty"`
 }
 
 func (x *GovParamsProto) Reset() {
@@ -211,11 +219,67 @@ func (x *GovParamsProto) GetMinSignedBlocks() int64 {
 	return 0
 }
 
+func (x *GovParamsProto) GetFeeProposerRatio() int64 {
+	if x != nil {
+		return x.FeeProposerRatio
+	}
+	return 0
+}
+
+func (x *GovParamsProto) GetFeeValidatorsRatio() int64 {
+	if x != nil {
+		return x.FeeValidatorsRatio
+	}
+	return 0
+}
+
+func (x *GovParamsProto) GetFeeCommunityRatio() int64 {
+	if x != nil {
+		return x.FeeCommunityRatio
+	}
+	return 0
+}
+
+func (x *GovParamsProto) GetFeeBurnRatio() int64 {
+	if x != nil {
+		return x.FeeBurnRatio
+	}
+	return 0
+}
+
+func (x *GovParamsProto) GetTargetBlockGas() uint64 {
+	if x != nil {
+		return x.TargetBlockGas
+	}
+	return 0
+}
+
+func (x *GovParamsProto) GetBaseFeeChangeRatio() int64 {
+	if x != nil {
+		return x.BaseFeeChangeRatio
+	}
+	return 0
+}
+
+func (x *GovParamsProto) GetTxTimeWindow() int64 {
+	if x != nil {
+		return x.TxTimeWindow
+	}
+	return 0
+}
+
+func (x *GovParamsProto) GetJailBlocks() int64 {
+	if x != nil {
+		return x.JailBlocks
+	}
+	return 0
+}
+
 var File_gov_params_proto protoreflect.FileDescriptor
 
 var file_gov_params_proto_rawDesc = []byte{
 	0x0a, 0x10, 0x67, 0x6f, 0x76, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x22, 0xdf, 0x06, 0x0a, 0x0e, 0x47, 0x6f,
+	0x74, 0x6f, 0x12, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x22, 0xb9, 0x09, 0x0a, 0x0e, 0x47, 0x6f,
 	0x76, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x18, 0x0a, 0x07,
 	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76,
 	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x11, 0x6d, 0x61, 0x78, 0x5f, 0x76, 0x61,
@@ -269,11 +333,32 @@ var file_gov_params_proto_rawDesc = []byte{
 	0x6e, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12,
 	0x2a, 0x0a, 0x11, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x62, 0x6c,
 	0x6f, 0x63, 0x6b, 0x73, 0x18, 0x12, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x6d, 0x69, 0x6e, 0x53,
-	0x69, 0x67, 0x6e, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x42, 0x2c, 0x5a, 0x2a, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x69, 0x67, 0x6f, 0x63, 0x68,
-	0x61, 0x69, 0x6e, 0x2f, 0x72, 0x69, 0x67, 0x6f, 0x2d, 0x67, 0x6f, 0x2f, 0x63, 0x74, 0x72, 0x6c,
-	0x65, 0x72, 0x73, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x69, 0x67, 0x6e, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x66,
+	0x65, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x5f, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x18, 0x14, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x66, 0x65, 0x65, 0x50, 0x72, 0x6f, 0x70,
+	0x6f, 0x73, 0x65, 0x72, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x12, 0x30, 0x0a, 0x14, 0x66, 0x65, 0x65,
+	0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x5f, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x18, 0x15, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x66, 0x65, 0x65, 0x56, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x12, 0x2e, 0x0a, 0x13, 0x66,
+	0x65, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x75, 0x6e, 0x69, 0x74, 0x79, 0x5f, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x18, 0x16, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x66, 0x65, 0x65, 0x43, 0x6f, 0x6d,
+	0x6d, 0x75, 0x6e, 0x69, 0x74, 0x79, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x12, 0x24, 0x0a, 0x0e, 0x66,
+	0x65, 0x65, 0x5f, 0x62, 0x75, 0x72, 0x6e, 0x5f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x18, 0x17, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0c, 0x66, 0x65, 0x65, 0x42, 0x75, 0x72, 0x6e, 0x52, 0x61, 0x74, 0x69,
+	0x6f, 0x12, 0x28, 0x0a, 0x10, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x5f, 0x67, 0x61, 0x73, 0x18, 0x18, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x47, 0x61, 0x73, 0x12, 0x31, 0x0a, 0x15, 0x62,
+	0x61, 0x73, 0x65, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x18, 0x19, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x62, 0x61, 0x73, 0x65,
+	0x46, 0x65, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x12, 0x24,
+	0x0a, 0x0e, 0x74, 0x78, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77,
+	0x18, 0x1a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x74, 0x78, 0x54, 0x69, 0x6d, 0x65, 0x57, 0x69,
+	0x6e, 0x64, 0x6f, 0x77, 0x12, 0x1f, 0x0a, 0x0b, 0x6a, 0x61, 0x69, 0x6c, 0x5f, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x73, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6a, 0x61, 0x69, 0x6c, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x69, 0x67, 0x6f, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x2f, 0x72, 0x69,
+	0x67, 0x6f, 0x2d, 0x67, 0x6f, 0x2f, 0x63, 0x74, 0x72, 0x6c, 0x65, 0x72, 0x73, 0x2f, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -29,3 +29,38 @@ func TestJsonCodec(t *testing.T) {
 
 	require.Equal(t, params0, params1)
 }
+
+func TestCheckFeeRatios(t *testing.T) {
+	require.NoError(t, DefaultGovParams().CheckFeeRatios())
+	require.NoError(t, Test7GovParams_FeeDistribution().CheckFeeRatios())
+	require.NoError(t, Test1GovParams().CheckFeeRatios()) // all ratios are 0
+
+	params := Test7GovParams_FeeDistribution()
+	params.feeBurnRatio = 20
+	require.Error(t, params.CheckFeeRatios())
+
+	params = Test7GovParams_FeeDistribution()
+	params.feeBurnRatio = -10
+	params.feeProposerRatio = 60
+	require.Error(t, params.CheckFeeRatios())
+}
+
+func TestMergeFeeRatios(t *testing.T) {
+	oldParams := Test7GovParams_FeeDistribution()
+
+	// the fee ratios which are not set are taken from `oldParams`.
+	newParams := &GovParams{}
+	MergeGovParams(oldParams, newParams)
+	require.Equal(t, oldParams.FeeProposerRatio(), newParams.FeeProposerRatio())
+	require.Equal(t, oldParams.FeeValidatorsRatio(), newParams.FeeValidatorsRatio())
+	require.Equal(t, oldParams.FeeCommunityRatio(), newParams.FeeCommunityRatio())
+	require.Equal(t, oldParams.FeeBurnRatio(), newParams.FeeBurnRatio())
+
+	// if some of them are set, the others are 0.
+	newParams = &GovParams{feeProposerRatio: 50, feeBurnRatio: 50}
+	MergeGovParams(oldParams, newParams)
+	require.EqualValues(t, 50, newParams.FeeProposerRatio())
+	require.EqualValues(t, 0, newParams.FeeValidatorsRatio())
+	require.EqualValues(t, 0, newParams.FeeCommunityRatio())
+	require.EqualValues(t, 50, newParams.FeeBurnRatio())
+}
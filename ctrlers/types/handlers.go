@@ -36,6 +36,14 @@ type IGovHandler interface {
 	SlashRatio() int64
 	SignedBlocksWindow() int64
 	MinSignedBlocks() int64
+	FeeProposerRatio() int64
+	FeeValidatorsRatio() int64
+	FeeCommunityRatio() int64
+	FeeBurnRatio() int64
+	TargetBlockGas() uint64
+	BaseFeeChangeRatio() int64
+	TxTimeWindow() int64
+	JailBlocks() int64
 }
 
 type IAccountHandler interface {
@@ -53,6 +61,7 @@ type IStakeHandler interface {
 	TotalPowerOf(types.Address) int64
 	SelfPowerOf(types.Address) int64
 	DelegatedPowerOf(types.Address) int64
+	IsPunished(*abcitypes.Evidence) bool
 }
 
 type IDelegatee interface {
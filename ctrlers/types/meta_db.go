@@ -13,6 +13,8 @@ const (
 	keyBlockContext = "bc"
 	keyBlockAppHash = "ah"
 	keyRewardHash   = "rh"
+	keyCommitting   = "ch"
+	keyUpgrade      = "ug"
 )
 
 type MetaDB struct {
@@ -85,6 +87,81 @@ func (stdb *MetaDB) PutLastRewardHash(v []byte) error {
 	return stdb.put(keyRewardHash, v)
 }
 
+func (stdb *MetaDB) DelLastRewardHash() error {
+	return stdb.del(keyRewardHash)
+}
+
+// CommittingHeight returns the height of the block whose commit has been started but not finished.
+// It returns 0 if there is no such block.
+func (stdb *MetaDB) CommittingHeight() int64 {
+	v := stdb.get(keyCommitting)
+	if v == nil {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(v))
+}
+
+func (stdb *MetaDB) PutCommittingHeight(h int64) error {
+	v := make([]byte, 8)
+	binary.BigEndian.PutUint64(v, uint64(h))
+	return stdb.put(keyCommitting, v)
+}
+
+func (stdb *MetaDB) DelCommittingHeight() error {
+	return stdb.del(keyCommitting)
+}
+
+// PutUpgrade records the upgrade applied at `plan.Height`.
+func (stdb *MetaDB) PutUpgrade(plan *UpgradePlan) error {
+	bz, xerr := plan.Encode()
+	if xerr != nil {
+		return xerr
+	}
+	return stdb.put(keyUpgrade+plan.Name, bz)
+}
+
+// HasUpgrade returns true if the upgrade named `name` is recorded.
+func (stdb *MetaDB) HasUpgrade(name string) bool {
+	return stdb.get(keyUpgrade+name) != nil
+}
+
+// DelUpgradesAbove removes the upgrades recorded at the heights higher than `height`.
+// It is called when the state is rolled back to `height`.
+func (stdb *MetaDB) DelUpgradesAbove(height int64) error {
+	plans, err := stdb.Upgrades()
+	if err != nil {
+		return err
+	}
+	for _, plan := range plans {
+		if plan.Height > height {
+			if err := stdb.del(keyUpgrade + plan.Name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Upgrades returns all recorded upgrades.
+// The upgrades higher than the last block height are the ones whose blocks were not committed.
+func (stdb *MetaDB) Upgrades() ([]*UpgradePlan, error) {
+	itr, err := tmdb.IteratePrefix(stdb.db, []byte(keyUpgrade))
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+
+	var plans []*UpgradePlan
+	for ; itr.Valid(); itr.Next() {
+		plan, xerr := DecodeUpgradePlan(itr.Value())
+		if xerr != nil {
+			return nil, xerr
+		}
+		plans = append(plans, plan)
+	}
+	return plans, itr.Error()
+}
+
 func (stdb *MetaDB) LastBlockContext() *BlockContext {
 	bz := stdb.get(keyBlockContext)
 	if bz == nil {
@@ -105,6 +182,35 @@ func (stdb *MetaDB) PutLastBlockContext(ctx *BlockContext) error {
 	return stdb.put(keyBlockContext, bz)
 }
 
+// Items returns all key-value pairs as snapshot items of `store`.
+func (stdb *MetaDB) Items(store string) ([]*SnapshotItemProto, error) {
+	itr, err := stdb.db.Iterator(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+
+	var items []*SnapshotItemProto
+	for ; itr.Valid(); itr.Next() {
+		items = append(items, &SnapshotItemProto{
+			Store: store,
+			Key:   append([]byte(nil), itr.Key()...),
+			Value: append([]byte(nil), itr.Value()...),
+		})
+	}
+	return items, itr.Error()
+}
+
+// PutItems writes the key-value pairs exported by Items.
+func (stdb *MetaDB) PutItems(items []*SnapshotItemProto) error {
+	for _, item := range items {
+		if err := stdb.put(string(item.Key), item.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (stdb *MetaDB) putCache(k string, v []byte) {
 	stdb.mtx.Lock()
 	defer stdb.mtx.Unlock()
@@ -112,6 +218,13 @@ func (stdb *MetaDB) putCache(k string, v []byte) {
 	stdb.cache[k] = v
 }
 
+func (stdb *MetaDB) delCache(k string) {
+	stdb.mtx.Lock()
+	defer stdb.mtx.Unlock()
+
+	delete(stdb.cache, k)
+}
+
 func (stdb *MetaDB) getCache(k string) []byte {
 	stdb.mtx.RLock()
 	defer stdb.mtx.RUnlock()
@@ -140,3 +253,11 @@ func (stdb *MetaDB) put(k string, v []byte) error {
 	stdb.putCache(k, v)
 	return nil
 }
+
+func (stdb *MetaDB) del(k string) error {
+	if err := stdb.db.DeleteSync([]byte(k)); err != nil {
+		return err
+	}
+	stdb.delCache(k)
+	return nil
+}
@@ -0,0 +1,120 @@
+package types
+
+import (
+	"encoding/binary"
+	"fmt"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/rigochain/rigo-go/types"
+	"github.com/rigochain/rigo-go/types/bytes"
+	"github.com/rigochain/rigo-go/types/crypto"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	"sort"
+)
+
+const MAX_MULTISIG_KEYS = 20
+
+// MultiSig is the public keys of a multi-signature account and the number of the signatures required.
+// The tx from a multi-signature account has `Threshold` signatures or more of the different `PubKeys` in `Trx.Sig`.
+// The signatures are concatenated in `Trx.Sig`, so the preimage to be signed is the same as the one of a single-signature tx.
+type MultiSig struct {
+	Threshold uint32           `json:"threshold"`
+	PubKeys   []bytes.HexBytes `json:"pubKeys"`
+}
+
+func NewMultiSig(threshold uint32, pubKeys ...bytes.HexBytes) *MultiSig {
+	return &MultiSig{
+		Threshold: threshold,
+		PubKeys:   pubKeys,
+	}
+}
+
+func (ms *MultiSig) ValidateBasic() xerrors.XError {
+	if len(ms.PubKeys) == 0 || len(ms.PubKeys) > MAX_MULTISIG_KEYS {
+		return xerrors.ErrInvalidTrxPayloadParams.Wrapf("the number of the public keys should be in [1, %v]", MAX_MULTISIG_KEYS)
+	}
+	if ms.Threshold == 0 || int(ms.Threshold) > len(ms.PubKeys) {
+		return xerrors.ErrInvalidTrxPayloadParams.Wrapf("the threshold(%v) should be in [1, %v]", ms.Threshold, len(ms.PubKeys))
+	}
+	for i, pubKey := range ms.PubKeys {
+		if _, xerr := crypto.DecompressPubkey(pubKey); xerr != nil {
+			return xerrors.ErrInvalidTrxPayloadParams.Wrapf("invalid public key(%v): %v", pubKey, xerr)
+		}
+		for _, pubKey0 := range ms.PubKeys[:i] {
+			if bytes.Compare(pubKey, pubKey0) == 0 {
+				return xerrors.ErrInvalidTrxPayloadParams.Wrapf("duplicated public key(%v)", pubKey)
+			}
+		}
+	}
+	return nil
+}
+
+// Address returns the address of the multi-signature account.
+// It is derived from the threshold and the public keys regardless of their order,
+// and nobody has the private key of it.
+func (ms *MultiSig) Address() types.Address {
+	pubKeys := make([]bytes.HexBytes, len(ms.PubKeys))
+	copy(pubKeys, ms.PubKeys)
+	sort.Slice(pubKeys, func(i, j int) bool {
+		return bytes.Compare(pubKeys[i], pubKeys[j]) < 0
+	})
+
+	hasher := crypto.DefaultHasher()
+	_ = binary.Write(hasher, binary.BigEndian, ms.Threshold)
+	for _, pubKey := range pubKeys {
+		_, _ = hasher.Write(pubKey)
+	}
+	return hasher.Sum(nil)[:types.AddrSize]
+}
+
+func (ms *MultiSig) Equal(o *MultiSig) bool {
+	if ms == nil || o == nil {
+		return ms == o
+	}
+	if ms.Threshold != o.Threshold || len(ms.PubKeys) != len(o.PubKeys) {
+		return false
+	}
+	for i := range ms.PubKeys {
+		if bytes.Compare(ms.PubKeys[i], o.PubKeys[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// VerifySigs returns an error if `sigs` doesn't have the signatures of `Threshold` or more different keys for `preimg`.
+func (ms *MultiSig) VerifySigs(preimg, sigs []byte) xerrors.XError {
+	if len(sigs) == 0 || len(sigs)%ethcrypto.SignatureLength != 0 {
+		return xerrors.ErrInvalidTrxSig.Wrapf("wrong length of the signatures: %v", len(sigs))
+	}
+
+	signed := make([]bool, len(ms.PubKeys))
+	cnt := uint32(0)
+	for i := 0; i < len(sigs); i += ethcrypto.SignatureLength {
+		_, pubKey, xerr := crypto.Sig2Addr(preimg, sigs[i:i+ethcrypto.SignatureLength])
+		if xerr != nil {
+			return xerrors.ErrInvalidTrxSig.Wrap(xerr)
+		}
+		idx := ms.indexOf(pubKey)
+		if idx < 0 {
+			return xerrors.ErrInvalidTrxSig.Wrap(fmt.Errorf("the signer(%v) is not a key of the multi-signature account", pubKey))
+		}
+		if signed[idx] {
+			return xerrors.ErrInvalidTrxSig.Wrap(fmt.Errorf("the signer(%v) signs twice", pubKey))
+		}
+		signed[idx] = true
+		cnt++
+	}
+	if cnt < ms.Threshold {
+		return xerrors.ErrInvalidTrxSig.Wrap(fmt.Errorf("not enough signatures - threshold: %v, signatures: %v", ms.Threshold, cnt))
+	}
+	return nil
+}
+
+func (ms *MultiSig) indexOf(pubKey bytes.HexBytes) int {
+	for i, k := range ms.PubKeys {
+		if bytes.Compare(k, pubKey) == 0 {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,91 @@
+package types_test
+
+import (
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/holiman/uint256"
+	types2 "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/libs/web3"
+	"github.com/rigochain/rigo-go/types"
+	"github.com/rigochain/rigo-go/types/bytes"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestMultiSig(t *testing.T) {
+	w0, w1, w2, w3 := web3.NewWallet(nil), web3.NewWallet(nil), web3.NewWallet(nil), web3.NewWallet(nil)
+	ms := types2.NewMultiSig(2, w0.GetPubKey(), w1.GetPubKey(), w2.GetPubKey())
+	require.NoError(t, ms.ValidateBasic())
+
+	// the address doesn't depend on the order of the keys, but on the threshold.
+	require.Equal(t, ms.Address(), types2.NewMultiSig(2, w2.GetPubKey(), w0.GetPubKey(), w1.GetPubKey()).Address())
+	require.NotEqual(t, ms.Address(), types2.NewMultiSig(3, w0.GetPubKey(), w1.GetPubKey(), w2.GetPubKey()).Address())
+
+	require.Error(t, types2.NewMultiSig(0, w0.GetPubKey()).ValidateBasic())
+	require.Error(t, types2.NewMultiSig(2, w0.GetPubKey()).ValidateBasic())
+	require.Error(t, types2.NewMultiSig(1, w0.GetPubKey(), w0.GetPubKey()).ValidateBasic())
+	require.Error(t, types2.NewMultiSig(1, bytes.RandBytes(33)).ValidateBasic())
+
+	tx := web3.NewTrxTransfer(ms.Address(), types.RandAddress(), 0, 100_000, uint256.NewInt(10), uint256.NewInt(1000))
+	newSigs := func(signers ...*web3.Wallet) bytes.HexBytes {
+		tx.Sig = nil
+		for _, w := range signers {
+			_, err := w.CoSignTrxRLP(tx, "multisig_test_chain")
+			require.NoError(t, err)
+		}
+		return tx.Sig
+	}
+
+	tx.Sig = newSigs(w0, w2)
+	require.NoError(t, types2.VerifyTrxMultiSigRLP(tx, ms, "multisig_test_chain"))
+	tx.Sig = newSigs(w2, w1, w0)
+	require.NoError(t, types2.VerifyTrxMultiSigRLP(tx, ms, "multisig_test_chain"))
+	require.Error(t, types2.VerifyTrxMultiSigRLP(tx, ms, "other_chain"))
+
+	for _, signers := range [][]*web3.Wallet{
+		{w0},     // not enough
+		{w0, w0}, // signed twice
+		{w0, w3}, // not a key of `ms`
+		{},
+	} {
+		tx.Sig = newSigs(signers...)
+		require.Error(t, types2.VerifyTrxMultiSigRLP(tx, ms, "multisig_test_chain"))
+	}
+
+	// the tx from other account.
+	tx.From = types.RandAddress()
+	tx.Sig = newSigs(w0, w1)
+	require.Error(t, types2.VerifyTrxMultiSigRLP(tx, ms, "multisig_test_chain"))
+}
+
+func TestMultiSigCodec(t *testing.T) {
+	w0, w1 := web3.NewWallet(nil), web3.NewWallet(nil)
+
+	tx0 := web3.NewTrxMultiSig(types.RandAddress(), 1, 100_000, uint256.NewInt(10), uint256.NewInt(1000), 1, w0.GetPubKey(), w1.GetPubKey())
+	require.Equal(t, types2.TRX_MULTISIG, tx0.GetType())
+	require.EqualValues(t, tx0.Payload.(*types2.TrxPayloadMultiSig).MultiSig().Address(), tx0.To)
+
+	bz, xerr := tx0.Encode()
+	require.NoError(t, xerr)
+	tx1 := &types2.Trx{}
+	require.NoError(t, tx1.Decode(bz))
+	require.True(t, tx0.Equal(tx1))
+
+	bz, err := rlp.EncodeToBytes(tx0)
+	require.NoError(t, err)
+	tx2 := &types2.Trx{}
+	require.NoError(t, rlp.DecodeBytes(bz, tx2))
+	require.True(t, tx0.Equal(tx2))
+
+	acct0 := types2.NewAccount(tx0.To)
+	acct0.SetMultiSig(tx0.Payload.(*types2.TrxPayloadMultiSig).MultiSig())
+	bz, xerr = acct0.Encode()
+	require.NoError(t, xerr)
+	acct1 := &types2.Account{}
+	require.NoError(t, acct1.Decode(bz))
+	require.True(t, acct0.GetMultiSig().Equal(acct1.GetMultiSig()))
+
+	bz, xerr = types2.NewAccount(types.RandAddress()).Encode()
+	require.NoError(t, xerr)
+	require.NoError(t, acct1.Decode(bz))
+	require.Nil(t, acct1.GetMultiSig())
+}
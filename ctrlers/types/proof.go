@@ -0,0 +1,107 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/cosmos/iavl"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmcrypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
+	"sort"
+)
+
+// IProofHandler is implemented by the controllers whose ledgers are proven by ABCI queries with `prove=true`.
+type IProofHandler interface {
+	// RootHashAt returns the root hash of the module at `height`.
+	RootHashAt(int64) ([]byte, xerrors.XError)
+	// Prove returns the item queried by `req` as stored in the ledger,
+	// and the proofs from the item to the root hash of the module.
+	Prove(abcitypes.RequestQuery) ([]byte, *tmcrypto.ProofOps, xerrors.XError)
+}
+
+// MerkleRoots has the root hashes keyed by their names.
+// Its hash is the root of the simple Merkle tree, which leaves are the pairs of a name and the hash of a root,
+// so that `merkle.ValueOp` ("simple:v") proves a root with its name.
+// The root hash of a module is built over the root hashes of its ledgers,
+// and the app hash is built over the root hashes of the modules.
+type MerkleRoots map[string][]byte
+
+func (roots MerkleRoots) Hash() []byte {
+	hash, _ := merkle.ProofsFromByteSlices(roots.leaves())
+	return hash
+}
+
+// ProofOp returns the proof of the root hash named `name`.
+func (roots MerkleRoots) ProofOp(name string) (*tmcrypto.ProofOp, xerrors.XError) {
+	names := roots.names()
+	idx := sort.SearchStrings(names, name)
+	if idx >= len(names) || names[idx] != name {
+		return nil, xerrors.ErrNotFoundResult.Wrapf("not found root hash: %v", name)
+	}
+
+	_, proofs := merkle.ProofsFromByteSlices(roots.leaves())
+	op := merkle.NewValueOp([]byte(name), proofs[idx]).ProofOp()
+	return &op, nil
+}
+
+func (roots MerkleRoots) names() []string {
+	names := make([]string, 0, len(roots))
+	for name := range roots {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (roots MerkleRoots) leaves() [][]byte {
+	names := roots.names()
+	leaves := make([][]byte, len(names))
+	for i, name := range names {
+		// the same encoding as the KVPair of `merkle.ValueOp`
+		buf := new(bytes.Buffer)
+		writeByteSlice(buf, []byte(name))
+		writeByteSlice(buf, tmhash.Sum(roots[name]))
+		leaves[i] = buf.Bytes()
+	}
+	return leaves
+}
+
+func writeByteSlice(buf *bytes.Buffer, bz []byte) {
+	var n [binary.MaxVarintLen64]byte
+	buf.Write(n[:binary.PutUvarint(n[:], uint64(len(bz)))])
+	buf.Write(bz)
+}
+
+// ProofKeyPath returns the key path of the item `key` in the ledger `ledgerName` of the module `moduleName`.
+// It is used to verify the proofs returned by an ABCI query.
+func ProofKeyPath(moduleName, ledgerName string, key []byte) string {
+	return merkle.KeyPath{}.
+		AppendKey([]byte(moduleName), merkle.KeyEncodingURL).
+		AppendKey([]byte(ledgerName), merkle.KeyEncodingURL).
+		AppendKey(key, merkle.KeyEncodingHex).String()
+}
+
+var proofRuntime = func() *merkle.ProofRuntime {
+	prt := merkle.NewProofRuntime()
+	prt.RegisterOpDecoder(merkle.ProofOpValue, merkle.ValueOpDecoder)
+	prt.RegisterOpDecoder(iavl.ProofOpIAVLValue, iavl.ValueOpDecoder)
+	prt.RegisterOpDecoder(iavl.ProofOpIAVLAbsence, iavl.AbsenceOpDecoder)
+	return prt
+}()
+
+// VerifyProof verifies that `value` is stored at `keyPath` in the state of which app hash is `appHash`.
+// If `value` is nil, it verifies that nothing is stored at `keyPath`.
+func VerifyProof(proofOps *tmcrypto.ProofOps, appHash []byte, keyPath string, value []byte) xerrors.XError {
+	var err error
+	if value == nil {
+		err = proofRuntime.VerifyAbsence(proofOps, appHash, keyPath)
+	} else {
+		err = proofRuntime.VerifyValue(proofOps, appHash, keyPath, value)
+	}
+	if err != nil {
+		return xerrors.ErrQuery.Wrap(err)
+	}
+	return nil
+}
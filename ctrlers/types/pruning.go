@@ -0,0 +1,25 @@
+package types
+
+import (
+	cfg "github.com/rigochain/rigo-go/cmd/config"
+	"github.com/rigochain/rigo-go/ledger"
+)
+
+// PruningOptionOf returns the pruning option of the ledgers and the EVM state configured by `config`.
+func PruningOptionOf(config *cfg.AppConfig) ledger.PruningOption {
+	switch config.Pruning {
+	case cfg.PruningKeepRecent:
+		return ledger.PruningOption{
+			KeepRecent: config.PruningKeepRecent,
+			Interval:   config.PruningInterval,
+		}
+	case cfg.PruningKeepEvery:
+		return ledger.PruningOption{
+			KeepRecent: config.PruningKeepRecent,
+			KeepEvery:  config.PruningKeepEvery,
+			Interval:   config.PruningInterval,
+		}
+	default:
+		return ledger.PruningOption{}
+	}
+}
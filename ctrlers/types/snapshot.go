@@ -0,0 +1,75 @@
+package types
+
+import (
+	"github.com/cosmos/iavl"
+	"github.com/rigochain/rigo-go/types/xerrors"
+)
+
+const SNAPSHOT_FORMAT uint32 = 1
+
+// SnapshotExporter streams all items of a snapshot taken at a certain height.
+// It may be run in another goroutine after the block is committed.
+type SnapshotExporter func(func(*SnapshotItemProto) xerrors.XError) xerrors.XError
+
+// SnapshotItems groups the items of a restored snapshot by their store name.
+type SnapshotItems map[string][]*SnapshotItemProto
+
+func (items SnapshotItems) Add(item *SnapshotItemProto) {
+	items[item.Store] = append(items[item.Store], item)
+}
+
+type ISnapshotHandler interface {
+	// Snapshot is called in RigoApp::Commit and captures the state of `height`.
+	Snapshot(int64) (SnapshotExporter, xerrors.XError)
+	// RestoreSnapshot imports the state of `height` and
+	// returns the hash which Commit() would return at that height.
+	RestoreSnapshot(int64, SnapshotItems) ([]byte, xerrors.XError)
+}
+
+type iSnapshotLedger interface {
+	Export(int64, func(*iavl.ExportNode) xerrors.XError) xerrors.XError
+	Import(int64, []*iavl.ExportNode) ([]byte, xerrors.XError)
+	ImportPast(int64, []*iavl.ExportNode) xerrors.XError
+}
+
+func ExportLedgerSnapshot(store string, height int64, ledger iSnapshotLedger, cb func(*SnapshotItemProto) xerrors.XError) xerrors.XError {
+	return ledger.Export(height, func(node *iavl.ExportNode) xerrors.XError {
+		return cb(&SnapshotItemProto{
+			Store:   store,
+			Key:     node.Key,
+			Value:   node.Value,
+			Version: node.Version,
+			Height:  int32(node.Height),
+		})
+	})
+}
+
+func ImportLedgerSnapshot(store string, height int64, ledger iSnapshotLedger, items SnapshotItems) ([]byte, xerrors.XError) {
+	hash, xerr := ledger.Import(height, toExportNodes(items[store]))
+	if xerr != nil {
+		return nil, xerrors.ErrSnapshot.Wrapf("fail to import '%s': %v", store, xerr)
+	}
+	return hash, nil
+}
+
+// ImportPastLedgerSnapshot imports the past version `height` of the ledger
+// after the latest version is imported by ImportLedgerSnapshot.
+func ImportPastLedgerSnapshot(store string, height int64, ledger iSnapshotLedger, items SnapshotItems) xerrors.XError {
+	if xerr := ledger.ImportPast(height, toExportNodes(items[store])); xerr != nil {
+		return xerrors.ErrSnapshot.Wrapf("fail to import '%s': %v", store, xerr)
+	}
+	return nil
+}
+
+func toExportNodes(items []*SnapshotItemProto) []*iavl.ExportNode {
+	nodes := make([]*iavl.ExportNode, len(items))
+	for i, item := range items {
+		nodes[i] = &iavl.ExportNode{
+			Key:     item.Key,
+			Value:   item.Value,
+			Version: item.Version,
+			Height:  int8(item.Height),
+		}
+	}
+	return nodes
+}
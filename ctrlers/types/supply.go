@@ -0,0 +1,84 @@
+package types
+
+import (
+	"github.com/holiman/uint256"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+)
+
+// Supply is the amount of the native coin at a height.
+// `Total` is the initial supply of the genesis plus `Issued` minus `Burned`,
+// and `Circulating` is the part of `Total` which is not staked, frozen or left as the rewards.
+type Supply struct {
+	Height      int64
+	Total       *uint256.Int
+	Issued      *uint256.Int
+	Burned      *uint256.Int
+	Staked      *uint256.Int
+	Frozen      *uint256.Int
+	Rewards     *uint256.Int
+	Circulating *uint256.Int
+}
+
+func NewSupply(height int64) *Supply {
+	return &Supply{
+		Height:      height,
+		Total:       uint256.NewInt(0),
+		Issued:      uint256.NewInt(0),
+		Burned:      uint256.NewInt(0),
+		Staked:      uint256.NewInt(0),
+		Frozen:      uint256.NewInt(0),
+		Rewards:     uint256.NewInt(0),
+		Circulating: uint256.NewInt(0),
+	}
+}
+
+type supplyJSON struct {
+	Height      int64  `json:"height,string"`
+	Total       string `json:"total"`
+	Issued      string `json:"issued"`
+	Burned      string `json:"burned"`
+	Staked      string `json:"staked"`
+	Frozen      string `json:"frozen"`
+	Rewards     string `json:"rewards"`
+	Circulating string `json:"circulating"`
+}
+
+func (s *Supply) MarshalJSON() ([]byte, error) {
+	return tmjson.Marshal(&supplyJSON{
+		Height:      s.Height,
+		Total:       s.Total.Dec(),
+		Issued:      s.Issued.Dec(),
+		Burned:      s.Burned.Dec(),
+		Staked:      s.Staked.Dec(),
+		Frozen:      s.Frozen.Dec(),
+		Rewards:     s.Rewards.Dec(),
+		Circulating: s.Circulating.Dec(),
+	})
+}
+
+func (s *Supply) UnmarshalJSON(bz []byte) error {
+	tm := &supplyJSON{}
+	if err := tmjson.Unmarshal(bz, tm); err != nil {
+		return err
+	}
+
+	var err error
+	s.Height = tm.Height
+	for _, v := range []struct {
+		dst **uint256.Int
+		src string
+	}{
+		{&s.Total, tm.Total},
+		{&s.Issued, tm.Issued},
+		{&s.Burned, tm.Burned},
+		{&s.Staked, tm.Staked},
+		{&s.Frozen, tm.Frozen},
+		{&s.Rewards, tm.Rewards},
+		{&s.Circulating, tm.Circulating},
+	} {
+		if *v.dst, err = uint256.FromDecimal(v.src); err != nil {
+			return err
+		}
+	}
+	return nil
+}
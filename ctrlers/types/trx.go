@@ -22,8 +22,17 @@ const (
 	TRX_CONTRACT
 	TRX_SETDOC
 	TRX_WITHDRAW
+	TRX_MULTISIG
+	TRX_BATCH
+	TRX_VESTING
+	TRX_COMMISSION
+	TRX_REDELEGATE
+	TRX_UNJAIL
 )
 
+// TRX_VERSION_FEE_PAYER is the version of the tx from which `Trx.Payer` is available.
+const TRX_VERSION_FEE_PAYER uint32 = 2
+
 const (
 	EVENT_ATTR_TXSTATUS = "status"
 	EVENT_ATTR_TXTYPE   = "type"
@@ -45,6 +54,8 @@ type trxRPL struct {
 	Type     uint64
 	Payload  bytes.HexBytes
 	Sig      bytes.HexBytes
+	Payer    types.Address  `rlp:"optional"`
+	PayerSig bytes.HexBytes `rlp:"optional"`
 }
 
 type ITrxPayload interface {
@@ -68,6 +79,11 @@ type Trx struct {
 	Type     int32          `json:"type"`
 	Payload  ITrxPayload    `json:"payload,omitempty"`
 	Sig      bytes.HexBytes `json:"sig"`
+
+	// If `Payer` is set, the fee of the tx is charged to `Payer` instead of `From`.
+	// `PayerSig` is the signature of `Payer` for the tx signed by `From`.
+	Payer    types.Address  `json:"payer,omitempty"`
+	PayerSig bytes.HexBytes `json:"payerSig,omitempty"`
 }
 
 func (tx *Trx) Equal(_tx *Trx) bool {
@@ -107,6 +123,12 @@ func (tx *Trx) Equal(_tx *Trx) bool {
 	if bytes.Compare(tx.Sig, _tx.Sig) != 0 {
 		return false
 	}
+	if bytes.Compare(tx.Payer, _tx.Payer) != 0 {
+		return false
+	}
+	if bytes.Compare(tx.PayerSig, _tx.PayerSig) != 0 {
+		return false
+	}
 	if tx.Payload != nil {
 		return tx.Payload.Equal(_tx.Payload)
 	} else if _tx.Payload != nil {
@@ -138,6 +160,8 @@ func (tx *Trx) EncodeRLP(w io.Writer) error {
 		Type:     uint64(tx.Type),
 		Payload:  payload,
 		Sig:      tx.Sig,
+		Payer:    tx.Payer,
+		PayerSig: tx.PayerSig,
 	}
 	return rlp.Encode(w, tmpTx)
 }
@@ -159,6 +183,8 @@ func (tx *Trx) DecodeRLP(s *rlp.Stream) error {
 	tx.GasPrice = new(uint256.Int).SetBytes(rtx.GasPrice)
 	tx.Type = int32(rtx.Type)
 	tx.Sig = rtx.Sig
+	tx.Payer = rtx.Payer
+	tx.PayerSig = rtx.PayerSig
 
 	var payload ITrxPayload
 	if rtx.Payload != nil && len(rtx.Payload) > 0 {
@@ -179,6 +205,18 @@ func (tx *Trx) DecodeRLP(s *rlp.Stream) error {
 			payload = &TrxPayloadContract{}
 		case TRX_SETDOC:
 			payload = &TrxPayloadSetDoc{}
+		case TRX_MULTISIG:
+			payload = &TrxPayloadMultiSig{}
+		case TRX_BATCH:
+			payload = &TrxPayloadBatch{}
+		case TRX_VESTING:
+			payload = &TrxPayloadVesting{}
+		case TRX_COMMISSION:
+			payload = &TrxPayloadCommission{}
+		case TRX_REDELEGATE:
+			payload = &TrxPayloadRedelegate{}
+		case TRX_UNJAIL:
+			payload = &TrxPayloadUnjail{}
 		default:
 			return xerrors.ErrInvalidTrxPayloadType
 		}
@@ -236,6 +274,18 @@ func (tx *Trx) TypeString() string {
 		return "contract"
 	case TRX_SETDOC:
 		return "setdoc"
+	case TRX_MULTISIG:
+		return "multisig"
+	case TRX_BATCH:
+		return "batch"
+	case TRX_VESTING:
+		return "vesting"
+	case TRX_COMMISSION:
+		return "commission"
+	case TRX_REDELEGATE:
+		return "redelegate"
+	case TRX_UNJAIL:
+		return "unjail"
 	}
 	return ""
 }
@@ -263,7 +313,7 @@ func (tx *Trx) Encode() ([]byte, xerrors.XError) {
 func (tx *Trx) fromProto(txProto *TrxProto) xerrors.XError {
 	var payload ITrxPayload
 	switch txProto.Type {
-	case TRX_TRANSFER, TRX_STAKING:
+	case TRX_TRANSFER, TRX_STAKING, TRX_UNJAIL:
 		// there is no payload!!!
 	case TRX_UNSTAKING:
 		payload = &TrxPayloadUnstaking{}
@@ -295,6 +345,31 @@ func (tx *Trx) fromProto(txProto *TrxProto) xerrors.XError {
 		if err := payload.Decode(txProto.XPayload); err != nil {
 			return err
 		}
+	case TRX_MULTISIG:
+		payload = &TrxPayloadMultiSig{}
+		if err := payload.Decode(txProto.XPayload); err != nil {
+			return err
+		}
+	case TRX_BATCH:
+		payload = &TrxPayloadBatch{}
+		if err := payload.Decode(txProto.XPayload); err != nil {
+			return err
+		}
+	case TRX_VESTING:
+		payload = &TrxPayloadVesting{}
+		if err := payload.Decode(txProto.XPayload); err != nil {
+			return err
+		}
+	case TRX_COMMISSION:
+		payload = &TrxPayloadCommission{}
+		if err := payload.Decode(txProto.XPayload); err != nil {
+			return err
+		}
+	case TRX_REDELEGATE:
+		payload = &TrxPayloadRedelegate{}
+		if err := payload.Decode(txProto.XPayload); err != nil {
+			return err
+		}
 	default:
 		return xerrors.ErrInvalidTrxPayloadType
 	}
@@ -310,6 +385,8 @@ func (tx *Trx) fromProto(txProto *TrxProto) xerrors.XError {
 	tx.Type = txProto.Type
 	tx.Payload = payload
 	tx.Sig = txProto.Sig
+	tx.Payer = txProto.Payer
+	tx.PayerSig = txProto.PayerSig
 	return nil
 }
 
@@ -335,13 +412,15 @@ func (tx *Trx) toProto() (*TrxProto, xerrors.XError) {
 		Type:      tx.Type,
 		XPayload:  payload,
 		Sig:       tx.Sig,
+		Payer:     tx.Payer,
+		PayerSig:  tx.PayerSig,
 	}, nil
 }
 
 func PreImageToSignTrxProto(tx *Trx, chainId string) ([]byte, xerrors.XError) {
-	sig := tx.Sig
-	tx.Sig = nil
-	defer func() { tx.Sig = sig }()
+	sig, payerSig := tx.Sig, tx.PayerSig
+	tx.Sig, tx.PayerSig = nil, nil
+	defer func() { tx.Sig, tx.PayerSig = sig, payerSig }()
 
 	bz, xerr := tx.Encode()
 	if xerr != nil {
@@ -352,9 +431,24 @@ func PreImageToSignTrxProto(tx *Trx, chainId string) ([]byte, xerrors.XError) {
 }
 
 func PreImageToSignTrxRLP(tx *Trx, chainId string) ([]byte, xerrors.XError) {
-	sig := tx.Sig
-	tx.Sig = nil
-	defer func() { tx.Sig = sig }()
+	sig, payerSig := tx.Sig, tx.PayerSig
+	tx.Sig, tx.PayerSig = nil, nil
+	defer func() { tx.Sig, tx.PayerSig = sig, payerSig }()
+
+	bz, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		return nil, xerrors.From(err)
+	}
+	prefix := fmt.Sprintf("\x19RIGO(%s) Signed Message:\n%d", chainId, len(bz))
+	return append([]byte(prefix), bz...), nil
+}
+
+// PreImageToSignTrxPayerRLP returns the preimage signed by the fee payer.
+// It has the signature of the sender, so the payer signs the tx after the sender does.
+func PreImageToSignTrxPayerRLP(tx *Trx, chainId string) ([]byte, xerrors.XError) {
+	payerSig := tx.PayerSig
+	tx.PayerSig = nil
+	defer func() { tx.PayerSig = payerSig }()
 
 	bz, err := rlp.EncodeToBytes(tx)
 	if err != nil {
@@ -395,3 +489,32 @@ func VerifyTrxRLP(tx *Trx, chainId string) (types.Address, bytes.HexBytes, xerro
 	}
 	return fromAddr, pubKey, nil
 }
+
+// VerifyTrxMultiSigRLP verifies the signatures of `tx` sent from the multi-signature account having `ms`.
+func VerifyTrxMultiSigRLP(tx *Trx, ms *MultiSig, chainId string) xerrors.XError {
+	if bytes.Compare(ms.Address(), tx.From) != 0 {
+		return xerrors.ErrInvalidTrxSig.Wrap(fmt.Errorf("wrong multi-signature account - expected: %v, actual: %v", tx.From, ms.Address()))
+	}
+	preimg, xerr := PreImageToSignTrxRLP(tx, chainId)
+	if xerr != nil {
+		return xerr
+	}
+	return ms.VerifySigs(preimg, tx.Sig)
+}
+
+// VerifyTrxPayerRLP verifies the signature of the fee payer of `tx`.
+func VerifyTrxPayerRLP(tx *Trx, chainId string) (types.Address, bytes.HexBytes, xerrors.XError) {
+	preimg, xerr := PreImageToSignTrxPayerRLP(tx, chainId)
+	if xerr != nil {
+		return nil, nil, xerr
+	}
+
+	payerAddr, pubKey, xerr := crypto.Sig2Addr(preimg, tx.PayerSig)
+	if xerr != nil {
+		return nil, nil, xerrors.ErrInvalidTrxSig.Wrap(xerr)
+	}
+	if bytes.Compare(payerAddr, tx.Payer) != 0 {
+		return nil, nil, xerrors.ErrInvalidTrxSig.Wrap(fmt.Errorf("wrong payer address or sig - expected: %v, actual: %v", tx.Payer, payerAddr))
+	}
+	return payerAddr, pubKey, nil
+}
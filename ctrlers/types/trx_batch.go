@@ -0,0 +1,115 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/holiman/uint256"
+	"github.com/rigochain/rigo-go/types"
+	"github.com/rigochain/rigo-go/types/bytes"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	"google.golang.org/protobuf/proto"
+	"io"
+)
+
+// TrxPayloadBatch transfers the amounts to the recipients from the sender at once.
+// `Trx.To` should be the zero address and `Trx.Amount` should be the sum of the amounts of `Recipients`.
+// The fee is charged per recipient, so `Trx.Gas` should be `MinTrxGas` times the number of the recipients at least.
+type TrxPayloadBatch struct {
+	Recipients []*BatchRecipient `json:"recipients"`
+}
+
+type BatchRecipient struct {
+	To     types.Address `json:"to"`
+	Amount *uint256.Int  `json:"amount"`
+}
+
+func (tx *TrxPayloadBatch) Type() int32 {
+	return TRX_BATCH
+}
+
+// SumAmount returns the sum of the amounts to the recipients.
+func (tx *TrxPayloadBatch) SumAmount() *uint256.Int {
+	sum := uint256.NewInt(0)
+	for _, r := range tx.Recipients {
+		_ = sum.Add(sum, r.Amount)
+	}
+	return sum
+}
+
+func (tx *TrxPayloadBatch) Equal(_tx ITrxPayload) bool {
+	if _tx == nil {
+		return false
+	}
+	_tx0, ok := (_tx).(*TrxPayloadBatch)
+	if !ok {
+		return false
+	}
+	if len(tx.Recipients) != len(_tx0.Recipients) {
+		return false
+	}
+	for i, r := range tx.Recipients {
+		if bytes.Compare(r.To, _tx0.Recipients[i].To) != 0 ||
+			r.Amount.Cmp(_tx0.Recipients[i].Amount) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (tx *TrxPayloadBatch) Encode() ([]byte, xerrors.XError) {
+	pm := &TrxPayloadBatchProto{}
+	for _, r := range tx.Recipients {
+		pm.Recipients = append(pm.Recipients, &BatchRecipientProto{
+			To:      r.To,
+			XAmount: r.Amount.Bytes(),
+		})
+	}
+
+	bz, err := proto.Marshal(pm)
+	return bz, xerrors.From(err)
+}
+
+func (tx *TrxPayloadBatch) Decode(bz []byte) xerrors.XError {
+	pm := &TrxPayloadBatchProto{}
+	if err := proto.Unmarshal(bz, pm); err != nil {
+		return xerrors.From(err)
+	}
+
+	tx.Recipients = nil
+	for _, r := range pm.Recipients {
+		tx.Recipients = append(tx.Recipients, &BatchRecipient{
+			To:     r.To,
+			Amount: new(uint256.Int).SetBytes(r.XAmount),
+		})
+	}
+	return nil
+}
+
+type batchRecipientRLP struct {
+	To     types.Address
+	Amount bytes.HexBytes
+}
+
+func (tx *TrxPayloadBatch) EncodeRLP(w io.Writer) error {
+	items := make([]*batchRecipientRLP, len(tx.Recipients))
+	for i, r := range tx.Recipients {
+		items[i] = &batchRecipientRLP{To: r.To, Amount: r.Amount.Bytes()}
+	}
+	return rlp.Encode(w, items)
+}
+
+func (tx *TrxPayloadBatch) DecodeRLP(s *rlp.Stream) error {
+	var items []*batchRecipientRLP
+	if err := s.Decode(&items); err != nil {
+		return err
+	}
+	tx.Recipients = nil
+	for _, item := range items {
+		tx.Recipients = append(tx.Recipients, &BatchRecipient{
+			To:     item.To,
+			Amount: new(uint256.Int).SetBytes(item.Amount),
+		})
+	}
+	return nil
+}
+
+var _ ITrxPayload = (*TrxPayloadBatch)(nil)
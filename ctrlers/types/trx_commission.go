@@ -0,0 +1,75 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	"google.golang.org/protobuf/proto"
+	"io"
+)
+
+// TrxPayloadCommission sets the commission of the validator `Trx.From`.
+// The rates are percentages(%).
+// `MaxRate` and `MaxChangeRate` are fixed when the commission is set first,
+// and then only `Rate` can be changed by up to `MaxChangeRate` once a day.
+type TrxPayloadCommission struct {
+	Rate          int64 `json:"rate"`
+	MaxRate       int64 `json:"maxRate"`
+	MaxChangeRate int64 `json:"maxChangeRate"`
+}
+
+func (tx *TrxPayloadCommission) Type() int32 {
+	return TRX_COMMISSION
+}
+
+func (tx *TrxPayloadCommission) Equal(_tx ITrxPayload) bool {
+	if _tx == nil {
+		return false
+	}
+	_tx0, ok := (_tx).(*TrxPayloadCommission)
+	if !ok {
+		return false
+	}
+	return *tx == *_tx0
+}
+
+func (tx *TrxPayloadCommission) Encode() ([]byte, xerrors.XError) {
+	pm := &TrxPayloadCommissionProto{
+		Rate:          tx.Rate,
+		MaxRate:       tx.MaxRate,
+		MaxChangeRate: tx.MaxChangeRate,
+	}
+
+	bz, err := proto.Marshal(pm)
+	return bz, xerrors.From(err)
+}
+
+func (tx *TrxPayloadCommission) Decode(bz []byte) xerrors.XError {
+	pm := &TrxPayloadCommissionProto{}
+	if err := proto.Unmarshal(bz, pm); err != nil {
+		return xerrors.From(err)
+	}
+
+	tx.Rate = pm.Rate
+	tx.MaxRate = pm.MaxRate
+	tx.MaxChangeRate = pm.MaxChangeRate
+	return nil
+}
+
+func (tx *TrxPayloadCommission) EncodeRLP(w io.Writer) error {
+	return rlp.Encode(w, []interface{}{uint64(tx.Rate), uint64(tx.MaxRate), uint64(tx.MaxChangeRate)})
+}
+
+func (tx *TrxPayloadCommission) DecodeRLP(s *rlp.Stream) error {
+	var item struct {
+		Rate          uint64
+		MaxRate       uint64
+		MaxChangeRate uint64
+	}
+	if err := s.Decode(&item); err != nil {
+		return err
+	}
+	tx.Rate, tx.MaxRate, tx.MaxChangeRate = int64(item.Rate), int64(item.MaxRate), int64(item.MaxChangeRate)
+	return nil
+}
+
+var _ ITrxPayload = (*TrxPayloadCommission)(nil)
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"github.com/holiman/uint256"
 	bytes2 "github.com/rigochain/rigo-go/types/bytes"
 	"github.com/rigochain/rigo-go/types/xerrors"
 	abcitypes "github.com/tendermint/tendermint/abci/types"
@@ -15,9 +16,14 @@ type TrxContext struct {
 	TxIdx     int
 	Exec      bool
 
+	// the base fee of the block in which the tx is executed.
+	// if it is nil, the gas price of the governance parameters is used as the base fee.
+	BaseFee *uint256.Int
+
 	SenderPubKey []byte
 	Sender       *Account
 	Receiver     *Account
+	Payer        *Account // the fee payer of a sponsored tx. it is nil if the tx has no fee payer.
 	GasUsed      uint64
 	RetData      []byte
 	Events       []abcitypes.Event
@@ -31,8 +37,6 @@ type TrxContext struct {
 	AcctHandler  IAccountHandler
 	StakeHandler IStakeHandler
 	ChainID      string
-
-	Callback func(*TrxContext, xerrors.XError)
 }
 
 type ITrxHandler interface {
@@ -62,7 +66,21 @@ func NewTrxContext(txbz []byte, height, btime int64, exec bool, cbfns ...NewTrxC
 		}
 	}
 
-	txctx.Sender = txctx.AcctHandler.FindAccount(tx.From, txctx.Exec)
+	if len(tx.Payer) > 0 {
+		txctx.Payer = txctx.AcctHandler.FindAccount(tx.Payer, txctx.Exec)
+		if txctx.Payer == nil {
+			return nil, xerrors.ErrNotFoundAccount.Wrapf("payer address: %v", tx.Payer)
+		}
+		// the sender of a sponsored tx may have never held any asset.
+		// In that case, the sender is a new account which is not set to the ledger yet,
+		// so that it is committed only when the tx is executed successfully.
+		txctx.Sender = txctx.AcctHandler.FindAccount(tx.From, txctx.Exec)
+		if txctx.Sender == nil {
+			txctx.Sender = NewAccountWithName(tx.From, "")
+		}
+	} else {
+		txctx.Sender = txctx.AcctHandler.FindAccount(tx.From, txctx.Exec)
+	}
 	if txctx.Sender == nil {
 		return nil, xerrors.ErrNotFoundAccount.Wrapf("address: %v", tx.From)
 	}
@@ -73,3 +91,11 @@ func NewTrxContext(txbz []byte, height, btime int64, exec bool, cbfns ...NewTrxC
 	}
 	return txctx, nil
 }
+
+// FeePayer returns the account paying the fee of the tx.
+func (ctx *TrxContext) FeePayer() *Account {
+	if ctx.Payer != nil {
+		return ctx.Payer
+	}
+	return ctx.Sender
+}
@@ -0,0 +1,79 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/rigochain/rigo-go/types/bytes"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	"google.golang.org/protobuf/proto"
+	"io"
+)
+
+// TrxPayloadMultiSig registers the keys of the multi-signature account `Trx.To`,
+// which should be the address derived from the keys.
+type TrxPayloadMultiSig struct {
+	Threshold uint32           `json:"threshold"`
+	PubKeys   []bytes.HexBytes `json:"pubKeys"`
+}
+
+func (tx *TrxPayloadMultiSig) Type() int32 {
+	return TRX_MULTISIG
+}
+
+func (tx *TrxPayloadMultiSig) MultiSig() *MultiSig {
+	return NewMultiSig(tx.Threshold, tx.PubKeys...)
+}
+
+func (tx *TrxPayloadMultiSig) Equal(_tx ITrxPayload) bool {
+	if _tx == nil {
+		return false
+	}
+	_tx0, ok := (_tx).(*TrxPayloadMultiSig)
+	if !ok {
+		return false
+	}
+	return tx.MultiSig().Equal(_tx0.MultiSig())
+}
+
+func (tx *TrxPayloadMultiSig) Encode() ([]byte, xerrors.XError) {
+	pm := &TrxPayloadMultiSigProto{
+		Threshold: tx.Threshold,
+	}
+	for _, k := range tx.PubKeys {
+		pm.PubKeys = append(pm.PubKeys, k)
+	}
+
+	bz, err := proto.Marshal(pm)
+	return bz, xerrors.From(err)
+}
+
+func (tx *TrxPayloadMultiSig) Decode(bz []byte) xerrors.XError {
+	pm := &TrxPayloadMultiSigProto{}
+	if err := proto.Unmarshal(bz, pm); err != nil {
+		return xerrors.From(err)
+	}
+
+	tx.Threshold = pm.Threshold
+	tx.PubKeys = nil
+	for _, k := range pm.PubKeys {
+		tx.PubKeys = append(tx.PubKeys, k)
+	}
+	return nil
+}
+
+func (tx *TrxPayloadMultiSig) EncodeRLP(w io.Writer) error {
+	return rlp.Encode(w, []interface{}{tx.Threshold, tx.PubKeys})
+}
+
+func (tx *TrxPayloadMultiSig) DecodeRLP(s *rlp.Stream) error {
+	var item struct {
+		Threshold uint32
+		PubKeys   []bytes.HexBytes
+	}
+	if err := s.Decode(&item); err != nil {
+		return err
+	}
+	tx.Threshold, tx.PubKeys = item.Threshold, item.PubKeys
+	return nil
+}
+
+var _ ITrxPayload = (*TrxPayloadMultiSig)(nil)
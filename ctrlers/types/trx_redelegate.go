@@ -0,0 +1,71 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/rigochain/rigo-go/types"
+	"github.com/rigochain/rigo-go/types/bytes"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	"google.golang.org/protobuf/proto"
+	"io"
+)
+
+// TrxPayloadRedelegate moves the stake of `TxHash` from the delegatee `Src` to the delegatee `Trx.To`.
+type TrxPayloadRedelegate struct {
+	Src    types.Address  `json:"src"`
+	TxHash bytes.HexBytes `json:"txhash"`
+}
+
+func (tx *TrxPayloadRedelegate) Type() int32 {
+	return TRX_REDELEGATE
+}
+
+func (tx *TrxPayloadRedelegate) Equal(_tx ITrxPayload) bool {
+	if _tx == nil {
+		return false
+	}
+	_tx0, ok := (_tx).(*TrxPayloadRedelegate)
+	if !ok {
+		return false
+	}
+	return bytes.Compare(tx.Src, _tx0.Src) == 0 &&
+		bytes.Compare(tx.TxHash, _tx0.TxHash) == 0
+}
+
+func (tx *TrxPayloadRedelegate) Encode() ([]byte, xerrors.XError) {
+	pm := &TrxPayloadRedelegateProto{
+		Src:    tx.Src,
+		TxHash: tx.TxHash,
+	}
+
+	bz, err := proto.Marshal(pm)
+	return bz, xerrors.From(err)
+}
+
+func (tx *TrxPayloadRedelegate) Decode(bz []byte) xerrors.XError {
+	pm := &TrxPayloadRedelegateProto{}
+	if err := proto.Unmarshal(bz, pm); err != nil {
+		return xerrors.From(err)
+	}
+
+	tx.Src = pm.Src
+	tx.TxHash = pm.TxHash
+	return nil
+}
+
+func (tx *TrxPayloadRedelegate) EncodeRLP(w io.Writer) error {
+	return rlp.Encode(w, []interface{}{[]byte(tx.Src), []byte(tx.TxHash)})
+}
+
+func (tx *TrxPayloadRedelegate) DecodeRLP(s *rlp.Stream) error {
+	var item struct {
+		Src    []byte
+		TxHash []byte
+	}
+	if err := s.Decode(&item); err != nil {
+		return err
+	}
+	tx.Src, tx.TxHash = item.Src, item.TxHash
+	return nil
+}
+
+var _ ITrxPayload = (*TrxPayloadRedelegate)(nil)
@@ -2,6 +2,7 @@ package types
 
 import (
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/holiman/uint256"
 	"github.com/rigochain/rigo-go/types/bytes"
 	"github.com/rigochain/rigo-go/types/xerrors"
 	"google.golang.org/protobuf/proto"
@@ -37,8 +38,12 @@ func (tx *TrxPayloadStaking) DecodeRLP(s *rlp.Stream) error {
 //
 // TrxPayloadUnstaking
 
+// TrxPayloadUnstaking un-stakes the whole stake of `TxHash`.
+// If `Amount` is not nil, only `Amount` is un-staked from the stake of `TxHash`,
+// or from the stakes of the sender on `Trx.To` when `TxHash` is empty.
 type TrxPayloadUnstaking struct {
 	TxHash bytes.HexBytes `json:"txhash"`
+	Amount *uint256.Int   `json:"amount,omitempty"`
 }
 
 var _ ITrxPayload = (*TrxPayloadUnstaking)(nil)
@@ -54,7 +59,10 @@ func (tx *TrxPayloadUnstaking) Equal(_tx ITrxPayload) bool {
 	if !ok {
 		return false
 	}
-	return bytes.Compare(tx.TxHash, _tx0.TxHash) == 0
+	if tx.Amount == nil || _tx0.Amount == nil {
+		return tx.Amount == _tx0.Amount && bytes.Compare(tx.TxHash, _tx0.TxHash) == 0
+	}
+	return bytes.Compare(tx.TxHash, _tx0.TxHash) == 0 && tx.Amount.Cmp(_tx0.Amount) == 0
 }
 
 func (tx *TrxPayloadUnstaking) Decode(bz []byte) xerrors.XError {
@@ -63,6 +71,9 @@ func (tx *TrxPayloadUnstaking) Decode(bz []byte) xerrors.XError {
 		return xerrors.From(err)
 	}
 	tx.TxHash = pm.TxHash
+	if len(pm.XAmount) > 0 {
+		tx.Amount = new(uint256.Int).SetBytes(pm.XAmount)
+	}
 	return nil
 }
 
@@ -70,20 +81,45 @@ func (tx *TrxPayloadUnstaking) Encode() ([]byte, xerrors.XError) {
 	pm := &TrxPayloadUnstakingProto{
 		TxHash: tx.TxHash,
 	}
+	if tx.Amount != nil {
+		pm.XAmount = tx.Amount.Bytes()
+	}
 
 	bz, err := proto.Marshal(pm)
 	return bz, xerrors.From(err)
 }
 
+// EncodeRLP encodes only `TxHash` as before if `Amount` is nil,
+// otherwise it encodes the list of `TxHash` and `Amount`.
 func (tx *TrxPayloadUnstaking) EncodeRLP(w io.Writer) error {
-	return rlp.Encode(w, tx.TxHash)
+	if tx.Amount == nil {
+		return rlp.Encode(w, tx.TxHash)
+	}
+	return rlp.Encode(w, []interface{}{[]byte(tx.TxHash), tx.Amount.Bytes()})
 }
 
 func (tx *TrxPayloadUnstaking) DecodeRLP(s *rlp.Stream) error {
-	bz, err := s.Bytes()
+	kind, _, err := s.Kind()
 	if err != nil {
 		return err
 	}
-	tx.TxHash = bz
+	if kind != rlp.List {
+		bz, err := s.Bytes()
+		if err != nil {
+			return err
+		}
+		tx.TxHash = bz
+		return nil
+	}
+
+	var item struct {
+		TxHash []byte
+		Amount []byte
+	}
+	if err := s.Decode(&item); err != nil {
+		return err
+	}
+	tx.TxHash = item.TxHash
+	tx.Amount = new(uint256.Int).SetBytes(item.Amount)
 	return nil
 }
@@ -218,3 +218,159 @@ func BenchmarkTrxDecode(b *testing.B) {
 		require.NoError(b, err)
 	}
 }
+
+func TestRLP_TrxPayloadBatch(t *testing.T) {
+	w := web3.NewWallet([]byte("1"))
+	require.NoError(t, w.Unlock([]byte("1")))
+
+	tx0 := web3.NewTrxBatch(w.Address(), rand.Uint64(), 4000, uint256.NewInt(rand.Uint64()),
+		&types2.BatchRecipient{To: types.RandAddress(), Amount: uint256.NewInt(1000)},
+		&types2.BatchRecipient{To: types.RandAddress(), Amount: bytes.RandU256IntN(uint256.NewInt(1_000_000_000))},
+	)
+	require.Equal(t, types2.TRX_BATCH, tx0.GetType())
+	require.Equal(t, uint64(8000), tx0.Gas)
+	require.Equal(t, tx0.Payload.(*types2.TrxPayloadBatch).SumAmount(), tx0.Amount)
+
+	_, _, err := w.SignTrxRLP(tx0, "trx_test_chain")
+	require.NoError(t, err)
+
+	bz0, err := rlp.EncodeToBytes(tx0)
+	require.NoError(t, err)
+	tx1 := &types2.Trx{}
+	require.NoError(t, rlp.DecodeBytes(bz0, tx1))
+	require.True(t, tx0.Equal(tx1))
+	_, _, xerr := types2.VerifyTrxRLP(tx1, "trx_test_chain")
+	require.NoError(t, xerr)
+
+	bz0, xerr = tx0.Encode()
+	require.NoError(t, xerr)
+	tx2 := &types2.Trx{}
+	require.NoError(t, tx2.Decode(bz0))
+	require.True(t, tx0.Equal(tx2))
+}
+
+func TestRLP_TrxPayer(t *testing.T) {
+	sender, payer := web3.NewWallet([]byte("1")), web3.NewWallet([]byte("1"))
+	require.NoError(t, sender.Unlock([]byte("1")))
+	require.NoError(t, payer.Unlock([]byte("1")))
+
+	tx0 := web3.NewTrxTransfer(sender.Address(), types.RandAddress(), rand.Uint64(), 100_000, uint256.NewInt(rand.Uint64()), uint256.NewInt(1000))
+	bzNoPayer, err := rlp.EncodeToBytes(tx0)
+	require.NoError(t, err)
+
+	tx0 = web3.SetTrxPayer(tx0, payer.Address())
+	require.Equal(t, types2.TRX_VERSION_FEE_PAYER, tx0.Version)
+	_, _, err = sender.SignTrxRLP(tx0, "trx_test_chain")
+	require.NoError(t, err)
+	_, _, err = payer.SignTrxPayerRLP(tx0, "trx_test_chain")
+	require.NoError(t, err)
+
+	// the payer's signature doesn't change the preimage of the sender.
+	_, _, xerr := types2.VerifyTrxRLP(tx0, "trx_test_chain")
+	require.NoError(t, xerr)
+	_, _, xerr = types2.VerifyTrxPayerRLP(tx0, "trx_test_chain")
+	require.NoError(t, xerr)
+	_, _, xerr = types2.VerifyTrxPayerRLP(tx0, "other_chain")
+	require.Error(t, xerr)
+
+	bz0, err := rlp.EncodeToBytes(tx0)
+	require.NoError(t, err)
+	tx1 := &types2.Trx{}
+	require.NoError(t, rlp.DecodeBytes(bz0, tx1))
+	require.True(t, tx0.Equal(tx1))
+
+	bz0, xerr = tx0.Encode()
+	require.NoError(t, xerr)
+	tx2 := &types2.Trx{}
+	require.NoError(t, tx2.Decode(bz0))
+	require.True(t, tx0.Equal(tx2))
+
+	// the payer signs the sender's signature too.
+	tx2.Sig = bytes.RandBytes(len(tx2.Sig))
+	_, _, xerr = types2.VerifyTrxPayerRLP(tx2, "trx_test_chain")
+	require.Error(t, xerr)
+
+	// the tx without the payer is encoded as before.
+	tx3 := &types2.Trx{}
+	require.NoError(t, rlp.DecodeBytes(bzNoPayer, tx3))
+	require.Nil(t, tx3.Payer)
+	bz0, err = rlp.EncodeToBytes(tx3)
+	require.NoError(t, err)
+	require.Equal(t, bzNoPayer, bz0)
+}
+
+func TestRLP_TrxPayloadCommission(t *testing.T) {
+	w := web3.NewWallet([]byte("1"))
+	require.NoError(t, w.Unlock([]byte("1")))
+
+	tx0 := web3.NewTrxCommission(w.Address(), rand.Uint64(), 100_000, uint256.NewInt(rand.Uint64()), 10, 20, 1)
+	require.Equal(t, types2.TRX_COMMISSION, tx0.GetType())
+	_, _, err := w.SignTrxRLP(tx0, "trx_test_chain")
+	require.NoError(t, err)
+
+	bz0, err := rlp.EncodeToBytes(tx0)
+	require.NoError(t, err)
+	tx1 := &types2.Trx{}
+	require.NoError(t, rlp.DecodeBytes(bz0, tx1))
+	require.True(t, tx0.Equal(tx1))
+	_, _, xerr := types2.VerifyTrxRLP(tx1, "trx_test_chain")
+	require.NoError(t, xerr)
+
+	bz0, xerr = tx0.Encode()
+	require.NoError(t, xerr)
+	tx2 := &types2.Trx{}
+	require.NoError(t, tx2.Decode(bz0))
+	require.True(t, tx0.Equal(tx2))
+}
+
+func TestRLP_TrxPayloadRedelegate(t *testing.T) {
+	w := web3.NewWallet([]byte("1"))
+	require.NoError(t, w.Unlock([]byte("1")))
+
+	tx0 := web3.NewTrxRedelegate(w.Address(), types.RandAddress(), types.RandAddress(), rand.Uint64(), 100_000, uint256.NewInt(rand.Uint64()), bytes.RandHexBytes(32))
+	require.Equal(t, types2.TRX_REDELEGATE, tx0.GetType())
+	_, _, err := w.SignTrxRLP(tx0, "trx_test_chain")
+	require.NoError(t, err)
+
+	bz0, err := rlp.EncodeToBytes(tx0)
+	require.NoError(t, err)
+	tx1 := &types2.Trx{}
+	require.NoError(t, rlp.DecodeBytes(bz0, tx1))
+	require.True(t, tx0.Equal(tx1))
+	_, _, xerr := types2.VerifyTrxRLP(tx1, "trx_test_chain")
+	require.NoError(t, xerr)
+
+	bz0, xerr = tx0.Encode()
+	require.NoError(t, xerr)
+	tx2 := &types2.Trx{}
+	require.NoError(t, tx2.Decode(bz0))
+	require.True(t, tx0.Equal(tx2))
+}
+
+func TestRLP_TrxPayloadUnstakingAmount(t *testing.T) {
+	w := web3.NewWallet([]byte("1"))
+	require.NoError(t, w.Unlock([]byte("1")))
+
+	for _, tx0 := range []*types2.Trx{
+		web3.NewTrxUnstaking(w.Address(), types.RandAddress(), rand.Uint64(), 100_000, uint256.NewInt(rand.Uint64()), bytes.RandHexBytes(32)),
+		web3.NewTrxUnstakingAmount(w.Address(), types.RandAddress(), rand.Uint64(), 100_000, uint256.NewInt(rand.Uint64()), bytes.RandHexBytes(32), bytes.RandU256Int()),
+		web3.NewTrxUnstakingAmount(w.Address(), types.RandAddress(), rand.Uint64(), 100_000, uint256.NewInt(rand.Uint64()), nil, bytes.RandU256Int()),
+	} {
+		_, _, err := w.SignTrxRLP(tx0, "trx_test_chain")
+		require.NoError(t, err)
+
+		bz0, err := rlp.EncodeToBytes(tx0)
+		require.NoError(t, err)
+		tx1 := &types2.Trx{}
+		require.NoError(t, rlp.DecodeBytes(bz0, tx1))
+		require.True(t, tx0.Equal(tx1))
+		_, _, xerr := types2.VerifyTrxRLP(tx1, "trx_test_chain")
+		require.NoError(t, xerr)
+
+		bz0, xerr = tx0.Encode()
+		require.NoError(t, xerr)
+		tx2 := &types2.Trx{}
+		require.NoError(t, tx2.Decode(bz0))
+		require.True(t, tx0.Equal(tx2))
+	}
+}
@@ -0,0 +1,34 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	"io"
+)
+
+// TrxPayloadUnjail releases the jailed validator `Trx.From` after its jail period.
+type TrxPayloadUnjail struct{}
+
+var _ ITrxPayload = (*TrxPayloadUnjail)(nil)
+
+func (tx *TrxPayloadUnjail) Type() int32 {
+	return TRX_UNJAIL
+}
+func (tx *TrxPayloadUnjail) Equal(_tx ITrxPayload) bool {
+	return true
+}
+func (tx *TrxPayloadUnjail) Decode(bz []byte) xerrors.XError {
+	return nil
+}
+
+func (tx *TrxPayloadUnjail) Encode() ([]byte, xerrors.XError) {
+	return nil, nil
+}
+
+func (tx *TrxPayloadUnjail) EncodeRLP(w io.Writer) error {
+	return nil
+}
+
+func (tx *TrxPayloadUnjail) DecodeRLP(s *rlp.Stream) error {
+	return nil
+}
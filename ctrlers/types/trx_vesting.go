@@ -0,0 +1,83 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/holiman/uint256"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	"google.golang.org/protobuf/proto"
+	"io"
+)
+
+// TrxPayloadVesting transfers `Trx.Amount` to `Trx.To` and locks it on the schedule.
+// `Trx.To` becomes a vesting account.
+type TrxPayloadVesting struct {
+	Start  int64 `json:"start"`
+	Cliff  int64 `json:"cliff"`
+	End    int64 `json:"end"`
+	Period int64 `json:"period,omitempty"`
+}
+
+func (tx *TrxPayloadVesting) Type() int32 {
+	return TRX_VESTING
+}
+
+// Vesting returns the vesting schedule locking `amt`.
+func (tx *TrxPayloadVesting) Vesting(amt *uint256.Int) *Vesting {
+	return NewVesting(amt.Clone(), tx.Start, tx.Cliff, tx.End, tx.Period)
+}
+
+func (tx *TrxPayloadVesting) Equal(_tx ITrxPayload) bool {
+	if _tx == nil {
+		return false
+	}
+	_tx0, ok := (_tx).(*TrxPayloadVesting)
+	if !ok {
+		return false
+	}
+	return *tx == *_tx0
+}
+
+func (tx *TrxPayloadVesting) Encode() ([]byte, xerrors.XError) {
+	pm := &TrxPayloadVestingProto{
+		Start:  tx.Start,
+		Cliff:  tx.Cliff,
+		End:    tx.End,
+		Period: tx.Period,
+	}
+
+	bz, err := proto.Marshal(pm)
+	return bz, xerrors.From(err)
+}
+
+func (tx *TrxPayloadVesting) Decode(bz []byte) xerrors.XError {
+	pm := &TrxPayloadVestingProto{}
+	if err := proto.Unmarshal(bz, pm); err != nil {
+		return xerrors.From(err)
+	}
+
+	tx.Start = pm.Start
+	tx.Cliff = pm.Cliff
+	tx.End = pm.End
+	tx.Period = pm.Period
+	return nil
+}
+
+func (tx *TrxPayloadVesting) EncodeRLP(w io.Writer) error {
+	return rlp.Encode(w, []interface{}{uint64(tx.Start), uint64(tx.Cliff), uint64(tx.End), uint64(tx.Period)})
+}
+
+func (tx *TrxPayloadVesting) DecodeRLP(s *rlp.Stream) error {
+	var item struct {
+		Start  uint64
+		Cliff  uint64
+		End    uint64
+		Period uint64
+	}
+	if err := s.Decode(&item); err != nil {
+		return err
+	}
+	tx.Start, tx.Cliff, tx.End, tx.Period = int64(item.Start), int64(item.Cliff), int64(item.End), int64(item.Period)
+	return nil
+}
+
+var _ ITrxPayload = (*TrxPayloadVesting)(nil)
@@ -0,0 +1,38 @@
+package types
+
+import (
+	"encoding/json"
+	"github.com/rigochain/rigo-go/types/xerrors"
+)
+
+// UpgradePlan is the option of the upgrade proposal.
+// The upgrade named `Name` is applied at `Height`, which is the applying height of the proposal.
+type UpgradePlan struct {
+	Name   string `json:"name"`
+	Info   string `json:"info,omitempty"`
+	Height int64  `json:"height,omitempty"`
+}
+
+// IUpgradeHistory provides the upgrades which have been applied.
+type IUpgradeHistory interface {
+	HasUpgrade(name string) bool
+}
+
+func DecodeUpgradePlan(bz []byte) (*UpgradePlan, xerrors.XError) {
+	plan := &UpgradePlan{}
+	if err := json.Unmarshal(bz, plan); err != nil {
+		return nil, xerrors.From(err)
+	}
+	if plan.Name == "" {
+		return nil, xerrors.NewOrdinary("the name of upgrade plan is empty")
+	}
+	return plan, nil
+}
+
+func (plan *UpgradePlan) Encode() ([]byte, xerrors.XError) {
+	if bz, err := json.Marshal(plan); err != nil {
+		return nil, xerrors.From(err)
+	} else {
+		return bz, nil
+	}
+}
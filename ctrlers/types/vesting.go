@@ -0,0 +1,158 @@
+package types
+
+import (
+	"encoding/json"
+	"github.com/holiman/uint256"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	"google.golang.org/protobuf/proto"
+)
+
+// MinVestingAmount is the minimum amount locked by TRX_VESTING.
+// It makes adding many tiny schedules to an account expensive.
+var MinVestingAmount = uint256.MustFromDecimal("1000000000000000000") // 1 RIGO
+
+// Vesting is the schedule on which `Amount` of a vesting account is unlocked.
+// Nothing is unlocked before `Cliff`, and all of `Amount` is unlocked at `End`.
+// Between them, `Amount` is unlocked in proportion to the time elapsed since `Start`;
+// linearly if `Period` is 0, or by `Period` seconds otherwise.
+// The times are unix timestamps in seconds.
+//
+// The locked amount can not be spent, but it can be staked.
+type Vesting struct {
+	Amount *uint256.Int
+	Start  int64
+	Cliff  int64
+	End    int64
+	Period int64
+}
+
+func NewVesting(amt *uint256.Int, start, cliff, end, period int64) *Vesting {
+	return &Vesting{
+		Amount: amt,
+		Start:  start,
+		Cliff:  cliff,
+		End:    end,
+		Period: period,
+	}
+}
+
+func (v *Vesting) ValidateBasic() xerrors.XError {
+	if v.Amount == nil || v.Amount.Sign() <= 0 {
+		return xerrors.ErrInvalidAmount.Wrapf("the vesting amount should be positive")
+	}
+	if v.Start < 0 || v.Start >= v.End {
+		return xerrors.ErrInvalidTrxPayloadParams.Wrapf("wrong vesting period - start: %v, end: %v", v.Start, v.End)
+	}
+	if v.Cliff < v.Start || v.Cliff > v.End {
+		return xerrors.ErrInvalidTrxPayloadParams.Wrapf("the cliff(%v) should be in [%v, %v]", v.Cliff, v.Start, v.End)
+	}
+	if v.Period < 0 || (v.Period > 0 && (v.End-v.Start)%v.Period != 0) {
+		return xerrors.ErrInvalidTrxPayloadParams.Wrapf("the vesting duration(%v) should be a multiple of the period(%v)", v.End-v.Start, v.Period)
+	}
+	return nil
+}
+
+// Unlocked returns the amount unlocked at `now`.
+func (v *Vesting) Unlocked(now int64) *uint256.Int {
+	if now < v.Cliff {
+		return uint256.NewInt(0)
+	}
+	if now >= v.End {
+		return v.Amount.Clone()
+	}
+
+	elapsed := now - v.Start
+	if v.Period > 0 {
+		elapsed -= elapsed % v.Period
+	}
+	unlocked := new(uint256.Int).Mul(v.Amount, uint256.NewInt(uint64(elapsed)))
+	return unlocked.Div(unlocked, uint256.NewInt(uint64(v.End-v.Start)))
+}
+
+// Locked returns the amount locked at `now`.
+func (v *Vesting) Locked(now int64) *uint256.Int {
+	return new(uint256.Int).Sub(v.Amount, v.Unlocked(now))
+}
+
+func (v *Vesting) Equal(o *Vesting) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	return v.Amount.Cmp(o.Amount) == 0 &&
+		v.Start == o.Start &&
+		v.Cliff == o.Cliff &&
+		v.End == o.End &&
+		v.Period == o.Period
+}
+
+func (v *Vesting) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Amount string `json:"amount"`
+		Start  int64  `json:"start"`
+		Cliff  int64  `json:"cliff"`
+		End    int64  `json:"end"`
+		Period int64  `json:"period,omitempty"`
+	}{
+		Amount: uint256ToString(v.Amount),
+		Start:  v.Start,
+		Cliff:  v.Cliff,
+		End:    v.End,
+		Period: v.Period,
+	})
+}
+
+func (v *Vesting) UnmarshalJSON(bz []byte) error {
+	tm := &struct {
+		Amount string `json:"amount"`
+		Start  int64  `json:"start"`
+		Cliff  int64  `json:"cliff"`
+		End    int64  `json:"end"`
+		Period int64  `json:"period,omitempty"`
+	}{}
+	if err := json.Unmarshal(bz, tm); err != nil {
+		return err
+	}
+
+	amt, err := stringToUint256(tm.Amount)
+	if err != nil {
+		return err
+	}
+	v.Amount = amt
+	v.Start = tm.Start
+	v.Cliff = tm.Cliff
+	v.End = tm.End
+	v.Period = tm.Period
+	return nil
+}
+
+func (v *Vesting) toProto() *VestingProto {
+	return &VestingProto{
+		XAmount: v.Amount.Bytes(),
+		Start:   v.Start,
+		Cliff:   v.Cliff,
+		End:     v.End,
+		Period:  v.Period,
+	}
+}
+
+func (v *Vesting) fromProto(pm *VestingProto) {
+	v.Amount = new(uint256.Int).SetBytes(pm.XAmount)
+	v.Start = pm.Start
+	v.Cliff = pm.Cliff
+	v.End = pm.End
+	v.Period = pm.Period
+}
+
+func (v *Vesting) Encode() ([]byte, xerrors.XError) {
+	bz, err := proto.Marshal(v.toProto())
+	return bz, xerrors.From(err)
+}
+
+func (v *Vesting) Decode(bz []byte) xerrors.XError {
+	pm := &VestingProto{}
+	if err := proto.Unmarshal(bz, pm); err != nil {
+		return xerrors.From(err)
+	}
+	v.fromProto(pm)
+	return nil
+}
@@ -0,0 +1,108 @@
+package types_test
+
+import (
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/holiman/uint256"
+	types2 "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/libs/web3"
+	"github.com/rigochain/rigo-go/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestVesting_Unlocked(t *testing.T) {
+	linear := types2.NewVesting(uint256.NewInt(1000), 100, 150, 200, 0)
+	require.NoError(t, linear.ValidateBasic())
+	periodic := types2.NewVesting(uint256.NewInt(1000), 100, 100, 200, 25)
+	require.NoError(t, periodic.ValidateBasic())
+
+	for _, c := range []struct {
+		now              int64
+		linear, periodic uint64
+	}{
+		{0, 0, 0},
+		{100, 0, 0},
+		{149, 0, 250},
+		{150, 500, 500},
+		{199, 990, 750},
+		{200, 1000, 1000},
+		{300, 1000, 1000},
+	} {
+		require.EqualValues(t, c.linear, linear.Unlocked(c.now).Uint64(), "now: %v", c.now)
+		require.EqualValues(t, 1000-c.linear, linear.Locked(c.now).Uint64(), "now: %v", c.now)
+		require.EqualValues(t, c.periodic, periodic.Unlocked(c.now).Uint64(), "now: %v", c.now)
+	}
+
+	require.Error(t, types2.NewVesting(uint256.NewInt(0), 100, 100, 200, 0).ValidateBasic())
+	require.Error(t, types2.NewVesting(uint256.NewInt(1000), 200, 200, 200, 0).ValidateBasic())
+	require.Error(t, types2.NewVesting(uint256.NewInt(1000), 100, 99, 200, 0).ValidateBasic())
+	require.Error(t, types2.NewVesting(uint256.NewInt(1000), 100, 201, 200, 0).ValidateBasic())
+	require.Error(t, types2.NewVesting(uint256.NewInt(1000), 100, 100, 200, 30).ValidateBasic())
+}
+
+func TestAccount_LockedBalance(t *testing.T) {
+	acct := types2.NewAccount(types.RandAddress())
+	require.NoError(t, acct.AddBalance(uint256.NewInt(1500)))
+	acct.AddVesting(types2.NewVesting(uint256.NewInt(1000), 100, 100, 200, 0), 0)
+
+	require.NoError(t, acct.CheckBalance(uint256.NewInt(500), 100))
+	require.Error(t, acct.CheckBalance(uint256.NewInt(501), 100))
+	require.NoError(t, acct.CheckBalance(uint256.NewInt(1000), 150))
+	require.NoError(t, acct.CheckBalance(uint256.NewInt(1500), 200))
+
+	// the locked balance has been staked.
+	require.NoError(t, acct.SubBalance(uint256.NewInt(1200)))
+	require.EqualValues(t, 300, acct.LockedBalance(100).Uint64())
+	require.Error(t, acct.CheckBalance(uint256.NewInt(1), 100))
+	require.NoError(t, acct.CheckBalance(uint256.NewInt(300), 200))
+
+	bz, xerr := acct.Encode()
+	require.NoError(t, xerr)
+	acct1 := &types2.Account{}
+	require.NoError(t, acct1.Decode(bz))
+	require.Len(t, acct1.GetVestings(), 1)
+	require.True(t, acct.GetVestings()[0].Equal(acct1.GetVestings()[0]))
+
+	bz, err := json.Marshal(acct.GetVestings()[0])
+	require.NoError(t, err)
+	v := &types2.Vesting{}
+	require.NoError(t, json.Unmarshal(bz, v))
+	require.True(t, acct.GetVestings()[0].Equal(v))
+}
+
+func TestAccount_AddVesting(t *testing.T) {
+	acct := types2.NewAccount(types.RandAddress())
+	require.NoError(t, acct.AddBalance(uint256.NewInt(3000)))
+	acct.AddVesting(types2.NewVesting(uint256.NewInt(1000), 100, 100, 200, 0), 0)
+
+	// a small schedule ending far in the future locks only its own amount.
+	acct.AddVesting(types2.NewVesting(uint256.NewInt(1), 100, 100, 1_000_000, 0), 100)
+	require.EqualValues(t, 1001, acct.LockedBalance(100).Uint64())
+	require.EqualValues(t, 1, acct.LockedBalance(200).Uint64())
+
+	// the schedules all unlocked are removed.
+	acct.AddVesting(types2.NewVesting(uint256.NewInt(1000), 200, 300, 400, 0), 200)
+	require.Len(t, acct.GetVestings(), 2)
+	require.EqualValues(t, 1001, acct.LockedBalance(200).Uint64())
+	require.NoError(t, acct.CheckBalance(uint256.NewInt(1999), 200))
+	require.Error(t, acct.CheckBalance(uint256.NewInt(2000), 200))
+}
+
+func TestRLP_TrxPayloadVesting(t *testing.T) {
+	tx0 := web3.NewTrxVesting(types.RandAddress(), types.RandAddress(), 1, 100_000, uint256.NewInt(10), uint256.NewInt(1000), 100, 150, 200, 25)
+	require.Equal(t, types2.TRX_VESTING, tx0.GetType())
+
+	bz, err := rlp.EncodeToBytes(tx0)
+	require.NoError(t, err)
+	tx1 := &types2.Trx{}
+	require.NoError(t, rlp.DecodeBytes(bz, tx1))
+	require.True(t, tx0.Equal(tx1))
+
+	bz, xerr := tx0.Encode()
+	require.NoError(t, xerr)
+	tx2 := &types2.Trx{}
+	require.NoError(t, tx2.Decode(bz))
+	require.True(t, tx0.Equal(tx2))
+	require.True(t, types2.NewVesting(uint256.NewInt(1000), 100, 150, 200, 25).Equal(tx2.Payload.(*types2.TrxPayloadVesting).Vesting(tx2.Amount)))
+}
@@ -32,7 +32,15 @@ func GetHash(h uint64) common.Hash {
 	return common.Hash{}
 }
 
-func evmBlockContext(sender common.Address, bn int64, tm int64) vm.BlockContext {
+// evmBlockContext returns the block context of EVM.
+// `baseFee` is returned by the `BASEFEE` opcode. If it is nil, it is 0.
+// The fee cap and the tip cap of the messages are 0,
+// so EVM charges the gas price of the tx and gives no tip to `Coinbase`; the fee is distributed at EndBlock.
+func evmBlockContext(sender common.Address, bn int64, tm int64, baseFee *uint256.Int) vm.BlockContext {
+	_baseFee := big.NewInt(0)
+	if baseFee != nil {
+		_baseFee = baseFee.ToBig()
+	}
 	return vm.BlockContext{
 		CanTransfer: CanTransfer,
 		Transfer:    Transfer,
@@ -41,7 +49,7 @@ func evmBlockContext(sender common.Address, bn int64, tm int64) vm.BlockContext
 		BlockNumber: big.NewInt(bn),
 		Time:        big.NewInt(tm),
 		Difficulty:  big.NewInt(1),
-		BaseFee:     big.NewInt(0),
+		BaseFee:     _baseFee,
 		GasLimit:    gasLimit, // issue #44
 	}
 }
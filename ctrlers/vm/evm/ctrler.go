@@ -13,6 +13,8 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/holiman/uint256"
 	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/genesis"
+	"github.com/rigochain/rigo-go/ledger"
 	"github.com/rigochain/rigo-go/types"
 	"github.com/rigochain/rigo-go/types/bytes"
 	"github.com/rigochain/rigo-go/types/xerrors"
@@ -27,12 +29,13 @@ import (
 
 var (
 	lastBlockHeightKey              = []byte("lbh")
+	blockKeyPrefix                  = []byte("bn")
 	RIGOTestnetEVMCtrlerChainConfig = &params.ChainConfig{big.NewInt(220818), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, nil, false, new(params.EthashConfig), nil}
 	RIGOMainnetEVMCtrlerChainConfig = &params.ChainConfig{big.NewInt(220819), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, nil, false, new(params.EthashConfig), nil}
 )
 
 func blockKey(h int64) []byte {
-	return []byte(fmt.Sprintf("bn%v", h))
+	return []byte(fmt.Sprintf("%s%v", blockKeyPrefix, h))
 }
 
 type EVMCtrler struct {
@@ -46,12 +49,14 @@ type EVMCtrler struct {
 	metadb          tmdb.DB
 	lastRootHash    bytes.HexBytes
 	lastBlockHeight int64
+	pruning         ledger.PruningOption
+	pruner          pruner
 
 	logger tmlog.Logger
 	mtx    sync.RWMutex
 }
 
-func NewEVMCtrler(path string, acctHandler ctrlertypes.IAccountHandler, logger tmlog.Logger) *EVMCtrler {
+func NewEVMCtrler(path string, acctHandler ctrlertypes.IAccountHandler, pruning ledger.PruningOption, logger tmlog.Logger) *EVMCtrler {
 	metadb, err := tmdb.NewDB("heightRootHash", "goleveldb", path)
 	if err != nil {
 		panic(err)
@@ -89,12 +94,28 @@ func NewEVMCtrler(path string, acctHandler ctrlertypes.IAccountHandler, logger t
 		acctHandler:     acctHandler,
 		lastRootHash:    hash,
 		lastBlockHeight: bn,
+		pruning:         pruning,
 		logger:          logger,
 	}
 }
 
 func (ctrler *EVMCtrler) InitLedger(req interface{}) xerrors.XError {
-	// Handle `lastRoot` at here
+	ctrler.mtx.Lock()
+	defer ctrler.mtx.Unlock()
+
+	genAppState, ok := req.(*genesis.GenesisAppState)
+	if !ok {
+		return xerrors.ErrInitChain.Wrapf("wrong parameter: EVMCtrler::InitLedger requires *genesis.GenesisAppState")
+	}
+	if len(genAppState.Contracts) == 0 {
+		return nil
+	}
+
+	rootHash, xerr := ctrler.importGenesis(genAppState)
+	if xerr != nil {
+		return xerrors.ErrInitChain.Wrap(xerr)
+	}
+	ctrler.lastRootHash = rootHash
 	return nil
 }
 
@@ -115,7 +136,7 @@ func (ctrler *EVMCtrler) BeginBlock(ctx *ctrlertypes.BlockContext) ([]abcitypes.
 	ctrler.blockGasPool = new(ethcore.GasPool).AddGas(gasLimit)
 
 	beneficiary := bytes.HexBytes(ctx.BlockInfo().Header.ProposerAddress).Array20()
-	blockContext := evmBlockContext(beneficiary, ctx.Height(), ctx.TimeSeconds())
+	blockContext := evmBlockContext(beneficiary, ctx.Height(), ctx.TimeSeconds(), ctx.BaseFee())
 	ctrler.vmevm = ethvm.NewEVM(blockContext, ethvm.TxContext{}, ctrler.stateDBWrapper, ctrler.ethChainConfig, ethvm.Config{NoBaseFee: true})
 
 	return nil, nil
@@ -172,6 +193,14 @@ func (ctrler *EVMCtrler) ExecuteTrx(ctx *ctrlertypes.TrxContext) xerrors.XError
 	// issue #48 - prepare hash and index of tx
 	ctrler.stateDBWrapper.Prepare(ctx.TxHash, ctx.TxIdx, ctx.Tx.From, ctx.Tx.To, snap, ctx.Exec)
 
+	// The EVM buys the gas from the sender.
+	// If the tx has a fee payer, the payer lends the gas to the sender before the execution,
+	// and takes back the gas refunded to the sender after the execution.
+	if ctx.Payer != nil {
+		fee := new(uint256.Int).Mul(ctx.Tx.GasPrice, uint256.NewInt(ctx.Tx.Gas))
+		ctrler.moveBalance(ctx.Tx.Payer, ctx.Tx.From, fee.ToBig())
+	}
+
 	inputData := []byte(nil)
 	payload, ok := ctx.Tx.Payload.(*ctrlertypes.TrxPayloadContract)
 	if ok {
@@ -183,7 +212,7 @@ func (ctrler *EVMCtrler) ExecuteTrx(ctx *ctrlertypes.TrxContext) xerrors.XError
 		ctx.Tx.To,
 		ctx.Tx.Nonce,
 		ctx.Tx.Gas,
-		ctx.GovHandler.GasPrice(),
+		ctx.Tx.GasPrice,
 		ctx.Tx.Amount,
 		inputData,
 		ctx.Exec,
@@ -201,6 +230,11 @@ func (ctrler *EVMCtrler) ExecuteTrx(ctx *ctrlertypes.TrxContext) xerrors.XError
 		return xerrors.From(evmResult.Err)
 	}
 
+	if ctx.Payer != nil {
+		refund := new(uint256.Int).Mul(ctx.Tx.GasPrice, uint256.NewInt(ctx.Tx.Gas-evmResult.UsedGas))
+		ctrler.moveBalance(ctx.Tx.From, ctx.Tx.Payer, refund.ToBig())
+	}
+
 	ctrler.stateDBWrapper.Finish()
 
 	// Update the state with pending changes.
@@ -316,6 +350,15 @@ func (ctrler *EVMCtrler) execVM(from, to types.Address, nonce, gas uint64, gasPr
 	return result, nil
 }
 
+// moveBalance moves `amt` from `from` to `to` in the state of the EVM.
+// The accounts are loaded from the account ledger and are written back to it by `Finish`.
+func (ctrler *EVMCtrler) moveBalance(from, to types.Address, amt *big.Int) {
+	ctrler.stateDBWrapper.addAccessedObjAddr(from.Array20())
+	ctrler.stateDBWrapper.addAccessedObjAddr(to.Array20())
+	ctrler.stateDBWrapper.SubBalance(from.Array20(), amt)
+	ctrler.stateDBWrapper.AddBalance(to.Array20(), amt)
+}
+
 func (ctrler *EVMCtrler) EndBlock(context *ctrlertypes.BlockContext) ([]abcitypes.Event, xerrors.XError) {
 	return nil, nil
 }
@@ -328,7 +371,11 @@ func (ctrler *EVMCtrler) Commit() ([]byte, int64, xerrors.XError) {
 	if err != nil {
 		panic(err)
 	}
-	if err := ctrler.stateDBWrapper.Database().TrieDB().Commit(rootHash, true, nil); err != nil {
+
+	// the pruning in background does not delete the nodes written here,
+	// even if they have the same hashes as the nodes only used by the pruned states.
+	ctrler.pruner.commitMtx.Lock()
+	if err := ctrler.stateDBWrapper.Database().TrieDB().Commit(rootHash, true, ctrler.pruner.onCommitted); err != nil {
 		panic(err)
 	}
 	ctrler.lastBlockHeight++
@@ -339,6 +386,7 @@ func (ctrler *EVMCtrler) Commit() ([]byte, int64, xerrors.XError) {
 	batch.Set(blockKey(ctrler.lastBlockHeight), ctrler.lastRootHash)
 	batch.WriteSync()
 	batch.Close()
+	ctrler.pruner.commitMtx.Unlock()
 
 	stdb, err := NewStateDBWrapper(ctrler.ethDB, ctrler.lastRootHash, ctrler.acctHandler, ctrler.logger)
 	if err != nil {
@@ -347,13 +395,62 @@ func (ctrler *EVMCtrler) Commit() ([]byte, int64, xerrors.XError) {
 
 	ctrler.stateDBWrapper = stdb
 
+	ctrler.requestPrune(ctrler.lastBlockHeight)
+
 	return rootHash[:], ctrler.lastBlockHeight, nil
 }
 
+// Rollback discards the state roots of the blocks higher than `height`.
+// The trie nodes of the discarded states are left in `ethDB`, but they are not reachable from the state root of `height`.
+func (ctrler *EVMCtrler) Rollback(height int64) xerrors.XError {
+	ctrler.mtx.Lock()
+	defer ctrler.mtx.Unlock()
+
+	ctrler.pruner.wait()
+
+	if height < 0 || height > ctrler.lastBlockHeight {
+		return xerrors.NewOrdinary(fmt.Sprintf("can not rollback to the height %v - current height: %v", height, ctrler.lastBlockHeight))
+	}
+
+	var rootHash []byte
+	if height > 0 {
+		hash, err := ctrler.metadb.Get(blockKey(height))
+		if err != nil {
+			return xerrors.From(err)
+		} else if hash == nil {
+			return xerrors.NewOrdinary(fmt.Sprintf("not found the state root of height %v", height))
+		}
+		rootHash = hash
+	}
+
+	batch := ctrler.metadb.NewBatch()
+	defer batch.Close()
+	for h := height + 1; h <= ctrler.lastBlockHeight; h++ {
+		_ = batch.Delete(blockKey(h))
+	}
+	if height > 0 {
+		_ = batch.Set(lastBlockHeightKey, []byte(strconv.FormatInt(height, 10)))
+	} else {
+		_ = batch.Delete(lastBlockHeightKey)
+	}
+	if err := batch.WriteSync(); err != nil {
+		return xerrors.From(err)
+	}
+
+	ctrler.lastBlockHeight = height
+	ctrler.lastRootHash = rootHash
+	// the state of `lastRootHash` is opened again at the next BeginBlock.
+	ctrler.stateDBWrapper = nil
+	ctrler.vmevm = nil
+	return nil
+}
+
 func (ctrler *EVMCtrler) Close() xerrors.XError {
 	ctrler.mtx.Lock()
 	defer ctrler.mtx.Unlock()
 
+	ctrler.pruner.wait()
+
 	if ctrler.metadb != nil {
 		if err := ctrler.metadb.Close(); err != nil {
 			return xerrors.From(err)
@@ -362,19 +459,38 @@ func (ctrler *EVMCtrler) Close() xerrors.XError {
 	}
 
 	if ctrler.stateDBWrapper != nil {
+		// it closes `ethDB` too.
 		if err := ctrler.stateDBWrapper.Close(); err != nil {
 			return xerrors.From(err)
 		}
 		ctrler.stateDBWrapper = nil
+	} else if ctrler.ethDB != nil {
+		if err := ctrler.ethDB.Close(); err != nil {
+			return xerrors.From(err)
+		}
 	}
+	ctrler.ethDB = nil
 
 	return nil
 }
 
+// RootHashAt returns the state root of `height`, which is included in the app hash of `height`.
+func (ctrler *EVMCtrler) RootHashAt(height int64) ([]byte, xerrors.XError) {
+	hash, err := ctrler.metadb.Get(blockKey(height))
+	if err != nil {
+		return nil, xerrors.From(err)
+	} else if hash == nil && height > 0 && height < ctrler.lastBlockHeight {
+		return nil, xerrors.ErrPrunedHeight.Wrapf("the state of height %v is pruned", height)
+	}
+	return hash, nil
+}
+
 func (ctrler *EVMCtrler) ImmutableStateAt(height int64) (*StateDBWrapper, xerrors.XError) {
 	hash, err := ctrler.metadb.Get(blockKey(height))
 	if err != nil {
 		return nil, xerrors.From(err)
+	} else if hash == nil && height > 0 && height < ctrler.lastBlockHeight {
+		return nil, xerrors.ErrPrunedHeight.Wrapf("the state of height %v is pruned", height)
 	}
 
 	stateDB, err := state.New(bytes.HexBytes(hash).Array32(), state.NewDatabase(ctrler.ethDB), nil)
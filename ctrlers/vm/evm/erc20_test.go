@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/holiman/uint256"
 	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/ledger"
 	"github.com/rigochain/rigo-go/libs/web3"
 	"github.com/rigochain/rigo-go/types"
 	bytes2 "github.com/rigochain/rigo-go/types/bytes"
@@ -76,7 +77,7 @@ func init() {
 
 func Test_callEVM_Deploy(t *testing.T) {
 	os.RemoveAll(dbPath)
-	erc20EVM = NewEVMCtrler(dbPath, &acctHandler, tmlog.NewTMLogger(tmlog.NewSyncWriter(os.Stdout)))
+	erc20EVM = NewEVMCtrler(dbPath, &acctHandler, ledger.PruningOption{}, tmlog.NewTMLogger(tmlog.NewSyncWriter(os.Stdout)))
 
 	deployInput, err := abiERC20Contract.Pack("", "TokenOnRigo", "TOR")
 	require.NoError(t, err)
@@ -200,7 +201,7 @@ func Test_callEVM_Transfer(t *testing.T) {
 	xerr = erc20EVM.Close()
 	require.NoError(t, xerr)
 
-	erc20EVM = NewEVMCtrler(dbPath, &acctHandler, tmlog.NewNopLogger())
+	erc20EVM = NewEVMCtrler(dbPath, &acctHandler, ledger.PruningOption{}, tmlog.NewNopLogger())
 
 	state, xerr = erc20EVM.ImmutableStateAt(erc20EVM.lastBlockHeight)
 	require.NoError(t, xerr)
@@ -8,6 +8,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/holiman/uint256"
 	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/ledger"
 	"github.com/rigochain/rigo-go/libs/web3"
 	"github.com/rigochain/rigo-go/types"
 	bytes2 "github.com/rigochain/rigo-go/types/bytes"
@@ -41,7 +42,7 @@ func init() {
 
 func Test_Fallback(t *testing.T) {
 	os.RemoveAll(dbPath)
-	fallbackEVM = NewEVMCtrler(dbPath, &acctHandler, tmlog.NewTMLogger(tmlog.NewSyncWriter(os.Stdout)))
+	fallbackEVM = NewEVMCtrler(dbPath, &acctHandler, ledger.PruningOption{}, tmlog.NewTMLogger(tmlog.NewSyncWriter(os.Stdout)))
 
 	//
 	// deploy
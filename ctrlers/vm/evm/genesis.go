@@ -0,0 +1,140 @@
+package evm
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/rigochain/rigo-go/genesis"
+	"github.com/rigochain/rigo-go/types/bytes"
+	"github.com/rigochain/rigo-go/types/xerrors"
+)
+
+// ExportGenesis puts the code and the storage of the contracts in `appState.Accounts` at `height` into `appState`.
+// The preimages of the storage slots are not recorded,
+// so the storage is exported as it is stored in the state trie, keyed by the hashes of the slots.
+func (ctrler *EVMCtrler) ExportGenesis(height int64, appState *genesis.GenesisAppState) xerrors.XError {
+	ctrler.mtx.RLock()
+	defer ctrler.mtx.RUnlock()
+
+	hash, err := ctrler.metadb.Get(blockKey(height))
+	if err != nil {
+		return xerrors.From(err)
+	} else if hash == nil {
+		return xerrors.ErrNotFoundResult.Wrapf("not found the state root of height %v", height)
+	}
+	stateDB, err := state.New(bytes.HexBytes(hash).Array32(), state.NewDatabase(ctrler.ethDB), nil)
+	if err != nil {
+		return xerrors.From(err)
+	}
+
+	for _, acct := range appState.Accounts {
+		if acct.Code == nil {
+			continue
+		}
+
+		addr := acct.Address.Array20()
+		contract := &genesis.GenesisContract{
+			Address: acct.Address,
+			Code:    stateDB.GetCode(addr),
+		}
+		if tr := stateDB.StorageTrie(addr); tr != nil {
+			it := trie.NewIterator(tr.NodeIterator(nil))
+			for it.Next() {
+				_, val, _, err := rlp.Split(it.Value)
+				if err != nil {
+					return xerrors.From(err)
+				}
+				contract.Storage = append(contract.Storage, &genesis.GenesisStorageSlot{
+					Key:   common.CopyBytes(it.Key),
+					Value: common.CopyBytes(val),
+				})
+			}
+			if it.Err != nil {
+				return xerrors.From(it.Err)
+			}
+		}
+		appState.Contracts = append(appState.Contracts, contract)
+	}
+	return nil
+}
+
+// importGenesis builds the state trie of the contracts in `genAppState` and returns its root.
+// The storage tries are built directly, because their keys are already hashed.
+func (ctrler *EVMCtrler) importGenesis(genAppState *genesis.GenesisAppState) ([]byte, xerrors.XError) {
+	trieDB := state.NewDatabase(ctrler.ethDB).TrieDB()
+
+	acctTrie, err := trie.NewStateTrie(common.Hash{}, common.Hash{}, trieDB)
+	if err != nil {
+		return nil, xerrors.From(err)
+	}
+
+	for _, contract := range genAppState.Contracts {
+		addr := contract.Address.Array20()
+
+		storageTrie, err := trie.New(crypto.Keccak256Hash(addr[:]), common.Hash{}, trieDB)
+		if err != nil {
+			return nil, xerrors.From(err)
+		}
+		for _, slot := range contract.Storage {
+			val, err := rlp.EncodeToBytes([]byte(slot.Value))
+			if err != nil {
+				return nil, xerrors.From(err)
+			}
+			if err := storageTrie.TryUpdate(slot.Key, val); err != nil {
+				return nil, xerrors.From(err)
+			}
+		}
+		storageRoot, xerr := commitTrie(trieDB, storageTrie)
+		if xerr != nil {
+			return nil, xerr
+		}
+
+		codeHash := crypto.Keccak256Hash(contract.Code)
+		rawdb.WriteCode(ctrler.ethDB, codeHash, contract.Code)
+
+		// the nonce and the balance are synchronized with the account ledger whenever the contract is accessed.
+		stateAcct := &ethtypes.StateAccount{
+			Balance:  common.Big0,
+			Root:     storageRoot,
+			CodeHash: codeHash[:],
+		}
+		for _, acct := range genAppState.Accounts {
+			if acct.Address.Compare(contract.Address) == 0 {
+				stateAcct.Nonce = acct.Nonce
+				stateAcct.Balance = acct.Balance.ToBig()
+				break
+			}
+		}
+		if err := acctTrie.TryUpdateAccount(addr[:], stateAcct); err != nil {
+			return nil, xerrors.From(err)
+		}
+	}
+
+	rootHash, xerr := commitTrie(trieDB, acctTrie)
+	if xerr != nil {
+		return nil, xerr
+	}
+	return rootHash[:], nil
+}
+
+func commitTrie(trieDB *trie.Database, tr interface {
+	Commit(bool) (common.Hash, *trie.NodeSet, error)
+}) (common.Hash, xerrors.XError) {
+	root, nodes, err := tr.Commit(false)
+	if err != nil {
+		return common.Hash{}, xerrors.From(err)
+	}
+	if nodes != nil {
+		if err := trieDB.Update(trie.NewWithNodeSet(nodes)); err != nil {
+			return common.Hash{}, xerrors.From(err)
+		}
+		if err := trieDB.Commit(root, false, nil); err != nil {
+			return common.Hash{}, xerrors.From(err)
+		}
+	}
+	return root, nil
+}
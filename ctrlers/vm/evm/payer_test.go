@@ -0,0 +1,70 @@
+package evm
+
+import (
+	"github.com/holiman/uint256"
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/ledger"
+	"github.com/rigochain/rigo-go/libs/web3"
+	"github.com/rigochain/rigo-go/types"
+	bytes2 "github.com/rigochain/rigo-go/types/bytes"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestExecuteTrx_Payer(t *testing.T) {
+	payerDBPath := filepath.Join(os.TempDir(), "rigo-evm-payer-test")
+	require.NoError(t, os.RemoveAll(payerDBPath))
+	defer func() { _ = os.RemoveAll(payerDBPath) }()
+
+	evmCtrler := NewEVMCtrler(payerDBPath, &acctHandler, ledger.PruningOption{}, tmlog.NewNopLogger())
+	defer func() { _ = evmCtrler.Close() }()
+
+	payerWallet := web3.NewWallet(nil)
+	payerBalance := uint256.MustFromDecimal("1000000000000000000000")
+	require.NoError(t, payerWallet.GetAccount().AddBalance(payerBalance))
+	acctHandler.walletsMap[payerWallet.Address().String()] = payerWallet
+	defer delete(acctHandler.walletsMap, payerWallet.Address().String())
+
+	// the sender has no balance.
+	sender := acctHandler.FindOrNewAccount(types.RandAddress(), true)
+
+	deployInput, err := abiERC20Contract.Pack("", "TokenOnRigo", "TOR")
+	require.NoError(t, err)
+	deployInput = append(erc20BuildInfo.Bytecode, deployInput...)
+
+	bctx := ctrlertypes.NewBlockContext(abcitypes.RequestBeginBlock{Header: tmproto.Header{Height: evmCtrler.lastBlockHeight + 1}}, nil, &acctHandler, nil)
+	_, xerr := evmCtrler.BeginBlock(bctx)
+	require.NoError(t, xerr)
+
+	gasPrice := uint256.NewInt(10_000_000_000)
+	tx := web3.NewTrxContract(sender.Address, types.ZeroAddress(), 0, 3_000_000, gasPrice, uint256.NewInt(0), deployInput)
+	tx = web3.SetTrxPayer(tx, payerWallet.Address())
+	txctx := &ctrlertypes.TrxContext{
+		Height:      bctx.Height(),
+		BlockTime:   time.Now().Unix(),
+		TxHash:      bytes2.RandBytes(32),
+		Tx:          tx,
+		TxIdx:       1,
+		Exec:        true,
+		Sender:      sender,
+		Payer:       payerWallet.GetAccount(),
+		GovHandler:  govParams,
+		AcctHandler: &acctHandler,
+	}
+	require.NoError(t, evmCtrler.ExecuteTrx(txctx))
+	require.Greater(t, txctx.GasUsed, uint64(0))
+	require.Less(t, txctx.GasUsed, tx.Gas)
+
+	// only the gas used is charged to the payer.
+	fee := new(uint256.Int).Mul(gasPrice, uint256.NewInt(txctx.GasUsed))
+	require.Equal(t, new(uint256.Int).Sub(payerBalance, fee), payerWallet.GetAccount().Balance)
+	require.EqualValues(t, 0, payerWallet.GetAccount().GetNonce())
+	require.True(t, sender.Balance.IsZero())
+	require.EqualValues(t, 1, sender.GetNonce())
+}
@@ -0,0 +1,260 @@
+package evm
+
+import (
+	"bytes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	tmdb "github.com/tendermint/tm-db"
+	"strconv"
+	"sync"
+)
+
+var (
+	emptyStateRoot = common.HexToHash("56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421")
+)
+
+// pruner runs the pruning of the EVM state in background, so that Commit is not delayed by it.
+// The trie nodes are shared by the states of many heights and `ethDB` has no reference counts of them,
+// so the nodes reachable from the kept state roots are marked at first, and then the other nodes are swept.
+type pruner struct {
+	// the latest height requested to prune at while pruning. 0 if none.
+	latest  int64
+	running bool
+	wg      sync.WaitGroup
+	mtx     sync.Mutex
+
+	// exportMtx is held for reading by the snapshot exporters, so that the states being exported are not deleted.
+	exportMtx sync.RWMutex
+
+	// commitMtx orders the trie nodes written by Commit and the deletions of the sweep.
+	// The nodes written while sweeping are in `committed`, and they are not deleted
+	// even if they were not reachable when marking. `committed` is nil if not sweeping.
+	commitMtx sync.Mutex
+	committed map[common.Hash]struct{}
+}
+
+// onCommitted is called for the trie node written by Commit. `commitMtx` should be held.
+func (p *pruner) onCommitted(hash common.Hash) {
+	if p.committed != nil {
+		p.committed[hash] = struct{}{}
+	}
+}
+
+// wait blocks until the pruning in background is finished.
+func (p *pruner) wait() {
+	p.wg.Wait()
+}
+
+// requestPrune starts the pruning at `latest` in background.
+// If a pruning is already running, it is pruned again at the latest height requested when the running one is finished.
+func (ctrler *EVMCtrler) requestPrune(latest int64) {
+	if !ctrler.pruning.ShouldPrune(latest) {
+		return
+	}
+
+	p := &ctrler.pruner
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.latest = latest
+	if p.running {
+		return
+	}
+	p.running = true
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		for {
+			p.mtx.Lock()
+			h := p.latest
+			p.latest = 0
+			if h == 0 {
+				p.running = false
+				p.mtx.Unlock()
+				return
+			}
+			p.mtx.Unlock()
+
+			if xerr := ctrler.prune(h); xerr != nil {
+				ctrler.logger.Error("fail to prune the EVM state", "height", h, "error", xerr)
+			}
+		}
+	}()
+}
+
+// prune deletes the state roots of the heights pruned by `ctrler.pruning` and the trie nodes only used by them.
+// It does not use `ctrler.mtx`, so it can run while blocks are executed and committed.
+func (ctrler *EVMCtrler) prune(latest int64) xerrors.XError {
+	p := &ctrler.pruner
+	p.exportMtx.Lock()
+	defer p.exportMtx.Unlock()
+
+	// the nodes committed from now are recorded,
+	// because the state roots committed from now may not be found below.
+	p.commitMtx.Lock()
+	p.committed = make(map[common.Hash]struct{})
+	p.commitMtx.Unlock()
+	defer func() {
+		p.commitMtx.Lock()
+		p.committed = nil
+		p.commitMtx.Unlock()
+	}()
+
+	var prunedKeys [][]byte
+	var keptRoots []common.Hash
+	itr, err := tmdb.IteratePrefix(ctrler.metadb, blockKeyPrefix)
+	if err != nil {
+		return xerrors.From(err)
+	}
+	for ; itr.Valid(); itr.Next() {
+		h, err := strconv.ParseInt(string(itr.Key()[len(blockKeyPrefix):]), 10, 64)
+		if err != nil {
+			_ = itr.Close()
+			return xerrors.From(err)
+		}
+		if ctrler.pruning.IsPruned(h, latest) {
+			prunedKeys = append(prunedKeys, append([]byte(nil), itr.Key()...))
+		} else {
+			keptRoots = append(keptRoots, common.BytesToHash(itr.Value()))
+		}
+	}
+	err = itr.Error()
+	_ = itr.Close()
+	if err != nil {
+		return xerrors.From(err)
+	}
+	if len(prunedKeys) == 0 {
+		return nil
+	}
+
+	batch := ctrler.metadb.NewBatch()
+	defer batch.Close()
+	for _, k := range prunedKeys {
+		if err := batch.Delete(k); err != nil {
+			return xerrors.From(err)
+		}
+	}
+	if err := batch.WriteSync(); err != nil {
+		return xerrors.From(err)
+	}
+
+	reachable, err := markTrieNodes(ctrler.ethDB, keptRoots)
+	if err != nil {
+		return xerrors.From(err)
+	}
+	if err := sweepTrieNodes(ctrler.ethDB, reachable, p); err != nil {
+		return xerrors.From(err)
+	}
+	return nil
+}
+
+// markTrieNodes returns the hashes of all trie nodes reachable from `roots`, including the nodes of the storage tries.
+func markTrieNodes(db ethdb.Database, roots []common.Hash) (map[common.Hash]struct{}, error) {
+	triedb := trie.NewDatabase(db)
+	reachable := make(map[common.Hash]struct{})
+
+	for _, root := range roots {
+		var storageRoots []common.Hash
+		if err := markTrie(triedb, common.Hash{}, root, reachable, func(it trie.NodeIterator) error {
+			var acct types.StateAccount
+			if err := rlp.Decode(bytes.NewReader(it.LeafBlob()), &acct); err != nil {
+				return err
+			}
+			storageRoots = append(storageRoots, common.BytesToHash(it.LeafKey()), acct.Root)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+
+		for i := 0; i < len(storageRoots); i += 2 {
+			if err := markTrie(triedb, storageRoots[i], storageRoots[i+1], reachable, nil); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return reachable, nil
+}
+
+// markTrie adds the nodes of the trie `root` into `reachable`.
+// The sub-tries already in `reachable` are skipped, because their nodes were already marked.
+func markTrie(triedb *trie.Database, owner, root common.Hash, reachable map[common.Hash]struct{}, onLeaf func(trie.NodeIterator) error) error {
+	if root == emptyStateRoot || root == (common.Hash{}) {
+		return nil
+	}
+	if _, ok := reachable[root]; ok {
+		return nil
+	}
+
+	tr, err := trie.New(owner, root, triedb)
+	if err != nil {
+		return err
+	}
+	it := tr.NodeIterator(nil)
+	for descend := true; it.Next(descend); {
+		descend = true
+		if hash := it.Hash(); hash != (common.Hash{}) {
+			if _, ok := reachable[hash]; ok {
+				descend = false
+				continue
+			}
+			reachable[hash] = struct{}{}
+		}
+		if it.Leaf() && onLeaf != nil {
+			if err := onLeaf(it); err != nil {
+				return err
+			}
+		}
+	}
+	return it.Error()
+}
+
+// sweepTrieNodes deletes the trie nodes not in `reachable` and not committed while sweeping.
+// The trie nodes are stored with their hashes as keys, and the contract codes are never deleted.
+func sweepTrieNodes(db ethdb.Database, reachable map[common.Hash]struct{}, p *pruner) error {
+	it := db.NewIterator(nil, nil)
+	defer it.Release()
+
+	var keys []common.Hash
+	size := 0
+	for it.Next() {
+		key := it.Key()
+		if len(key) != common.HashLength {
+			continue
+		}
+		if _, ok := reachable[common.BytesToHash(key)]; ok {
+			continue
+		}
+		keys = append(keys, common.BytesToHash(key))
+		size += len(key)
+		if size >= ethdb.IdealBatchSize {
+			if err := deleteTrieNodes(db, keys, p); err != nil {
+				return err
+			}
+			keys, size = keys[:0], 0
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return deleteTrieNodes(db, keys, p)
+}
+
+func deleteTrieNodes(db ethdb.Database, keys []common.Hash, p *pruner) error {
+	p.commitMtx.Lock()
+	defer p.commitMtx.Unlock()
+
+	batch := db.NewBatch()
+	for _, k := range keys {
+		if _, ok := p.committed[k]; ok {
+			continue
+		}
+		if err := batch.Delete(k.Bytes()); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
@@ -0,0 +1,103 @@
+package evm
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/holiman/uint256"
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/ledger"
+	"github.com/rigochain/rigo-go/libs/web3"
+	"github.com/rigochain/rigo-go/types"
+	bytes2 "github.com/rigochain/rigo-go/types/bytes"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func Test_Pruning(t *testing.T) {
+	path := filepath.Join(os.TempDir(), "rigo-evm-pruning-test")
+	require.NoError(t, os.RemoveAll(path))
+	defer os.RemoveAll(path)
+
+	pruning := ledger.PruningOption{KeepRecent: 2, Interval: 1}
+	ctrler := NewEVMCtrler(path, &acctHandler, pruning, tmlog.NewNopLogger())
+	defer ctrler.Close()
+
+	fromAcct := acctHandler.walletsArr[0].GetAccount()
+	toAcct := acctHandler.walletsArr[1].GetAccount()
+
+	// the storage of the contract is changed at every height.
+	deployInput, err := abiERC20Contract.Pack("", "TokenOnRigo", "TOR")
+	require.NoError(t, err)
+	txctx := execPruningTestTrx(t, ctrler,
+		web3.NewTrxContract(fromAcct.Address, types.ZeroAddress(), fromAcct.GetNonce(), 3_000_000, uint256.NewInt(10_000_000_000), uint256.NewInt(0), bytes2.HexBytes(append(erc20BuildInfo.Bytecode, deployInput...))),
+		fromAcct, nil)
+	var contAddr types.Address
+	for _, evt := range txctx.Events {
+		if evt.Type == "evm" {
+			contAddr, err = types.HexToAddress(string(evt.Attributes[0].Value))
+			require.NoError(t, err)
+		}
+	}
+	contAcct := acctHandler.FindAccount(contAddr, true)
+	require.NotNil(t, contAcct)
+
+	roots := map[int64][]byte{1: ctrler.lastRootHash}
+	for h := int64(2); h <= 6; h++ {
+		input, err := abiERC20Contract.Pack("transfer", toAddrArr(toAcct.Address), toWei(h))
+		require.NoError(t, err)
+		execPruningTestTrx(t, ctrler,
+			web3.NewTrxContract(fromAcct.Address, contAddr, fromAcct.GetNonce(), 3_000_000, uint256.NewInt(10_000_000_000), uint256.NewInt(0), input),
+			fromAcct, contAcct)
+		roots[h] = ctrler.lastRootHash
+	}
+	ctrler.pruner.wait()
+
+	for h := int64(1); h <= 6; h++ {
+		_, xerr := ctrler.ImmutableStateAt(h)
+		if pruning.IsPruned(h, 6) {
+			require.Error(t, xerr, "height", h)
+			require.Equal(t, xerrors.ErrCodePrunedHeight, xerr.Code(), "height", h)
+			require.Empty(t, rawdb.ReadTrieNode(ctrler.ethDB, common.BytesToHash(roots[h])), "height", h)
+			continue
+		}
+		require.NoError(t, xerr, "height", h)
+
+		// all trie nodes of the kept states are not deleted.
+		exporter, xerr := ctrler.Snapshot(h)
+		require.NoError(t, xerr)
+		require.NoError(t, exporter(func(*ctrlertypes.SnapshotItemProto) xerrors.XError { return nil }), "height", h)
+	}
+}
+
+func execPruningTestTrx(t *testing.T, ctrler *EVMCtrler, tx *ctrlertypes.Trx, sender, receiver *ctrlertypes.Account) *ctrlertypes.TrxContext {
+	bctx := ctrlertypes.NewBlockContext(abcitypes.RequestBeginBlock{Header: tmproto.Header{Height: ctrler.lastBlockHeight + 1}}, nil, &acctHandler, nil)
+	_, xerr := ctrler.BeginBlock(bctx)
+	require.NoError(t, xerr)
+
+	txctx := &ctrlertypes.TrxContext{
+		Height:      bctx.Height(),
+		BlockTime:   time.Now().Unix(),
+		TxHash:      bytes2.RandBytes(32),
+		Tx:          tx,
+		TxIdx:       1,
+		Exec:        true,
+		Sender:      sender,
+		Receiver:    receiver,
+		GovHandler:  govParams,
+		AcctHandler: &acctHandler,
+	}
+	require.NoError(t, ctrler.ExecuteTrx(txctx))
+
+	_, xerr = ctrler.EndBlock(bctx)
+	require.NoError(t, xerr)
+	_, _, xerr = ctrler.Commit()
+	require.NoError(t, xerr)
+	return txctx
+}
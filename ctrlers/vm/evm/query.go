@@ -83,7 +83,7 @@ func (ctrler *EVMCtrler) callVM(from, to types.Address, data []byte, height, blo
 	}
 
 	vmmsg := evmMessage(sender, toAddr, 0, gasLimit, uint256.NewInt(0), uint256.NewInt(0), data, true)
-	blockContext := evmBlockContext(sender, height, blockTime)
+	blockContext := evmBlockContext(sender, height, blockTime, nil)
 
 	txContext := core.NewEVMTxContext(vmmsg)
 	vmevm := vm.NewEVM(blockContext, txContext, state, ctrler.ethChainConfig, vm.Config{NoBaseFee: true})
@@ -0,0 +1,125 @@
+package evm
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/types/bytes"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	"strconv"
+)
+
+const (
+	snapshotStoreTrieNodes = "evm_trie"
+	snapshotStoreCodes     = "evm_code"
+	snapshotStoreRootHash  = "evm_root"
+)
+
+func (ctrler *EVMCtrler) Snapshot(height int64) (ctrlertypes.SnapshotExporter, xerrors.XError) {
+	ctrler.mtx.RLock()
+	defer ctrler.mtx.RUnlock()
+
+	hash, err := ctrler.metadb.Get(blockKey(height))
+	if err != nil {
+		return nil, xerrors.ErrSnapshot.Wrap(err)
+	} else if hash == nil {
+		return nil, xerrors.ErrSnapshot.Wrapf("not found the state root of height %v", height)
+	}
+	ethDB, metadb := ctrler.ethDB, ctrler.metadb
+	p := &ctrler.pruner
+
+	return func(cb func(*ctrlertypes.SnapshotItemProto) xerrors.XError) xerrors.XError {
+		// the pruning in background waits until the state is exported.
+		p.exportMtx.RLock()
+		defer p.exportMtx.RUnlock()
+		if hash0, err := metadb.Get(blockKey(height)); err != nil {
+			return xerrors.ErrSnapshot.Wrap(err)
+		} else if hash0 == nil {
+			return xerrors.ErrSnapshot.Wrapf("the state of height %v has been pruned", height)
+		}
+
+		stateDB, err := state.New(bytes.HexBytes(hash).Array32(), state.NewDatabase(ethDB), nil)
+		if err != nil {
+			return xerrors.ErrSnapshot.Wrap(err)
+		}
+
+		// the iterator traverses the account trie, all storage tries and all contract codes.
+		it := state.NewNodeIterator(stateDB)
+		for it.Next() {
+			if it.Hash == (common.Hash{}) {
+				// the node is embedded in its parent
+				continue
+			}
+
+			item := &ctrlertypes.SnapshotItemProto{Key: it.Hash.Bytes()}
+			if blob := rawdb.ReadTrieNode(ethDB, it.Hash); len(blob) > 0 {
+				item.Store, item.Value = snapshotStoreTrieNodes, blob
+			} else if code := rawdb.ReadCode(ethDB, it.Hash); len(code) > 0 {
+				item.Store, item.Value = snapshotStoreCodes, code
+			} else {
+				return xerrors.ErrSnapshot.Wrapf("not found the state entry: %x", it.Hash)
+			}
+			if xerr := cb(item); xerr != nil {
+				return xerr
+			}
+		}
+		if it.Error != nil {
+			return xerrors.ErrSnapshot.Wrap(it.Error)
+		}
+
+		return cb(&ctrlertypes.SnapshotItemProto{
+			Store: snapshotStoreRootHash,
+			Key:   blockKey(height),
+			Value: hash,
+		})
+	}, nil
+}
+
+func (ctrler *EVMCtrler) RestoreSnapshot(height int64, items ctrlertypes.SnapshotItems) ([]byte, xerrors.XError) {
+	ctrler.mtx.Lock()
+	defer ctrler.mtx.Unlock()
+
+	if len(items[snapshotStoreRootHash]) != 1 {
+		return nil, xerrors.ErrSnapshot.Wrapf("wrong state root of evm")
+	}
+	rootHash := items[snapshotStoreRootHash][0].Value
+
+	batch := ctrler.ethDB.NewBatch()
+	for _, item := range items[snapshotStoreTrieNodes] {
+		rawdb.WriteTrieNode(batch, common.BytesToHash(item.Key), item.Value)
+	}
+	for _, item := range items[snapshotStoreCodes] {
+		rawdb.WriteCode(batch, common.BytesToHash(item.Key), item.Value)
+	}
+	if err := batch.Write(); err != nil {
+		return nil, xerrors.ErrSnapshot.Wrap(err)
+	}
+
+	// check that the state of `rootHash` is completely restored.
+	stateDB, err := state.New(bytes.HexBytes(rootHash).Array32(), state.NewDatabase(ctrler.ethDB), nil)
+	if err != nil {
+		return nil, xerrors.ErrSnapshot.Wrap(err)
+	}
+	it := state.NewNodeIterator(stateDB)
+	for it.Next() {
+	}
+	if it.Error != nil {
+		return nil, xerrors.ErrSnapshot.Wrap(it.Error)
+	}
+
+	metaBatch := ctrler.metadb.NewBatch()
+	defer metaBatch.Close()
+	_ = metaBatch.Set(lastBlockHeightKey, []byte(strconv.FormatInt(height, 10)))
+	_ = metaBatch.Set(blockKey(height), rootHash)
+	if err := metaBatch.WriteSync(); err != nil {
+		return nil, xerrors.ErrSnapshot.Wrap(err)
+	}
+
+	ctrler.lastBlockHeight = height
+	ctrler.lastRootHash = rootHash
+
+	return rootHash, nil
+}
+
+var _ ctrlertypes.ISnapshotHandler = (*EVMCtrler)(nil)
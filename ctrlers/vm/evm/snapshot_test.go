@@ -0,0 +1,60 @@
+package evm
+
+import (
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/ledger"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	"github.com/stretchr/testify/require"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_Snapshot(t *testing.T) {
+	// `fallbackEVM` has the contract deployed by Test_Fallback
+	require.NotNil(t, fallbackEVM)
+	height := fallbackEVM.lastBlockHeight
+
+	exporter, xerr := fallbackEVM.Snapshot(height)
+	require.NoError(t, xerr)
+
+	items := make(ctrlertypes.SnapshotItems)
+	require.NoError(t, exporter(func(item *ctrlertypes.SnapshotItemProto) xerrors.XError {
+		items.Add(item)
+		return nil
+	}))
+	require.NotEmpty(t, items[snapshotStoreTrieNodes])
+	require.Len(t, items[snapshotStoreCodes], 1)
+	require.Equal(t, []byte(buildInfoFallbackContract.DeployedBytecode), items[snapshotStoreCodes][0].Value)
+	require.Len(t, items[snapshotStoreRootHash], 1)
+
+	restoredPath := filepath.Join(os.TempDir(), "rigo-evm-snapshot-test")
+	require.NoError(t, os.RemoveAll(restoredPath))
+	defer os.RemoveAll(restoredPath)
+
+	restoredEVM := NewEVMCtrler(restoredPath, &acctHandler, ledger.PruningOption{}, tmlog.NewNopLogger())
+	rootHash, xerr := restoredEVM.RestoreSnapshot(height, items)
+	require.NoError(t, xerr)
+	require.EqualValues(t, fallbackEVM.lastRootHash, rootHash)
+	require.Equal(t, height, restoredEVM.lastBlockHeight)
+	require.NoError(t, restoredEVM.Close())
+
+	// the restored state is loaded when reopened.
+	restoredEVM = NewEVMCtrler(restoredPath, &acctHandler, ledger.PruningOption{}, tmlog.NewNopLogger())
+	require.Equal(t, height, restoredEVM.lastBlockHeight)
+	require.EqualValues(t, rootHash, restoredEVM.lastRootHash)
+
+	state, xerr := restoredEVM.ImmutableStateAt(height)
+	require.NoError(t, xerr)
+	require.EqualValues(t, rootHash, state.IntermediateRoot(true).Bytes())
+	require.NoError(t, restoredEVM.Close())
+
+	// a missing trie node is detected.
+	items[snapshotStoreTrieNodes] = items[snapshotStoreTrieNodes][1:]
+	require.NoError(t, os.RemoveAll(restoredPath))
+	restoredEVM = NewEVMCtrler(restoredPath, &acctHandler, ledger.PruningOption{}, tmlog.NewNopLogger())
+	_, xerr = restoredEVM.RestoreSnapshot(height, items)
+	require.Error(t, xerr)
+	require.NoError(t, restoredEVM.Close())
+}
@@ -10,10 +10,14 @@ import (
 )
 
 func NewGenesisDoc(chainID string, validators []tmtypes.GenesisValidator, assetHolders []*GenesisAssetHolder, govParams *types2.GovParams) (*tmtypes.GenesisDoc, error) {
-	appState := GenesisAppState{
+	return NewGenesisDocWithAppState(chainID, validators, &GenesisAppState{
 		AssetHolders: assetHolders,
 		GovParams:    govParams,
-	}
+	})
+}
+
+// NewGenesisDocWithAppState returns the genesis document which has `appState` as the initial state of the application.
+func NewGenesisDocWithAppState(chainID string, validators []tmtypes.GenesisValidator, appState *GenesisAppState) (*tmtypes.GenesisDoc, error) {
 	appStateJsonBlob, err := tmjson.Marshal(appState)
 	if err != nil {
 		return nil, err
@@ -1,13 +1,55 @@
 package genesis
 
 import (
+	"github.com/rigochain/rigo-go/ctrlers/gov/proposal"
+	"github.com/rigochain/rigo-go/ctrlers/stake"
 	types2 "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/types"
+	"github.com/rigochain/rigo-go/types/bytes"
 	"github.com/rigochain/rigo-go/types/crypto"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	"hash"
 )
 
+// GenesisAppState is the initial state of the application.
+// A new network has only `AssetHolders` and `GovParams`.
+// The other fields are filled when the state of a running network is exported by `rigo export`,
+// and all of them are imported at InitChain.
 type GenesisAppState struct {
 	AssetHolders []*GenesisAssetHolder `json:"assetHolders"`
 	GovParams    *types2.GovParams     `json:"govParams"`
+
+	Accounts        []*types2.Account       `json:"accounts,omitempty"`
+	Delegatees      []*stake.Delegatee      `json:"delegatees,omitempty"`
+	FrozenStakes    []*stake.Stake          `json:"frozenStakes,omitempty"`
+	Rewards         []*stake.Reward         `json:"rewards,omitempty"`
+	Proposals       []*proposal.GovProposal `json:"proposals,omitempty"`
+	FrozenProposals []*proposal.GovProposal `json:"frozenProposals,omitempty"`
+	Contracts       []*GenesisContract      `json:"contracts,omitempty"`
+}
+
+// GenesisContract has the code and the storage of a contract.
+// The keys of `Storage` are the hashes of the storage slots, as they are stored in the state trie of EVM.
+type GenesisContract struct {
+	Address types.Address         `json:"address"`
+	Code    bytes.HexBytes        `json:"code"`
+	Storage []*GenesisStorageSlot `json:"storage,omitempty"`
+}
+
+type GenesisStorageSlot struct {
+	Key   bytes.HexBytes `json:"key"`
+	Value bytes.HexBytes `json:"value"`
+}
+
+func (gc *GenesisContract) Hash() []byte {
+	hasher := crypto.DefaultHasher()
+	hasher.Write(gc.Address)
+	hasher.Write(gc.Code)
+	for _, slot := range gc.Storage {
+		hasher.Write(slot.Key)
+		hasher.Write(slot.Value)
+	}
+	return hasher.Sum(nil)
 }
 
 func (ga *GenesisAppState) Hash() ([]byte, error) {
@@ -23,5 +65,50 @@ func (ga *GenesisAppState) Hash() ([]byte, error) {
 			}
 		}
 	}
+
+	for _, acct := range ga.Accounts {
+		if err := writeEncoded(hasher, acct.Encode); err != nil {
+			return nil, err
+		}
+	}
+	for _, d := range ga.Delegatees {
+		if err := writeEncoded(hasher, d.Encode); err != nil {
+			return nil, err
+		}
+	}
+	for _, s := range ga.FrozenStakes {
+		if err := writeEncoded(hasher, s.Encode); err != nil {
+			return nil, err
+		}
+	}
+	for _, rwd := range ga.Rewards {
+		if err := writeEncoded(hasher, rwd.Encode); err != nil {
+			return nil, err
+		}
+	}
+	for _, prop := range ga.Proposals {
+		if err := writeEncoded(hasher, prop.Encode); err != nil {
+			return nil, err
+		}
+	}
+	for _, prop := range ga.FrozenProposals {
+		if err := writeEncoded(hasher, prop.Encode); err != nil {
+			return nil, err
+		}
+	}
+	for _, c := range ga.Contracts {
+		if _, err := hasher.Write(c.Hash()); err != nil {
+			return nil, err
+		}
+	}
 	return hasher.Sum(nil), nil
 }
+
+func writeEncoded(hasher hash.Hash, encode func() ([]byte, xerrors.XError)) error {
+	bz, xerr := encode()
+	if xerr != nil {
+		return xerr
+	}
+	_, err := hasher.Write(bz)
+	return err
+}
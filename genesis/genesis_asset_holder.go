@@ -3,22 +3,28 @@ package genesis
 import (
 	"encoding/json"
 	"github.com/holiman/uint256"
+	types2 "github.com/rigochain/rigo-go/ctrlers/types"
 	"github.com/rigochain/rigo-go/types"
 	"github.com/rigochain/rigo-go/types/crypto"
 )
 
+// GenesisAssetHolder is an account having `Balance` at genesis.
+// If `Vesting` is not nil, the holder is a vesting account and `Vesting.Amount` of `Balance` is locked on the schedule.
 type GenesisAssetHolder struct {
 	Address types.Address
 	Balance *uint256.Int
+	Vesting *types2.Vesting
 }
 
 func (gh *GenesisAssetHolder) MarshalJSON() ([]byte, error) {
 	tm := &struct {
-		Address types.Address `json:"address"`
-		Balance string        `json:"balance"`
+		Address types.Address   `json:"address"`
+		Balance string          `json:"balance"`
+		Vesting *types2.Vesting `json:"vesting,omitempty"`
 	}{
 		Address: gh.Address,
 		Balance: gh.Balance.Dec(),
+		Vesting: gh.Vesting,
 	}
 
 	return json.Marshal(tm)
@@ -26,8 +32,9 @@ func (gh *GenesisAssetHolder) MarshalJSON() ([]byte, error) {
 
 func (gh *GenesisAssetHolder) UnmarshalJSON(bz []byte) error {
 	tm := &struct {
-		Address types.Address `json:"address"`
-		Balance string        `json:"balance"`
+		Address types.Address   `json:"address"`
+		Balance string          `json:"balance"`
+		Vesting *types2.Vesting `json:"vesting,omitempty"`
 	}{}
 
 	if err := json.Unmarshal(bz, tm); err != nil {
@@ -41,6 +48,7 @@ func (gh *GenesisAssetHolder) UnmarshalJSON(bz []byte) error {
 
 	gh.Address = tm.Address
 	gh.Balance = bal
+	gh.Vesting = tm.Vesting
 
 	return nil
 }
@@ -49,6 +57,10 @@ func (gh *GenesisAssetHolder) Hash() []byte {
 	hasher := crypto.DefaultHasher()
 	hasher.Write(gh.Address[:])
 	hasher.Write(gh.Balance.Bytes())
+	if gh.Vesting != nil {
+		bz, _ := gh.Vesting.Encode()
+		hasher.Write(bz)
+	}
 	return hasher.Sum(nil)
 }
 
@@ -15,7 +15,7 @@ type FinalityLedger[T ILedgerItem] struct {
 	mtx sync.RWMutex
 }
 
-func NewFinalityLedger[T ILedgerItem](name, dbDir string, cacheSize int, cb func() T) (*FinalityLedger[T], xerrors.XError) {
+func NewFinalityLedger[T ILedgerItem](name, dbDir string, cacheSize int, pruning PruningOption, cb func() T) (*FinalityLedger[T], xerrors.XError) {
 	if db, err := tmdb.NewDB(name, "goleveldb", dbDir); err != nil {
 		return nil, xerrors.From(err)
 	} else if tree, err := iavl.NewMutableTreeWithOpts(db, cacheSize, nil /*&iavl.Options{Sync: true}*/); err != nil {
@@ -31,6 +31,8 @@ func NewFinalityLedger[T ILedgerItem](name, dbDir string, cacheSize int, cb func
 				tree:        tree,
 				cachedItems: newMemItems[T](),
 				getNewItem:  cb,
+				cacheSize:   cacheSize,
+				pruning:     pruning,
 			},
 			finalityItems: newMemItems[T](),
 		}, nil
@@ -190,6 +192,8 @@ func (ledger *FinalityLedger[T]) Commit() ([]byte, int64, xerrors.XError) {
 
 	if r1, r2, err := ledger.tree.SaveVersion(); err != nil {
 		return r1, r2, xerrors.From(err)
+	} else if xerr := ledger.SimpleLedger.prune(r2); xerr != nil {
+		return nil, -1, xerr
 	} else {
 		ledger.SimpleLedger.cachedItems.reset()
 		ledger.finalityItems.refresh()
@@ -197,4 +201,27 @@ func (ledger *FinalityLedger[T]) Commit() ([]byte, int64, xerrors.XError) {
 	}
 }
 
+func (ledger *FinalityLedger[T]) Import(ver int64, nodes []*iavl.ExportNode) ([]byte, xerrors.XError) {
+	ledger.mtx.Lock()
+	defer ledger.mtx.Unlock()
+
+	hash, xerr := ledger.SimpleLedger.Import(ver, nodes)
+	if xerr != nil {
+		return nil, xerr
+	}
+	ledger.finalityItems.reset()
+	return hash, nil
+}
+
+func (ledger *FinalityLedger[T]) Rollback(ver int64) xerrors.XError {
+	ledger.mtx.Lock()
+	defer ledger.mtx.Unlock()
+
+	if xerr := ledger.SimpleLedger.Rollback(ver); xerr != nil {
+		return xerr
+	}
+	ledger.finalityItems.reset()
+	return nil
+}
+
 var _ IFinalityLedger[ILedgerItem] = (*FinalityLedger[ILedgerItem])(nil)
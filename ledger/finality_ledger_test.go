@@ -2,6 +2,7 @@ package ledger
 
 import (
 	"github.com/rigochain/rigo-go/types/bytes"
+	"github.com/rigochain/rigo-go/types/xerrors"
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/libs/rand"
 	"os"
@@ -25,7 +26,7 @@ func resetLedger(t *testing.T) {
 	}
 
 	var err error
-	testLedger, err = NewFinalityLedger[*MyItem]("treeLedger1", dbDir, 256, func() *MyItem { return &MyItem{} })
+	testLedger, err = NewFinalityLedger[*MyItem]("treeLedger1", dbDir, 256, PruningOption{}, func() *MyItem { return &MyItem{} })
 	require.NoError(t, err)
 
 	testItem0 = NewMyItem(bytes.RandHexString(32), rand.Int32())
@@ -189,3 +190,53 @@ func TestFinalityLedger_SetAfterDelFinality(t *testing.T) {
 
 	require.NoError(t, testLedger.Close())
 }
+
+func TestFinalityLedger_Rollback(t *testing.T) {
+	ledger := newSnapshotTestLedger(t, "rollback")
+
+	var items []*MyItem
+	var hashes [][]byte
+	for i := 0; i < 3; i++ {
+		item := NewMyItem(bytes.RandHexString(32), rand.Int32())
+		require.NoError(t, ledger.SetFinality(item))
+		hash, ver, xerr := ledger.Commit()
+		require.NoError(t, xerr)
+		require.EqualValues(t, i+1, ver)
+		items = append(items, item)
+		hashes = append(hashes, hash)
+	}
+
+	hash, xerr := ledger.RootHashAt(2)
+	require.NoError(t, xerr)
+	require.Equal(t, hashes[1], hash)
+
+	// can not rollback to the future.
+	require.Error(t, ledger.Rollback(4))
+
+	// the uncommitted items are discarded too.
+	require.NoError(t, ledger.SetFinality(NewMyItem(bytes.RandHexString(32), rand.Int32())))
+	require.NoError(t, ledger.Rollback(2))
+	require.EqualValues(t, 2, ledger.Version())
+	_, xerr = ledger.Read(items[2].Key())
+	require.Equal(t, xerrors.ErrNotFoundResult, xerr)
+	_, xerr = ledger.Read(items[1].Key())
+	require.NoError(t, xerr)
+
+	// the version 3 is recreated with the same hash.
+	require.NoError(t, ledger.SetFinality(items[2]))
+	hash, ver, xerr := ledger.Commit()
+	require.NoError(t, xerr)
+	require.EqualValues(t, 3, ver)
+	require.Equal(t, hashes[2], hash)
+
+	require.NoError(t, ledger.Rollback(0))
+	require.EqualValues(t, 0, ledger.Version())
+	_, xerr = ledger.Read(items[0].Key())
+	require.Equal(t, xerrors.ErrNotFoundResult, xerr)
+
+	require.NoError(t, ledger.SetFinality(items[0]))
+	hash, ver, xerr = ledger.Commit()
+	require.NoError(t, xerr)
+	require.EqualValues(t, 1, ver)
+	require.Equal(t, hashes[0], hash)
+}
@@ -0,0 +1,67 @@
+package ledger
+
+import (
+	"github.com/cosmos/iavl"
+	"github.com/rigochain/rigo-go/types/bytes"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/libs/rand"
+	tmcrypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFinalityLedger_ProveAt(t *testing.T) {
+	dbDir := filepath.Join(os.TempDir(), "test-prove")
+	require.NoError(t, os.RemoveAll(dbDir))
+	defer os.RemoveAll(dbDir)
+
+	ledger, xerr := NewFinalityLedger[*MyItem]("prove", dbDir, 128, PruningOption{}, func() *MyItem { return &MyItem{} })
+	require.NoError(t, xerr)
+	defer ledger.Close()
+
+	// the empty ledger has no proof.
+	_, _, xerr = ledger.Commit()
+	require.NoError(t, xerr)
+	_, _, xerr = ledger.ProveAt(1, ToLedgerKey([]byte("none")))
+	require.Error(t, xerr)
+
+	var items []*MyItem
+	for i := 0; i < 5; i++ {
+		item := NewMyItem(bytes.RandHexString(32), rand.Int32())
+		require.NoError(t, ledger.SetFinality(item))
+		_, _, xerr := ledger.Commit()
+		require.NoError(t, xerr)
+		items = append(items, item)
+	}
+
+	prt := merkle.NewProofRuntime()
+	prt.RegisterOpDecoder(iavl.ProofOpIAVLValue, iavl.ValueOpDecoder)
+	prt.RegisterOpDecoder(iavl.ProofOpIAVLAbsence, iavl.AbsenceOpDecoder)
+
+	for i, item := range items {
+		ver := int64(i + 2)
+		root, xerr := ledger.RootHashAt(ver)
+		require.NoError(t, xerr)
+
+		key := item.Key()
+		keyPath := merkle.KeyPath{}.AppendKey(key[:], merkle.KeyEncodingHex).String()
+		bz, op, xerr := ledger.ProveAt(ver, key)
+		require.NoError(t, xerr)
+		expected, xerr := item.Encode()
+		require.NoError(t, xerr)
+		require.Equal(t, expected, bz)
+		require.NoError(t, prt.VerifyValue(&tmcrypto.ProofOps{Ops: []tmcrypto.ProofOp{*op}}, root, keyPath, bz), "version", ver)
+
+		// the item added at the next version is absent.
+		if i+1 < len(items) {
+			next := items[i+1].Key()
+			bz, op, xerr := ledger.ProveAt(ver, next)
+			require.NoError(t, xerr)
+			require.Nil(t, bz)
+			nextKeyPath := merkle.KeyPath{}.AppendKey(next[:], merkle.KeyEncodingHex).String()
+			require.NoError(t, prt.VerifyAbsence(&tmcrypto.ProofOps{Ops: []tmcrypto.ProofOp{*op}}, root, nextKeyPath), "version", ver)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package ledger
+
+// PruningOption is the strategy to prune the old versions of a ledger.
+// The zero value keeps all versions.
+type PruningOption struct {
+	// KeepRecent is the number of recent versions to keep.
+	// 0 means that all versions are kept.
+	KeepRecent int64
+	// KeepEvery makes every KeepEvery-th version be kept in addition to the recent versions.
+	// 0 means that only the recent versions are kept.
+	KeepEvery int64
+	// Interval is the number of versions between two prunings.
+	Interval int64
+}
+
+func (opt PruningOption) IsArchive() bool {
+	return opt.KeepRecent <= 0
+}
+
+// ShouldPrune returns true if the pruning should be run when the version `ver` is committed.
+func (opt PruningOption) ShouldPrune(ver int64) bool {
+	if opt.IsArchive() {
+		return false
+	}
+	return opt.Interval <= 1 || ver%opt.Interval == 0
+}
+
+// IsPruned returns true if the version `ver` is pruned by the strategy when the latest version is `latest`.
+func (opt PruningOption) IsPruned(ver, latest int64) bool {
+	if opt.IsArchive() || ver > latest-opt.KeepRecent {
+		return false
+	}
+	return opt.KeepEvery <= 0 || ver%opt.KeepEvery != 0
+}
@@ -0,0 +1,65 @@
+package ledger
+
+import (
+	"github.com/rigochain/rigo-go/types/bytes"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/rand"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPruningOption(t *testing.T) {
+	archive := PruningOption{}
+	require.True(t, archive.IsArchive())
+	require.False(t, archive.ShouldPrune(100))
+	require.False(t, archive.IsPruned(1, 100))
+
+	keepRecent := PruningOption{KeepRecent: 10, Interval: 5}
+	require.False(t, keepRecent.IsArchive())
+	require.True(t, keepRecent.ShouldPrune(100))
+	require.False(t, keepRecent.ShouldPrune(101))
+	require.True(t, keepRecent.IsPruned(90, 100))
+	require.False(t, keepRecent.IsPruned(91, 100))
+	require.False(t, keepRecent.IsPruned(100, 100))
+
+	keepEvery := PruningOption{KeepRecent: 10, KeepEvery: 20, Interval: 1}
+	require.True(t, keepEvery.ShouldPrune(101))
+	require.True(t, keepEvery.IsPruned(81, 100))
+	require.False(t, keepEvery.IsPruned(80, 100))
+	require.False(t, keepEvery.IsPruned(95, 100))
+}
+
+func TestFinalityLedger_Pruning(t *testing.T) {
+	dbDir := filepath.Join(os.TempDir(), "test-pruning")
+	require.NoError(t, os.RemoveAll(dbDir))
+	defer os.RemoveAll(dbDir)
+
+	pruning := PruningOption{KeepRecent: 3, KeepEvery: 4, Interval: 2}
+	ledger, xerr := NewFinalityLedger[*MyItem]("pruning", dbDir, 128, pruning, func() *MyItem { return &MyItem{} })
+	require.NoError(t, xerr)
+	defer ledger.Close()
+
+	var items []*MyItem
+	for i := 0; i < 10; i++ {
+		item := NewMyItem(bytes.RandHexString(32), rand.Int32())
+		require.NoError(t, ledger.SetFinality(item))
+		_, _, xerr := ledger.Commit()
+		require.NoError(t, xerr)
+		items = append(items, item)
+	}
+
+	// the versions [8, 10] are recent and the versions 4, 8 are kept by `KeepEvery`.
+	for ver := int64(1); ver <= 10; ver++ {
+		immuLedger, xerr := ledger.ImmutableLedgerAt(ver, 0)
+		if pruning.IsPruned(ver, 10) {
+			require.Error(t, xerr, "version", ver)
+			require.Equal(t, xerrors.ErrCodePrunedHeight, xerr.Code())
+			continue
+		}
+		require.NoError(t, xerr, "version", ver)
+		_, xerr = immuLedger.Get(items[ver-1].Key())
+		require.NoError(t, xerr, "version", ver)
+	}
+}
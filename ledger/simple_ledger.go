@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/cosmos/iavl"
 	"github.com/rigochain/rigo-go/types/xerrors"
+	tmcrypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
 	tmdb "github.com/tendermint/tm-db"
 	"sync"
 )
@@ -13,11 +14,13 @@ type SimpleLedger[T ILedgerItem] struct {
 	tree        *iavl.MutableTree
 	cachedItems *memItems[T]
 	getNewItem  func() T
+	cacheSize   int
+	pruning     PruningOption
 
 	mtx sync.RWMutex
 }
 
-func NewSimpleLedger[T ILedgerItem](name, dbDir string, cacheSize int, cb func() T) (*SimpleLedger[T], xerrors.XError) {
+func NewSimpleLedger[T ILedgerItem](name, dbDir string, cacheSize int, pruning PruningOption, cb func() T) (*SimpleLedger[T], xerrors.XError) {
 	if db, err := tmdb.NewDB(name, "goleveldb", dbDir); err != nil {
 		return nil, xerrors.From(err)
 	} else if tree, err := iavl.NewMutableTree(db, cacheSize); err != nil {
@@ -32,6 +35,8 @@ func NewSimpleLedger[T ILedgerItem](name, dbDir string, cacheSize int, cb func()
 			tree:        tree,
 			cachedItems: newMemItems[T](),
 			getNewItem:  cb,
+			cacheSize:   cacheSize,
+			pruning:     pruning,
 		}, nil
 	}
 }
@@ -45,6 +50,10 @@ func (ledger *SimpleLedger[T]) ImmutableLedgerAt(n int64, cacheSize int) (*Simpl
 		return nil, xerrors.From(err)
 	}
 
+	if n > 0 && n < ledger.tree.Version() && !tree.VersionExists(n) {
+		return nil, xerrors.ErrPrunedHeight.Wrapf("the version %v of the ledger is pruned", n)
+	}
+
 	_, err = tree.LazyLoadVersion(n)
 	if err != nil {
 		return nil, xerrors.From(err)
@@ -64,6 +73,52 @@ func (ledger *SimpleLedger[T]) Version() int64 {
 	return ledger.tree.Version()
 }
 
+// RootHashAt returns the root hash of the tree at version `ver`.
+func (ledger *SimpleLedger[T]) RootHashAt(ver int64) ([]byte, xerrors.XError) {
+	ledger.mtx.RLock()
+	defer ledger.mtx.RUnlock()
+
+	immuTree, err := ledger.tree.GetImmutable(ver)
+	if err != nil {
+		return nil, xerrors.From(err)
+	}
+	hash, err := immuTree.Hash()
+	if err != nil {
+		return nil, xerrors.From(err)
+	}
+	return hash, nil
+}
+
+// ProveAt returns the encoded item of `key` at version `ver` and the proof of it, which is chained to the root hash of the tree.
+// If the item does not exist, the returned item is nil and the proof is the proof of absence.
+func (ledger *SimpleLedger[T]) ProveAt(ver int64, key LedgerKey) ([]byte, *tmcrypto.ProofOp, xerrors.XError) {
+	ledger.mtx.RLock()
+	defer ledger.mtx.RUnlock()
+
+	if ver > 0 && ver < ledger.tree.Version() && !ledger.tree.VersionExists(ver) {
+		return nil, nil, xerrors.ErrPrunedHeight.Wrapf("the version %v of the ledger is pruned", ver)
+	}
+	immuTree, err := ledger.tree.GetImmutable(ver)
+	if err != nil {
+		return nil, nil, xerrors.From(err)
+	}
+	bz, proof, err := immuTree.GetWithProof(key[:])
+	if err != nil {
+		return nil, nil, xerrors.From(err)
+	} else if proof == nil {
+		// the proof of an empty tree is not chained to the root hash.
+		return nil, nil, xerrors.ErrNotFoundResult.Wrapf("the version %v of the ledger is empty", ver)
+	}
+
+	var op tmcrypto.ProofOp
+	if bz == nil {
+		op = iavl.NewAbsenceOp(key[:], proof).ProofOp()
+	} else {
+		op = iavl.NewValueOp(key[:], proof).ProofOp()
+	}
+	return bz, &op, nil
+}
+
 func (ledger *SimpleLedger[T]) Set(item T) xerrors.XError {
 	ledger.mtx.Lock()
 	defer ledger.mtx.Unlock()
@@ -251,6 +306,200 @@ func (ledger *SimpleLedger[T]) Commit() ([]byte, int64, xerrors.XError) {
 	//}
 }
 
+// prune deletes the versions pruned by the pruning option when the latest version is `latest`.
+func (ledger *SimpleLedger[T]) prune(latest int64) xerrors.XError {
+	if !ledger.pruning.ShouldPrune(latest) {
+		return nil
+	}
+
+	var vers []int64
+	for _, v := range ledger.tree.AvailableVersions() {
+		if ledger.pruning.IsPruned(int64(v), latest) {
+			vers = append(vers, int64(v))
+		}
+	}
+	if err := ledger.tree.DeleteVersions(vers...); err != nil {
+		return xerrors.From(err)
+	}
+	return nil
+}
+
+// Export walks all nodes of the tree at version `ver` in the order that Import requires.
+func (ledger *SimpleLedger[T]) Export(ver int64, cb func(*iavl.ExportNode) xerrors.XError) xerrors.XError {
+	immuLedger, xerr := ledger.ImmutableLedgerAt(ver, 0)
+	if xerr != nil {
+		return xerr
+	}
+
+	exporter := immuLedger.tree.Export()
+	defer exporter.Close()
+
+	for {
+		node, err := exporter.Next()
+		if err == iavl.ExportDone {
+			break
+		} else if err != nil {
+			return xerrors.From(err)
+		}
+		if xerr := cb(node); xerr != nil {
+			return xerr
+		}
+	}
+	return nil
+}
+
+// Import rebuilds the tree at version `ver` from the nodes produced by Export.
+// The ledger MUST be empty. It returns the root hash of the imported tree.
+func (ledger *SimpleLedger[T]) Import(ver int64, nodes []*iavl.ExportNode) ([]byte, xerrors.XError) {
+	ledger.mtx.Lock()
+	defer ledger.mtx.Unlock()
+
+	importer, err := ledger.tree.Import(ver)
+	if err != nil {
+		return nil, xerrors.From(err)
+	}
+	defer importer.Close()
+
+	for _, node := range nodes {
+		if err := importer.Add(node); err != nil {
+			return nil, xerrors.From(err)
+		}
+	}
+	if err := importer.Commit(); err != nil {
+		return nil, xerrors.From(err)
+	}
+
+	ledger.cachedItems.reset()
+
+	hash, err := ledger.tree.Hash()
+	if err != nil {
+		return nil, xerrors.From(err)
+	}
+	return hash, nil
+}
+
+// ImportPast imports the older version `ver` into the ledger which already has a newer version imported by Import.
+// The version is only readable through ImmutableLedgerAt.
+func (ledger *SimpleLedger[T]) ImportPast(ver int64, nodes []*iavl.ExportNode) xerrors.XError {
+	ledger.mtx.Lock()
+	defer ledger.mtx.Unlock()
+
+	if ver <= 0 || ver >= ledger.tree.Version() {
+		return xerrors.NewOrdinary(fmt.Sprintf("the version %v is not older than the current version %v", ver, ledger.tree.Version()))
+	}
+
+	// The version is imported into a temporary tree at first,
+	// because iavl.Importer works only on an empty tree.
+	memDB := tmdb.NewMemDB()
+	defer memDB.Close()
+
+	tree, err := iavl.NewMutableTree(memDB, 0)
+	if err != nil {
+		return xerrors.From(err)
+	}
+	importer, err := tree.Import(ver)
+	if err != nil {
+		return xerrors.From(err)
+	}
+	defer importer.Close()
+
+	for _, node := range nodes {
+		if err := importer.Add(node); err != nil {
+			return xerrors.From(err)
+		}
+	}
+	if err := importer.Commit(); err != nil {
+		return xerrors.From(err)
+	}
+
+	itr, err := memDB.Iterator(nil, nil)
+	if err != nil {
+		return xerrors.From(err)
+	}
+	defer itr.Close()
+
+	batch := ledger.db.NewBatch()
+	defer batch.Close()
+	for ; itr.Valid(); itr.Next() {
+		// Only the nodes('n<hash>') and the root('r<version>') are copied.
+		// The nodes are addressed by their hashes, so the nodes shared with other versions are just overwritten.
+		switch itr.Key()[0] {
+		case 'n', 'r':
+			if err := batch.Set(itr.Key(), itr.Value()); err != nil {
+				return xerrors.From(err)
+			}
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return xerrors.From(err)
+	}
+	if err := batch.WriteSync(); err != nil {
+		return xerrors.From(err)
+	}
+	return nil
+}
+
+// Rollback discards all versions newer than `ver` and the uncommitted items.
+// If `ver` is 0, the ledger becomes empty.
+func (ledger *SimpleLedger[T]) Rollback(ver int64) xerrors.XError {
+	ledger.mtx.Lock()
+	defer ledger.mtx.Unlock()
+
+	return ledger.rollback(ver)
+}
+
+func (ledger *SimpleLedger[T]) rollback(ver int64) xerrors.XError {
+	if ver < 0 || ver > ledger.tree.Version() {
+		return xerrors.NewOrdinary(fmt.Sprintf("can not rollback to the version %v - current version: %v", ver, ledger.tree.Version()))
+	}
+
+	if ver == 0 {
+		// iavl can not load the version 0 for overwriting,
+		// so all the versions are removed from the db.
+		if xerr := clearDB(ledger.db); xerr != nil {
+			return xerr
+		}
+		tree, err := iavl.NewMutableTree(ledger.db, ledger.cacheSize)
+		if err != nil {
+			return xerrors.From(err)
+		}
+		if _, err := tree.Load(); err != nil {
+			return xerrors.From(err)
+		}
+		ledger.tree = tree
+	} else if ver < ledger.tree.Version() {
+		if _, err := ledger.tree.LoadVersionForOverwriting(ver); err != nil {
+			return xerrors.From(err)
+		}
+	}
+
+	ledger.cachedItems.reset()
+	return nil
+}
+
+func clearDB(db tmdb.DB) xerrors.XError {
+	itr, err := db.Iterator(nil, nil)
+	if err != nil {
+		return xerrors.From(err)
+	}
+	defer itr.Close()
+
+	batch := db.NewBatch()
+	defer batch.Close()
+	for ; itr.Valid(); itr.Next() {
+		if err := batch.Delete(itr.Key()); err != nil {
+			return xerrors.From(err)
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return xerrors.From(err)
+	}
+	if err := batch.WriteSync(); err != nil {
+		return xerrors.From(err)
+	}
+	return nil
+}
+
 func (ledger *SimpleLedger[T]) Clone() ILedger[T] {
 	return &SimpleLedger[T]{
 		tree:        ledger.tree,
@@ -50,7 +50,7 @@ func TestSimpleLedger(t *testing.T) {
 	dbDir := filepath.Join(os.TempDir(), "test")
 	os.RemoveAll(dbDir)
 
-	ledger, err := NewSimpleLedger[*MyItem]("treeLedger1", dbDir, 256, func() *MyItem { return &MyItem{} })
+	ledger, err := NewSimpleLedger[*MyItem]("treeLedger1", dbDir, 256, PruningOption{}, func() *MyItem { return &MyItem{} })
 	require.NoError(t, err)
 
 	i0 := NewMyItem("i0", 0)
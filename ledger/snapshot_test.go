@@ -0,0 +1,98 @@
+package ledger
+
+import (
+	"github.com/cosmos/iavl"
+	"github.com/rigochain/rigo-go/types/bytes"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/rand"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newSnapshotTestLedger(t *testing.T, name string) *FinalityLedger[*MyItem] {
+	dbDir := filepath.Join(os.TempDir(), "test-snapshot-"+name)
+	require.NoError(t, os.RemoveAll(dbDir))
+	t.Cleanup(func() { _ = os.RemoveAll(dbDir) })
+
+	ledger, xerr := NewFinalityLedger[*MyItem](name, dbDir, 128, PruningOption{}, func() *MyItem { return &MyItem{} })
+	require.NoError(t, xerr)
+	t.Cleanup(func() { _ = ledger.Close() })
+	return ledger
+}
+
+func exportNodes(t *testing.T, ledger *FinalityLedger[*MyItem], ver int64) []*iavl.ExportNode {
+	var nodes []*iavl.ExportNode
+	require.NoError(t, ledger.Export(ver, func(node *iavl.ExportNode) xerrors.XError {
+		nodes = append(nodes, node)
+		return nil
+	}))
+	return nodes
+}
+
+func TestFinalityLedger_ExportImport(t *testing.T) {
+	src := newSnapshotTestLedger(t, "src")
+
+	var items []*MyItem
+	var hashes [][]byte
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 10; j++ {
+			item := NewMyItem(bytes.RandHexString(32), rand.Int32())
+			require.NoError(t, src.SetFinality(item))
+			items = append(items, item)
+		}
+		if i == 3 {
+			_, xerr := src.DelFinality(items[0].Key())
+			require.NoError(t, xerr)
+		}
+		hash, ver, xerr := src.Commit()
+		require.NoError(t, xerr)
+		require.EqualValues(t, i+1, ver)
+		hashes = append(hashes, hash)
+	}
+
+	dst := newSnapshotTestLedger(t, "dst")
+
+	// import the latest version
+	hash, xerr := dst.Import(5, exportNodes(t, src, 5))
+	require.NoError(t, xerr)
+	require.Equal(t, hashes[4], hash)
+	require.EqualValues(t, 5, dst.Version())
+
+	_, xerr = dst.Read(items[0].Key())
+	require.Equal(t, xerrors.ErrNotFoundResult, xerr)
+	for _, item := range items[1:] {
+		found, xerr := dst.Read(item.Key())
+		require.NoError(t, xerr)
+		require.Equal(t, item, found)
+	}
+
+	// a ledger which is not empty can not import the latest version again.
+	_, xerr = dst.Import(5, exportNodes(t, src, 5))
+	require.Error(t, xerr)
+
+	// import a past version
+	require.Error(t, dst.ImportPast(5, exportNodes(t, src, 5)))
+	require.NoError(t, dst.ImportPast(3, exportNodes(t, src, 3)))
+
+	immuLedger, xerr := dst.ImmutableLedgerAt(3, 0)
+	require.NoError(t, xerr)
+	found, xerr := immuLedger.Read(items[0].Key())
+	require.NoError(t, xerr)
+	require.Equal(t, items[0], found)
+	_, xerr = immuLedger.Read(items[30].Key())
+	require.Equal(t, xerrors.ErrNotFoundResult, xerr)
+
+	// the next version of both ledgers should be same.
+	item := NewMyItem(bytes.RandHexString(32), rand.Int32())
+	require.NoError(t, src.SetFinality(item))
+	require.NoError(t, dst.SetFinality(item))
+
+	hash0, ver0, xerr := src.Commit()
+	require.NoError(t, xerr)
+	hash1, ver1, xerr := dst.Commit()
+	require.NoError(t, xerr)
+	require.Equal(t, ver0, ver1)
+	require.Equal(t, hash0, hash1)
+}
@@ -2,7 +2,9 @@ package ledger
 
 import (
 	"bytes"
+	"github.com/cosmos/iavl"
 	"github.com/rigochain/rigo-go/types/xerrors"
+	tmcrypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
 	"sort"
 )
 
@@ -70,4 +72,10 @@ type IFinalityLedger[T ILedgerItem] interface {
 	IterateFinalityGotItems(func(T) xerrors.XError) xerrors.XError
 	IterateFinalityUpdatedItems(func(T) xerrors.XError) xerrors.XError
 	ImmutableLedgerAt(int64, int) (ILedger[T], xerrors.XError)
+	Export(int64, func(*iavl.ExportNode) xerrors.XError) xerrors.XError
+	Import(int64, []*iavl.ExportNode) ([]byte, xerrors.XError)
+	ImportPast(int64, []*iavl.ExportNode) xerrors.XError
+	RootHashAt(int64) ([]byte, xerrors.XError)
+	ProveAt(int64, LedgerKey) ([]byte, *tmcrypto.ProofOp, xerrors.XError)
+	Rollback(int64) xerrors.XError
 }
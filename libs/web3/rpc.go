@@ -3,6 +3,7 @@ package web3
 import (
 	"errors"
 	"github.com/holiman/uint256"
+	"github.com/rigochain/rigo-go/ctrlers/account"
 	"github.com/rigochain/rigo-go/ctrlers/stake"
 	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
 	rweb3types "github.com/rigochain/rigo-go/libs/web3/types"
@@ -50,7 +51,7 @@ func (rweb3 *RigoWeb3) Genesis() (*coretypes.ResultGenesis, error) {
 func (rweb3 *RigoWeb3) GetGovParams() (*ctrlertypes.GovParams, error) {
 	queryResp := &rpc.QueryResult{}
 
-	if req, err := rweb3.NewRequest("gov_params", strconv.FormatInt(0, 10)); err != nil {
+	if req, err := rweb3.NewRequest("gov_params", strconv.FormatInt(0, 10), false); err != nil {
 		panic(err)
 	} else if resp, err := rweb3.provider.Call(req); err != nil {
 		return nil, err
@@ -70,7 +71,7 @@ func (rweb3 *RigoWeb3) GetGovParams() (*ctrlertypes.GovParams, error) {
 func (rweb3 *RigoWeb3) GetAccount(addr types.Address) (*ctrlertypes.Account, error) {
 	queryResp := &rpc.QueryResult{}
 
-	if req, err := rweb3.NewRequest("account", addr.String(), strconv.FormatInt(0, 10)); err != nil {
+	if req, err := rweb3.NewRequest("account", addr.String(), strconv.FormatInt(0, 10), false); err != nil {
 		panic(err)
 	} else if resp, err := rweb3.provider.Call(req); err != nil {
 		return nil, err
@@ -114,7 +115,7 @@ func (rweb3 *RigoWeb3) GetDelegatee(addr types.Address) (*stake.Delegatee, error
 	queryResp := &rpc.QueryResult{}
 	delegatee := stake.NewDelegatee(nil, nil)
 
-	if req, err := rweb3.NewRequest("delegatee", addr.String(), strconv.FormatInt(0, 10)); err != nil {
+	if req, err := rweb3.NewRequest("delegatee", addr.String(), strconv.FormatInt(0, 10), false); err != nil {
 		panic(err)
 	} else if resp, err := rweb3.provider.Call(req); err != nil {
 		return nil, err
@@ -132,7 +133,7 @@ func (rweb3 *RigoWeb3) GetDelegatee(addr types.Address) (*stake.Delegatee, error
 func (rweb3 *RigoWeb3) GetStakes(addr types.Address) ([]*stake.Stake, error) {
 	queryResp := &rpc.QueryResult{}
 	var stakes []*stake.Stake
-	if req, err := rweb3.NewRequest("stakes", addr.String(), strconv.FormatInt(0, 10)); err != nil {
+	if req, err := rweb3.NewRequest("stakes", addr.String(), strconv.FormatInt(0, 10), false); err != nil {
 		panic(err)
 	} else if resp, err := rweb3.provider.Call(req); err != nil {
 		return nil, err
@@ -150,7 +151,7 @@ func (rweb3 *RigoWeb3) GetStakes(addr types.Address) ([]*stake.Stake, error) {
 func (rweb3 *RigoWeb3) QueryReward(addr types.Address, height int64) (*stake.Reward, error) {
 	queryResp := &rpc.QueryResult{}
 	rwd := stake.NewReward(addr)
-	if req, err := rweb3.NewRequest("reward", addr.String(), strconv.FormatInt(height, 10)); err != nil {
+	if req, err := rweb3.NewRequest("reward", addr.String(), strconv.FormatInt(height, 10), false); err != nil {
 		panic(err)
 	} else if resp, err := rweb3.provider.Call(req); err != nil {
 		return nil, err
@@ -165,6 +166,63 @@ func (rweb3 *RigoWeb3) QueryReward(addr types.Address, height int64) (*stake.Rew
 	}
 }
 
+func (rweb3 *RigoWeb3) QueryFeeDistribution(height int64) (*account.FeeDistribution, error) {
+	queryResp := &rpc.QueryResult{}
+	dist := &account.FeeDistribution{}
+	if req, err := rweb3.NewRequest("fee_distribution", strconv.FormatInt(height, 10)); err != nil {
+		panic(err)
+	} else if resp, err := rweb3.provider.Call(req); err != nil {
+		return nil, err
+	} else if resp.Error != nil {
+		return nil, errors.New("provider error: " + string(resp.Error))
+	} else if err := tmjson.Unmarshal(resp.Result, queryResp); err != nil {
+		return nil, err
+	} else if err := tmjson.Unmarshal(queryResp.Value, dist); err != nil {
+		return nil, err
+	} else {
+		return dist, nil
+	}
+}
+
+func (rweb3 *RigoWeb3) QuerySupply(height int64) (*ctrlertypes.Supply, error) {
+	queryResp := &rpc.QueryResult{}
+	supply := &ctrlertypes.Supply{}
+	if req, err := rweb3.NewRequest("supply", strconv.FormatInt(height, 10)); err != nil {
+		panic(err)
+	} else if resp, err := rweb3.provider.Call(req); err != nil {
+		return nil, err
+	} else if resp.Error != nil {
+		return nil, errors.New("provider error: " + string(resp.Error))
+	} else if err := tmjson.Unmarshal(resp.Result, queryResp); err != nil {
+		return nil, err
+	} else if err := tmjson.Unmarshal(queryResp.Value, supply); err != nil {
+		return nil, err
+	} else {
+		return supply, nil
+	}
+}
+
+// QueryBaseFee returns the base fee of the next block.
+func (rweb3 *RigoWeb3) QueryBaseFee() (*uint256.Int, error) {
+	queryResp := &rpc.QueryResult{}
+	_baseFee := &struct {
+		BaseFee string `json:"baseFee"`
+	}{}
+	if req, err := rweb3.NewRequest("base_fee"); err != nil {
+		panic(err)
+	} else if resp, err := rweb3.provider.Call(req); err != nil {
+		return nil, err
+	} else if resp.Error != nil {
+		return nil, errors.New("provider error: " + string(resp.Error))
+	} else if err := tmjson.Unmarshal(resp.Result, queryResp); err != nil {
+		return nil, err
+	} else if err := tmjson.Unmarshal(queryResp.Value, _baseFee); err != nil {
+		return nil, err
+	} else {
+		return uint256.FromDecimal(_baseFee.BaseFee)
+	}
+}
+
 func (rweb3 *RigoWeb3) QueryTotalPower(height int64) (int64, error) {
 	queryResp := &rpc.QueryResult{}
 	if req, err := rweb3.NewRequest("stakes/total_power", strconv.FormatInt(height, 10)); err != nil {
@@ -40,6 +40,19 @@ func NewTrxUnstaking(from, to types.Address, nonce, gas uint64, gasPrice *uint25
 		&types2.TrxPayloadUnstaking{TxHash: txhash})
 }
 
+// NewTrxUnstakingAmount returns the tx un-staking only `amt` from the stake of `txhash`.
+// If `txhash` is nil, `amt` is taken from all the stakes of `from` delegated to `to`, the most recent one first.
+func NewTrxUnstakingAmount(from, to types.Address, nonce, gas uint64, gasPrice *uint256.Int, txhash bytes.HexBytes, amt *uint256.Int) *types2.Trx {
+	return types2.NewTrx(
+		uint32(1),
+		from, to,
+		nonce,
+		gas,
+		gasPrice,
+		uint256.NewInt(0),
+		&types2.TrxPayloadUnstaking{TxHash: txhash, Amount: amt})
+}
+
 func NewTrxWithdraw(from, to types.Address, nonce, gas uint64, gasPrice, req *uint256.Int) *types2.Trx {
 	return types2.NewTrx(
 		uint32(1),
@@ -107,3 +120,108 @@ func NewTrxSetDoc(from types.Address, nonce, gas uint64, gasPrice *uint256.Int,
 		&types2.TrxPayloadSetDoc{name, docUrl},
 	)
 }
+
+// NewTrxMultiSig returns the tx creating the multi-signature account of `pubKeys` with `amt` as its initial balance.
+func NewTrxMultiSig(from types.Address, nonce, gas uint64, gasPrice, amt *uint256.Int, threshold uint32, pubKeys ...bytes.HexBytes) *types2.Trx {
+	payload := &types2.TrxPayloadMultiSig{
+		Threshold: threshold,
+		PubKeys:   pubKeys,
+	}
+	return types2.NewTrx(
+		uint32(1),
+		from, payload.MultiSig().Address(),
+		nonce,
+		gas,
+		gasPrice,
+		amt,
+		payload,
+	)
+}
+
+// NewTrxBatch returns the tx transferring the amounts to `recipients` at once.
+// `gas` is the gas for each of `recipients`.
+func NewTrxBatch(from types.Address, nonce, gas uint64, gasPrice *uint256.Int, recipients ...*types2.BatchRecipient) *types2.Trx {
+	payload := &types2.TrxPayloadBatch{Recipients: recipients}
+	return types2.NewTrx(
+		uint32(1),
+		from, types.ZeroAddress(),
+		nonce,
+		gas*uint64(len(recipients)),
+		gasPrice,
+		payload.SumAmount(),
+		payload,
+	)
+}
+
+// NewTrxVesting returns the tx transferring `amt` to `to` and locking it on the vesting schedule.
+func NewTrxVesting(from, to types.Address, nonce, gas uint64, gasPrice, amt *uint256.Int, start, cliff, end, period int64) *types2.Trx {
+	return types2.NewTrx(
+		uint32(1),
+		from, to,
+		nonce,
+		gas,
+		gasPrice,
+		amt,
+		&types2.TrxPayloadVesting{
+			Start:  start,
+			Cliff:  cliff,
+			End:    end,
+			Period: period,
+		},
+	)
+}
+
+// SetTrxPayer makes `tx` a sponsored tx whose fee is paid by `payer`.
+// `tx` should be signed by the sender first, and then by `payer` with `SignTrxPayerRLP`.
+func SetTrxPayer(tx *types2.Trx, payer types.Address) *types2.Trx {
+	tx.Version = types2.TRX_VERSION_FEE_PAYER
+	tx.Payer = payer
+	return tx
+}
+
+// NewTrxCommission returns the tx setting the commission of the validator `from`.
+// `maxRate` and `maxChangeRate` should be the same as the ones set first.
+func NewTrxCommission(from types.Address, nonce, gas uint64, gasPrice *uint256.Int, rate, maxRate, maxChangeRate int64) *types2.Trx {
+	return types2.NewTrx(
+		uint32(1),
+		from, from,
+		nonce,
+		gas,
+		gasPrice,
+		uint256.NewInt(0),
+		&types2.TrxPayloadCommission{
+			Rate:          rate,
+			MaxRate:       maxRate,
+			MaxChangeRate: maxChangeRate,
+		},
+	)
+}
+
+// NewTrxRedelegate returns the tx moving the stake of `txhash` from the delegatee `src` to `to`.
+func NewTrxRedelegate(from, src, to types.Address, nonce, gas uint64, gasPrice *uint256.Int, txhash bytes.HexBytes) *types2.Trx {
+	return types2.NewTrx(
+		uint32(1),
+		from, to,
+		nonce,
+		gas,
+		gasPrice,
+		uint256.NewInt(0),
+		&types2.TrxPayloadRedelegate{
+			Src:    src,
+			TxHash: txhash,
+		},
+	)
+}
+
+// NewTrxUnjail returns the tx releasing the jailed validator `from`.
+func NewTrxUnjail(from types.Address, nonce, gas uint64, gasPrice *uint256.Int) *types2.Trx {
+	return types2.NewTrx(
+		uint32(1),
+		from, from,
+		nonce,
+		gas,
+		gasPrice,
+		uint256.NewInt(0),
+		&types2.TrxPayloadUnjail{},
+	)
+}
@@ -170,6 +170,46 @@ func (w *Wallet) SignTrxRLP(tx *types2.Trx, chainId string) (bytes.HexBytes, byt
 	return sig, preimg, nil
 }
 
+// CoSignTrxRLP appends the signature of `w` to the signatures of `tx`, which is sent from a multi-signature account.
+// The co-signers of a multi-signature account sign `tx` one by one.
+func (w *Wallet) CoSignTrxRLP(tx *types2.Trx, chainId string) (bytes.HexBytes, error) {
+	w.mtx.RLock()
+	defer w.mtx.RUnlock()
+
+	preimg, xerr := types2.PreImageToSignTrxRLP(tx, chainId)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	sig, err := w.wkey.Sign(preimg)
+	if err != nil {
+		return nil, err
+	}
+
+	tx.Sig = append(append(bytes.HexBytes(nil), tx.Sig...), sig...)
+	return sig, nil
+}
+
+// SignTrxPayerRLP signs `tx` as the fee payer of it.
+// The fee payer signs `tx` already signed by the sender.
+func (w *Wallet) SignTrxPayerRLP(tx *types2.Trx, chainId string) (bytes.HexBytes, bytes.HexBytes, error) {
+	w.mtx.RLock()
+	defer w.mtx.RUnlock()
+
+	preimg, xerr := types2.PreImageToSignTrxPayerRLP(tx, chainId)
+	if xerr != nil {
+		return nil, nil, xerr
+	}
+
+	sig, err := w.wkey.Sign(preimg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tx.PayerSig = sig
+	return sig, preimg, nil
+}
+
 func (w *Wallet) SendTxAsync(tx *types2.Trx, rweb3 *RigoWeb3) (*coretypes.ResultBroadcastTx, error) {
 	if _, _, err := w.SignTrxRLP(tx, rweb3.ChainID()); err != nil {
 		return nil, err
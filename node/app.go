@@ -2,6 +2,7 @@ package node
 
 import (
 	"fmt"
+	"github.com/holiman/uint256"
 	cfg "github.com/rigochain/rigo-go/cmd/config"
 	"github.com/rigochain/rigo-go/cmd/version"
 	"github.com/rigochain/rigo-go/ctrlers/account"
@@ -20,6 +21,8 @@ import (
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	tmtime "github.com/tendermint/tendermint/types/time"
 	tmver "github.com/tendermint/tendermint/version"
+	"runtime"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -40,6 +43,25 @@ type RigoApp struct {
 	vmCtrler    *evm.EVMCtrler
 	txExecutor  *TrxExecutor
 
+	supplyLedger *supplyLedger
+
+	// the accounts updated by the txs in the mempool. it is reset after Commit.
+	checkAcctHandler *checkAcctHandler
+	// the txs replaced in the mempool. it is kept across the blocks.
+	replacedTxs *replacedTrxs
+
+	upgradeHandlers map[string]UpgradeHandler
+	// the upgrade applied by the current block. it is recorded in `metaDB` at Commit.
+	appliedUpgrade *rctypes.UpgradePlan
+
+	// the requests and responses of DeliverTx, which are executed in parallel at EndBlock.
+	deliverTxReqs  []abcitypes.RequestDeliverTx
+	deliverTxResps []abcitypes.ResponseDeliverTx
+
+	snapshotStore *snapshotStore
+	snapshotting  int32
+	restorer      *snapshotRestorer
+
 	localClient abcicli.Client
 	rootConfig  *cfg.Config
 
@@ -58,6 +80,7 @@ func NewRigoApp(config *cfg.Config, logger log.Logger) *RigoApp {
 	if err != nil {
 		panic(err)
 	}
+	govCtrler.SetUpgradeHistory(stateDB)
 
 	acctCtrler, err := account.NewAcctCtrler(config, logger)
 	if err != nil {
@@ -69,21 +92,35 @@ func NewRigoApp(config *cfg.Config, logger log.Logger) *RigoApp {
 		panic(err)
 	}
 
-	vmCtrler := evm.NewEVMCtrler(config.DBDir(), acctCtrler, logger)
+	vmCtrler := evm.NewEVMCtrler(config.DBDir(), acctCtrler, rctypes.PruningOptionOf(config.App), logger)
+
+	supplyLedger, err := newSupplyLedger(config.DBDir(), rctypes.PruningOptionOf(config.App))
+	if err != nil {
+		panic(err)
+	}
 
 	// the first parameter of NewTrxExecutor `n` is 0,
-	// because the parallel tx-processing is not used
-	txExecutor := NewTrxExecutor(0 /*runtime.GOMAXPROCS(0)*/, logger)
+	// if the parallel tx-processing is not used
+	n := 0
+	if config.App.ParallelDeliverTx {
+		n = runtime.GOMAXPROCS(0)
+	}
+	txExecutor := NewTrxExecutor(n, logger)
 
 	return &RigoApp{
-		metaDB:      stateDB,
-		acctCtrler:  acctCtrler,
-		stakeCtrler: stakeCtrler,
-		govCtrler:   govCtrler,
-		vmCtrler:    vmCtrler,
-		txExecutor:  txExecutor,
-		rootConfig:  config,
-		logger:      logger,
+		metaDB:           stateDB,
+		acctCtrler:       acctCtrler,
+		stakeCtrler:      stakeCtrler,
+		govCtrler:        govCtrler,
+		vmCtrler:         vmCtrler,
+		txExecutor:       txExecutor,
+		supplyLedger:     supplyLedger,
+		checkAcctHandler: newCheckAcctHandler(acctCtrler),
+		replacedTxs:      newReplacedTrxs(),
+		upgradeHandlers:  registeredUpgradeHandlers(),
+		snapshotStore:    newSnapshotStore(config.SnapshotDir()),
+		rootConfig:       config,
+		logger:           logger,
 	}
 }
 
@@ -111,6 +148,9 @@ func (ctrler *RigoApp) Stop() error {
 	if err := ctrler.vmCtrler.Close(); err != nil {
 		return err
 	}
+	if err := ctrler.supplyLedger.close(); err != nil {
+		return err
+	}
 	if err := ctrler.metaDB.Close(); err != nil {
 		return err
 	}
@@ -152,18 +192,78 @@ func (ctrler *RigoApp) Info(info abcitypes.RequestInfo) abcitypes.ResponseInfo {
 		appHash = ctrler.lastBlockCtx.AppHash()
 	}
 
+	if xerr := ctrler.recoverCommit(lastHeight); xerr != nil {
+		panic(xerr)
+	}
+	ctrler.restoreBlockFee(ctrler.lastBlockCtx)
+
 	// get chain_id
 	ctrler.rootConfig.ChainID = ctrler.metaDB.ChainID()
 
 	return abcitypes.ResponseInfo{
 		Data:             "",
 		Version:          tmver.ABCIVersion,
-		AppVersion:       version.Uint64(version.MASK_MAJOR_VER, version.MASK_MINOR_VER),
+		AppVersion:       ctrler.appVersion(lastHeight),
 		LastBlockHeight:  lastHeight,
 		LastBlockAppHash: appHash,
 	}
 }
 
+// restoreBlockFee sets the base fee and the gas used of `blockCtx` to the ones recorded in the state.
+// The block context made by a rollback or restored from a snapshot may not have them,
+// and the base fee of the next block is computed from them.
+func (ctrler *RigoApp) restoreBlockFee(blockCtx *rctypes.BlockContext) {
+	fee := ctrler.acctCtrler.LastBlockFee()
+	if fee == nil || fee.Height != blockCtx.Height() {
+		return
+	}
+	blockCtx.SetBaseFee(fee.BaseFee)
+	blockCtx.SetGasUsed(fee.GasUsed)
+}
+
+// recoverCommit finishes the commit interrupted by a crash.
+// If the block context of the committing height was not saved, the commit was not finished,
+// so the ledgers already committed are rolled back to `lastHeight`.
+func (ctrler *RigoApp) recoverCommit(lastHeight int64) xerrors.XError {
+	committing := ctrler.metaDB.CommittingHeight()
+	if committing == 0 {
+		return nil
+	}
+
+	if lastHeight >= committing {
+		// all ledgers and the block context were committed.
+		if err := ctrler.metaDB.PutLastBlockHeight(lastHeight); err != nil {
+			return xerrors.From(err)
+		}
+	} else {
+		ctrler.logger.Info("Rollback the partially committed block", "committing", committing, "last", lastHeight)
+		if xerr := ctrler.rollbackCtrlers(lastHeight); xerr != nil {
+			return xerr
+		}
+	}
+
+	if err := ctrler.metaDB.DelCommittingHeight(); err != nil {
+		return xerrors.From(err)
+	}
+	return nil
+}
+
+func (ctrler *RigoApp) rollbackCtrlers(height int64) xerrors.XError {
+	if xerr := ctrler.govCtrler.Rollback(height); xerr != nil {
+		return xerr
+	}
+	if xerr := ctrler.acctCtrler.Rollback(height); xerr != nil {
+		return xerr
+	}
+	if xerr := ctrler.stakeCtrler.Rollback(height); xerr != nil {
+		return xerr
+	}
+	if xerr := ctrler.supplyLedger.rollback(height); xerr != nil {
+		return xerr
+	}
+	return ctrler.vmCtrler.Rollback(height)
+}
+
 // InitChain is called only when the ResponseInfo::LastBlockHeight which is returned in Info() is 0.
 func (ctrler *RigoApp) InitChain(req abcitypes.RequestInitChain) abcitypes.ResponseInitChain {
 	// set and put chain_id
@@ -193,6 +293,29 @@ func (ctrler *RigoApp) InitChain(req abcitypes.RequestInitChain) abcitypes.Respo
 		ctrler.logger.Error("RigoApp", "error", xerr)
 		panic(xerr)
 	}
+	if xerr := ctrler.vmCtrler.InitLedger(&appState); xerr != nil {
+		ctrler.logger.Error("RigoApp", "error", xerr)
+		panic(xerr)
+	}
+
+	if len(appState.Delegatees) > 0 {
+		// the state exported from a running network has the delegatees instead of the initial stakes.
+		if xerr := ctrler.stakeCtrler.InitLedger(&stake.GenesisState{
+			Delegatees: appState.Delegatees,
+			Frozen:     appState.FrozenStakes,
+			Rewards:    appState.Rewards,
+		}); xerr != nil {
+			ctrler.logger.Error("RigoApp", "error", xerr)
+			panic(xerr)
+		}
+		if xerr := ctrler.supplyLedger.put(genesisSupply(&appState, nil)); xerr != nil {
+			ctrler.logger.Error("RigoApp", "error", xerr)
+			panic(xerr)
+		}
+		return abcitypes.ResponseInitChain{
+			AppHash: appHash,
+		}
+	}
 
 	// validator - initial stakes
 	initStakes := make([]*stake.InitStake, len(req.Validators))
@@ -226,6 +349,10 @@ func (ctrler *RigoApp) InitChain(req abcitypes.RequestInitChain) abcitypes.Respo
 		ctrler.logger.Error("RigoApp", "error", xerr)
 		panic(xerr)
 	}
+	if xerr := ctrler.supplyLedger.put(genesisSupply(&appState, initStakes)); xerr != nil {
+		ctrler.logger.Error("RigoApp", "error", xerr)
+		panic(xerr)
+	}
 
 	// these values will be saved as state of the consensus engine.
 	return abcitypes.ResponseInitChain{
@@ -233,58 +360,189 @@ func (ctrler *RigoApp) InitChain(req abcitypes.RequestInitChain) abcitypes.Respo
 	}
 }
 
+// CheckTx validates the tx against the check-state, which has the pending nonces and balances of the txs in the mempool.
+// After Commit, the check-state is reset to the committed state and the txs left in the mempool are rechecked in order,
+// so the txs which have become invalid are evicted from the mempool.
 func (ctrler *RigoApp) CheckTx(req abcitypes.RequestCheckTx) abcitypes.ResponseCheckTx {
 	ctrler.mtx.Lock()
 	defer ctrler.mtx.Unlock()
 
-	switch req.Type {
-	case abcitypes.CheckTxType_New:
-		txctx, xerr := rctypes.NewTrxContext(req.Tx,
-			ctrler.lastBlockCtx.Height()+int64(1), // issue #39: set block number expected to include current tx.
-			ctrler.lastBlockCtx.ExpectedNextBlockTimeSeconds(ctrler.rootConfig.Consensus.CreateEmptyBlocksInterval), // issue #39: set block time expected to be executed.
-			false,
-			func(_txctx *rctypes.TrxContext) xerrors.XError {
-				_txctx.TrxGovHandler = ctrler.govCtrler
-				_txctx.TrxAcctHandler = ctrler.acctCtrler
-				_txctx.TrxStakeHandler = ctrler.stakeCtrler
-				_txctx.TrxEVMHandler = ctrler.vmCtrler
-				_txctx.GovHandler = ctrler.govCtrler
-				_txctx.AcctHandler = ctrler.acctCtrler
-				_txctx.StakeHandler = ctrler.stakeCtrler
-				_txctx.ChainID = ctrler.rootConfig.ChainID
-				return nil
-			})
-		if xerr != nil {
-			xerr = xerrors.ErrCheckTx.Wrap(xerr)
-			ctrler.logger.Error("CheckTx", "error", xerr)
+	txctx, xerr := rctypes.NewTrxContext(req.Tx,
+		ctrler.lastBlockCtx.Height()+int64(1), // issue #39: set block number expected to include current tx.
+		ctrler.lastBlockCtx.ExpectedNextBlockTimeSeconds(ctrler.rootConfig.Consensus.CreateEmptyBlocksInterval), // issue #39: set block time expected to be executed.
+		false,
+		func(_txctx *rctypes.TrxContext) xerrors.XError {
+			_txctx.TrxGovHandler = ctrler.govCtrler
+			_txctx.TrxAcctHandler = ctrler.acctCtrler
+			_txctx.TrxStakeHandler = ctrler.stakeCtrler
+			_txctx.TrxEVMHandler = ctrler.vmCtrler
+			_txctx.GovHandler = ctrler.govCtrler
+			_txctx.AcctHandler = ctrler.checkAcctHandler
+			_txctx.StakeHandler = ctrler.stakeCtrler
+			_txctx.ChainID = ctrler.rootConfig.ChainID
+			_txctx.BaseFee = rctypes.NextBaseFee(ctrler.lastBlockCtx.BaseFee(), ctrler.lastBlockCtx.GasUsed(), ctrler.govCtrler)
+			return nil
+		})
+	if xerr == nil {
+		// a forged tx must not change the pending state of the sender.
+		xerr = verifyTrxSigs(txctx)
+	}
+	if xerr != nil {
+		xerr = xerrors.ErrCheckTx.Wrap(xerr)
+		ctrler.logger.Error("CheckTx", "type", req.Type, "error", xerr)
+		return abcitypes.ResponseCheckTx{
+			Code: xerr.Code(),
+			Log:  xerr.Error(),
+		}
+	}
+
+	// the tx replaced by another tx of the same sender and nonce is evicted at its first recheck.
+	// The replacing tx may be rechecked after the following txs of the sender,
+	// so it is applied to the pending state in place of the replaced tx not to leave a gap in the nonces.
+	if req.Type == abcitypes.CheckTxType_Recheck {
+		if rtx := ctrler.replacedTxs.take(txctx.TxHash); rtx != nil {
+			ctrler.checkAcctHandler.applyReplacing(rtx)
+
+			xerr = xerrors.ErrCheckTx.Wrapf("replaced tx - txhash: %X", txctx.TxHash)
+			ctrler.logger.Debug("CheckTx", "type", req.Type, "error", xerr)
 			return abcitypes.ResponseCheckTx{
 				Code: xerr.Code(),
 				Log:  xerr.Error(),
 			}
 		}
+	}
 
-		xerr = ctrler.txExecutor.ExecuteSync(txctx)
-		if xerr != nil {
-			xerr = xerrors.ErrCheckTx.Wrap(xerr)
-			ctrler.logger.Error("CheckTx", "error", xerr)
-			return abcitypes.ResponseCheckTx{
-				Code: xerr.Code(),
-				Log:  xerr.Error(),
-				Data: txctx.RetData, // in case of evm, there may be return data when tx is failed.
+	// the sender and the receiver are copies kept by `checkAcctHandler`,
+	// so a failed tx must not leave its changes on them.
+	sender, receiver := txctx.Sender.Clone(), txctx.Receiver.Clone()
+	var payers []*rctypes.Account
+	if txctx.Payer != nil {
+		payers = append(payers, txctx.Payer.Clone())
+	}
+
+	// If a tx of the same sender and nonce is already in the mempool, the tx replaces it.
+	// The pending state of the sender is rolled back to the state before the replaced tx,
+	// and the pending nonce is restored after the tx is executed.
+	// A new tx is only validated as a replacement, and the pending state keeps the replaced tx until the next block.
+	// The replaced tx is recorded at once, so it is evicted at its first recheck after the next block
+	// and the replacement takes its place in the pending state.
+	// Until then both are in the mempool, and the one executed first in a block invalidates the other by its nonce.
+	// If the mempool rejects the replacing tx after CheckTx returns, e.g. when it is full,
+	// the replaced tx is evicted anyway and the sender should submit the replacement again.
+	//
+	// The mempool v1 rechecks the txs concurrently, so a tx may be rechecked before the txs of the lower nonces.
+	// Such a tx is executed at its nonce without changing the pending state and is queued,
+	// and it is applied when the pending nonce reaches its nonce.
+	pendingNonce := txctx.Sender.GetNonce()
+	queued := req.Type == abcitypes.CheckTxType_Recheck && txctx.Tx.Nonce > pendingNonce
+	var pending *pendingTrx
+	if queued {
+		pending = ctrler.checkAcctHandler.queuedTrx(txctx.Tx.From, txctx.Tx.Nonce)
+	} else {
+		pending = ctrler.checkAcctHandler.pendingTrx(txctx.Tx.From, txctx.Tx.Nonce)
+	}
+	if pending != nil && bytes.Compare(pending.txhash, txctx.TxHash) == 0 {
+		if req.Type == abcitypes.CheckTxType_Recheck {
+			// it has been applied in place of the tx replaced by it.
+			return checkTxResponse(txctx, pending.priority)
+		}
+		pending = nil
+	}
+	if pending != nil && !pending.canReplace(txctx.Tx.GasPrice, ctrler.rootConfig.App.ReplaceTxBumpRatio) {
+		xerr = xerrors.ErrCheckTx.Wrapf("replacement tx underpriced - gas price: %v, pending gas price: %v, bump ratio: %v%%",
+			txctx.Tx.GasPrice, pending.gasPrice, ctrler.rootConfig.App.ReplaceTxBumpRatio)
+		ctrler.logger.Error("CheckTx", "type", req.Type, "error", xerr)
+		return abcitypes.ResponseCheckTx{
+			Code:         xerr.Code(),
+			Log:          xerr.Error(),
+			MempoolError: xerr.Error(),
+		}
+	}
+	if pending != nil || queued {
+		txctx.Sender.SetNonce(txctx.Tx.Nonce)
+	}
+	if pending != nil && !queued {
+		_ = txctx.Sender.AddBalance(pending.cost)
+		// the fee of the replaced tx is given back to its fee payer.
+		if pending.payer != nil {
+			if payer := ctrler.checkAcctHandler.FindAccount(pending.payer, false); payer != nil {
+				payers = append(payers, payer.Clone())
+				_ = payer.AddBalance(pending.payerCost)
 			}
 		}
+	}
+	var payerBalance *uint256.Int
+	if txctx.Payer != nil {
+		payerBalance = txctx.Payer.Balance.Clone()
+	}
 
+	xerr = ctrler.txExecutor.ExecuteSync(txctx)
+	if xerr == nil {
+		xerr = postCheckTrx(txctx)
+	}
+	restore := func() {
+		_ = ctrler.checkAcctHandler.SetAccountCommittable(sender, false)
+		_ = ctrler.checkAcctHandler.SetAccountCommittable(receiver, false)
+		for _, payer := range payers {
+			_ = ctrler.checkAcctHandler.SetAccountCommittable(payer, false)
+		}
+	}
+	if xerr != nil {
+		restore()
+
+		xerr = xerrors.ErrCheckTx.Wrap(xerr)
+		ctrler.logger.Error("CheckTx", "type", req.Type, "error", xerr)
 		return abcitypes.ResponseCheckTx{
-			Code:      abcitypes.CodeTypeOK,
-			Log:       "",
-			Data:      txctx.RetData,
-			GasWanted: int64(txctx.Tx.Gas),
-			GasUsed:   int64(txctx.GasUsed),
+			Code: xerr.Code(),
+			Log:  xerr.Error(),
+			Data: txctx.RetData, // in case of evm, there may be return data when tx is failed.
+		}
+	}
+
+	priority := ctrler.checkAcctHandler.priority(txctx.Tx)
+
+	// the amount taken from the sender by the tx.
+	cost := new(uint256.Int).Set(sender.Balance)
+	if pending != nil && !queued {
+		_ = cost.Add(cost, pending.cost)
+	}
+	if cost.Gt(txctx.Sender.Balance) {
+		_ = cost.Sub(cost, txctx.Sender.Balance)
+	} else {
+		_ = cost.Clear()
+	}
+	payerCost := new(uint256.Int)
+	if payerBalance != nil && payerBalance.Gt(txctx.Payer.Balance) {
+		_ = payerCost.Sub(payerBalance, txctx.Payer.Balance)
+	}
+
+	if pending != nil && req.Type == abcitypes.CheckTxType_New {
+		restore()
+		ctrler.replacedTxs.add(pending.txhash, txctx.Tx.From, txctx.Tx.Nonce, newPendingTrx(txctx, priority, cost, payerCost))
+
+		// the replacing tx has no sender in the mempool v1,
+		// because the replaced tx has the same sender until it is evicted.
+		resp := checkTxResponse(txctx, priority)
+		resp.Sender = ""
+		return resp
+	}
+
+	var replacing *pendingTrx
+	if queued {
+		restore()
+		replacing = ctrler.checkAcctHandler.queueTrx(txctx, priority, cost, payerCost)
+	} else {
+		if pending != nil {
+			txctx.Sender.SetNonce(pendingNonce)
+			_ = ctrler.checkAcctHandler.SetAccountCommittable(txctx.Sender, false)
 		}
-	case abcitypes.CheckTxType_Recheck:
-		// do nothing
+		replacing = ctrler.checkAcctHandler.setPendingTrx(txctx, priority, cost, payerCost)
 	}
-	return abcitypes.ResponseCheckTx{Code: abcitypes.CodeTypeOK}
+	if pending != nil {
+		ctrler.replacedTxs.add(pending.txhash, txctx.Tx.From, txctx.Tx.Nonce, replacing)
+	}
+
+	return checkTxResponse(txctx, priority)
 }
 
 func (ctrler *RigoApp) BeginBlock(req abcitypes.RequestBeginBlock) abcitypes.ResponseBeginBlock {
@@ -300,6 +558,10 @@ func (ctrler *RigoApp) BeginBlock(req abcitypes.RequestBeginBlock) abcitypes.Res
 	defer ctrler.mtx.Unlock()
 
 	ctrler.nextBlockCtx = rctypes.NewBlockContext(req, ctrler.govCtrler, ctrler.acctCtrler, ctrler.stakeCtrler)
+	ctrler.nextBlockCtx.SetBaseFee(rctypes.NextBaseFee(ctrler.lastBlockCtx.BaseFee(), ctrler.lastBlockCtx.GasUsed(), ctrler.govCtrler))
+	ctrler.deliverTxReqs = nil
+	ctrler.deliverTxResps = nil
+	ctrler.appliedUpgrade = nil
 
 	ev0, xerr := ctrler.govCtrler.BeginBlock(ctrler.nextBlockCtx)
 	if xerr != nil {
@@ -323,30 +585,51 @@ func (ctrler *RigoApp) BeginBlock(req abcitypes.RequestBeginBlock) abcitypes.Res
 }
 
 func (ctrler *RigoApp) deliverTxSync(req abcitypes.RequestDeliverTx) abcitypes.ResponseDeliverTx {
+	resp, fee := ctrler.executeTrx(req.Tx, ctrler.acctCtrler, func() int {
+		txIdx := ctrler.nextBlockCtx.TxsCnt()
+		ctrler.nextBlockCtx.AddTxsCnt(1)
+		return txIdx
+	})
+	if fee != nil {
+		ctrler.nextBlockCtx.AddFee(fee)
+		ctrler.nextBlockCtx.AddGasUsed(uint64(resp.GasUsed))
+	}
+	return resp
+}
 
-	txctx, xerr := rctypes.NewTrxContext(req.Tx,
+// executeTrx executes `txbz` with `acctHandler` and returns the response and the fee of the tx.
+// `txIdx` is called to get the index of the tx only when `txbz` is decoded successfully.
+// It does not change `nextBlockCtx`, so it can be called in parallel for the txs which don't conflict.
+func (ctrler *RigoApp) executeTrx(txbz []byte, acctHandler rctypes.IAccountHandler, txIdx func() int) (abcitypes.ResponseDeliverTx, *uint256.Int) {
+	txctx, xerr := rctypes.NewTrxContext(txbz,
 		ctrler.nextBlockCtx.Height(),
 		ctrler.nextBlockCtx.TimeSeconds(),
 		true,
 		func(_txctx *rctypes.TrxContext) xerrors.XError {
-			_txctx.TxIdx = ctrler.nextBlockCtx.TxsCnt()
-			ctrler.nextBlockCtx.AddTxsCnt(1)
+			_txctx.TxIdx = txIdx()
 
 			_txctx.TrxGovHandler = ctrler.govCtrler
 			_txctx.TrxAcctHandler = ctrler.acctCtrler
 			_txctx.TrxStakeHandler = ctrler.stakeCtrler
 			_txctx.TrxEVMHandler = ctrler.vmCtrler
 			_txctx.GovHandler = ctrler.govCtrler
-			_txctx.AcctHandler = ctrler.acctCtrler
+			_txctx.AcctHandler = acctHandler
 			_txctx.StakeHandler = ctrler.stakeCtrler
 			_txctx.ChainID = ctrler.rootConfig.ChainID
+			_txctx.BaseFee = ctrler.nextBlockCtx.BaseFee()
 			return nil
 		})
 	if xerr != nil {
 		xerr = xerrors.ErrDeliverTx.Wrap(xerr)
-		ctrler.logger.Error("deliverTxSync", "error", xerr)
+		ctrler.logger.Error("executeTrx", "error", xerr)
 
-		if txctx.Tx != nil {
+		resp := abcitypes.ResponseDeliverTx{
+			Code: xerr.Code(),
+			Log:  xerr.Error(),
+		}
+
+		// `txctx` is nil when the tx can not be decoded or its sender is not found.
+		if txctx != nil && txctx.Tx != nil {
 			// add event
 			txctx.Events = append(txctx.Events, abcitypes.Event{
 				Type: "tx",
@@ -356,18 +639,14 @@ func (ctrler *RigoApp) deliverTxSync(req abcitypes.RequestDeliverTx) abcitypes.R
 					{Key: []byte(rctypes.EVENT_ATTR_TXSTATUS), Value: []byte{byte(xerr.Code())}, Index: false},
 				},
 			})
+			resp.Events = txctx.Events
 		}
-
-		return abcitypes.ResponseDeliverTx{
-			Code:   xerr.Code(),
-			Log:    xerr.Error(),
-			Events: txctx.Events,
-		}
+		return resp, nil
 	}
 	xerr = ctrler.txExecutor.ExecuteSync(txctx)
 	if xerr != nil {
 		xerr = xerrors.ErrDeliverTx.Wrap(xerr)
-		ctrler.logger.Error("deliverTxSync", "error", xerr)
+		ctrler.logger.Error("executeTrx", "error", xerr)
 
 		// add event
 		txctx.Events = append(txctx.Events, abcitypes.Event{
@@ -384,11 +663,9 @@ func (ctrler *RigoApp) deliverTxSync(req abcitypes.RequestDeliverTx) abcitypes.R
 			Log:    xerr.Error(),
 			Data:   txctx.RetData, // in case of evm, there may be return data when tx is failed.
 			Events: txctx.Events,
-		}
+		}, nil
 	} else {
 
-		ctrler.nextBlockCtx.AddFee(rctypes.GasToFee(txctx.GasUsed, ctrler.govCtrler.GasPrice()))
-
 		// add event
 		txctx.Events = append(txctx.Events, abcitypes.Event{
 			Type: "tx",
@@ -408,91 +685,139 @@ func (ctrler *RigoApp) deliverTxSync(req abcitypes.RequestDeliverTx) abcitypes.R
 			GasUsed:   int64(txctx.GasUsed),
 			Data:      txctx.RetData,
 			Events:    txctx.Events,
-		}
+		}, rctypes.GasToFee(txctx.GasUsed, txctx.Tx.GasPrice)
 	}
 }
 
-// deliverTxAsync is not fully implemented yet
-// todo: Fully implement deliverTxAsync which processes txs in parallel.
+// deliverTxAsync keeps `req` to execute it with the other txs of the block at EndBlock.
+// The returned value has no meaning,
+// and the actual response is returned by takeDeliverTxResponses after EndBlock.
 func (ctrler *RigoApp) deliverTxAsync(req abcitypes.RequestDeliverTx) abcitypes.ResponseDeliverTx {
-	txIdx := ctrler.nextBlockCtx.TxsCnt()
-	ctrler.nextBlockCtx.AddTxsCnt(1)
+	ctrler.deliverTxReqs = append(ctrler.deliverTxReqs, req)
+	return abcitypes.ResponseDeliverTx{}
+}
 
-	txctx, xerr := rctypes.NewTrxContext(req.Tx,
-		ctrler.nextBlockCtx.Height(),
-		ctrler.nextBlockCtx.TimeSeconds(),
-		true,
-		func(_txctx *rctypes.TrxContext) xerrors.XError {
-			_txctx.TxIdx = txIdx
-			_txctx.TrxGovHandler = ctrler.govCtrler
-			_txctx.TrxAcctHandler = ctrler.acctCtrler
-			_txctx.TrxStakeHandler = ctrler.stakeCtrler
-			_txctx.TrxEVMHandler = ctrler.vmCtrler
-			_txctx.GovHandler = ctrler.govCtrler
-			_txctx.AcctHandler = ctrler.acctCtrler
-			_txctx.StakeHandler = ctrler.stakeCtrler
-			_txctx.ChainID = ctrler.rootConfig.ChainID
+// deliverTxsParallel executes the txs of `reqs` and returns their responses in the order of `reqs`.
+// The consecutive txs which touch only the accounts of their sender, receiver and fee payer are grouped into lanes,
+// and the lanes are executed in parallel. The other txs are executed serially.
+// The result is the same as the result of deliverTxSync for each of `reqs`.
+func (ctrler *RigoApp) deliverTxsParallel(reqs []abcitypes.RequestDeliverTx) []abcitypes.ResponseDeliverTx {
+	ltxs := make([]*laneTrx, len(reqs))
+	for i, req := range reqs {
+		ltx := &laneTrx{txbz: req.Tx, txIdx: -1}
+
+		// as in deliverTxSync, only the txs decoded successfully are counted.
+		tx := &rctypes.Trx{}
+		if xerr := tx.Decode(req.Tx); xerr == nil {
+			ltx.tx = tx
+			ltx.txIdx = ctrler.nextBlockCtx.TxsCnt()
+			ctrler.nextBlockCtx.AddTxsCnt(1)
+		}
+		ltxs[i] = ltx
+	}
 
-			// when the 'tx' is finished, it's called
-			_txctx.Callback = func(ctx *rctypes.TrxContext, xerr xerrors.XError) {
-				// it is called from executionRoutine goroutine
-				// when execution is finished or error is generated
-				response := abcitypes.ResponseDeliverTx{}
-				if xerr != nil {
-					xerr = xerrors.ErrDeliverTx.Wrap(xerr)
-					response.Code = xerr.Code()
-					response.Log = xerr.Error()
-
-				} else {
-					response.GasWanted = int64(ctx.Tx.Gas)
-					response.GasUsed = int64(ctx.GasUsed)
-					response.Data = ctx.RetData
-					response.Events = []abcitypes.Event{
-						{
-							Type: "tx",
-							Attributes: []abcitypes.EventAttribute{
-								{Key: []byte(rctypes.EVENT_ATTR_TXTYPE), Value: []byte(ctx.Tx.TypeString()), Index: true},
-								{Key: []byte(rctypes.EVENT_ATTR_TXSENDER), Value: []byte(ctx.Tx.From.String()), Index: true},
-								{Key: []byte(rctypes.EVENT_ATTR_TXRECVER), Value: []byte(ctx.Tx.To.String()), Index: true},
-								{Key: []byte(rctypes.EVENT_ATTR_ADDRPAIR), Value: []byte(ctx.Tx.From.String() + ctx.Tx.To.String()), Index: true},
-							},
-						},
-					}
-
-					ctrler.nextBlockCtx.AddFee(rctypes.GasToFee(ctx.GasUsed, ctrler.govCtrler.GasPrice()))
-				}
-				ctrler.localClient.(*rigoLocalClient).OnTrxExecFinished(ctrler.localClient, ctx.TxIdx, &req, &response)
-			}
+	for i := 0; i < len(ltxs); {
+		j := i
+		for j < len(ltxs) && ctrler.isLaneTrx(ltxs[j]) {
+			j++
+		}
+		if j == i {
+			ctrler.executeLaneTrx(ltxs[i], ctrler.acctCtrler)
+			i++
+		} else {
+			ctrler.executeLanes(ltxs[i:j])
+			i = j
+		}
+	}
 
-			return nil
-		})
-	if xerr != nil {
-		xerr = xerrors.ErrDeliverTx.Wrap(xerr)
-		response := abcitypes.ResponseDeliverTx{
-			Code: xerr.Code(),
-			Log:  xerr.Error(),
+	resps := make([]abcitypes.ResponseDeliverTx, len(ltxs))
+	for i, ltx := range ltxs {
+		if ltx.fee != nil {
+			ctrler.nextBlockCtx.AddFee(ltx.fee)
+			ctrler.nextBlockCtx.AddGasUsed(uint64(ltx.resp.GasUsed))
 		}
-		ctrler.localClient.(*rigoLocalClient).OnTrxExecFinished(ctrler.localClient, txIdx, &req, &response)
+		resps[i] = ltx.resp
 	}
+	return resps
+}
 
-	xerr = ctrler.txExecutor.ExecuteAsync(txctx)
-	if xerr != nil {
-		xerr = xerrors.ErrDeliverTx.Wrap(xerr)
-		response := abcitypes.ResponseDeliverTx{
-			Code: xerr.Code(),
-			Log:  xerr.Error(),
+// isLaneTrx returns true if `ltx` touches only the accounts of its sender, receiver and fee payer.
+// The txs executed by EVM are not lane txs, even if they call different contracts.
+// EVM runs all of them on the one StateDB of the block, which updates the accounts
+// not through the IAccountHandler of a lane, so the conflicts between lanes could not be found.
+func (ctrler *RigoApp) isLaneTrx(ltx *laneTrx) bool {
+	if ltx.tx == nil {
+		// it touches nothing.
+		return true
+	}
+	switch ltx.tx.GetType() {
+	case rctypes.TRX_SETDOC:
+		return true
+	case rctypes.TRX_TRANSFER:
+		// the transfer to a contract is executed by EVM.
+		receiver := ctrler.acctCtrler.FindAccount(ltx.tx.To, true)
+		return receiver == nil || receiver.Code == nil
+	}
+	return false
+}
+
+func (ctrler *RigoApp) executeLaneTrx(ltx *laneTrx, acctHandler rctypes.IAccountHandler) {
+	ltx.resp, ltx.fee = ctrler.executeTrx(ltx.txbz, acctHandler, func() int { return ltx.txIdx })
+}
+
+// executeLanes executes `ltxs` in parallel lanes.
+// If the lanes are found to conflict after execution, their results are discarded
+// and `ltxs` are executed again serially.
+func (ctrler *RigoApp) executeLanes(ltxs []*laneTrx) {
+	lanes := groupTrxLanes(ltxs)
+	if len(lanes) > 1 {
+		for _, lane := range lanes {
+			lane.acctHandler = newLaneAcctHandler(ctrler.acctCtrler)
+		}
+
+		xerr := ctrler.txExecutor.ExecuteLanes(lanes, func(lane *trxLane) {
+			for _, ltx := range lane.txs {
+				ctrler.executeLaneTrx(ltx, lane.acctHandler)
+			}
+		})
+		if xerr == nil && !findLaneConflict(lanes) {
+			for _, lane := range lanes {
+				if xerr := lane.acctHandler.flush(); xerr != nil {
+					ctrler.logger.Error("RigoApp", "error", xerr)
+					panic(xerr)
+				}
+			}
+			return
 		}
-		ctrler.localClient.(*rigoLocalClient).OnTrxExecFinished(ctrler.localClient, txIdx, &req, &response)
+		ctrler.logger.Info("execute txs serially because the lanes conflict", "txs", len(ltxs), "lanes", len(lanes), "error", xerr)
 	}
 
-	// this return value has no meaning
-	return abcitypes.ResponseDeliverTx{}
+	for _, ltx := range ltxs {
+		ctrler.executeLaneTrx(ltx, ctrler.acctCtrler)
+	}
+}
+
+// takeDeliverTxResponses returns the responses of the txs executed in parallel at EndBlock.
+func (ctrler *RigoApp) takeDeliverTxResponses() []abcitypes.ResponseDeliverTx {
+	ctrler.mtx.Lock()
+	defer ctrler.mtx.Unlock()
+
+	resps := ctrler.deliverTxResps
+	ctrler.deliverTxResps = nil
+	return resps
+}
+
+func (ctrler *RigoApp) parallelDeliverTx() bool {
+	return ctrler.rootConfig.App.ParallelDeliverTx
 }
 
 func (ctrler *RigoApp) DeliverTx(req abcitypes.RequestDeliverTx) abcitypes.ResponseDeliverTx {
 	ctrler.mtx.Lock()
 	defer ctrler.mtx.Unlock()
 
+	if ctrler.parallelDeliverTx() {
+		return ctrler.deliverTxAsync(req)
+	}
 	return ctrler.deliverTxSync(req)
 }
 
@@ -507,6 +832,11 @@ func (ctrler *RigoApp) EndBlock(req abcitypes.RequestEndBlock) abcitypes.Respons
 			"height", req.Height)
 	}()
 
+	if len(ctrler.deliverTxReqs) > 0 {
+		ctrler.deliverTxResps = ctrler.deliverTxsParallel(ctrler.deliverTxReqs)
+		ctrler.deliverTxReqs = nil
+	}
+
 	ev0, xerr := ctrler.govCtrler.EndBlock(ctrler.nextBlockCtx)
 	if xerr != nil {
 		ctrler.logger.Error("RigoApp", "error", xerr)
@@ -534,6 +864,17 @@ func (ctrler *RigoApp) EndBlock(req abcitypes.RequestEndBlock) abcitypes.Respons
 	ev = append(ev, ev2...)
 	ev = append(ev, ev3...)
 
+	if plan := ctrler.govCtrler.UpgradePlan(); plan != nil {
+		ctrler.applyUpgrade(plan)
+		ev = append(ev, abcitypes.Event{
+			Type: rctypes.EVENT_TYPE_UPGRADE,
+			Attributes: []abcitypes.EventAttribute{
+				{Key: []byte(rctypes.EVENT_ATTR_NAME), Value: []byte(plan.Name), Index: true},
+				{Key: []byte(rctypes.EVENT_ATTR_HEIGHT), Value: []byte(strconv.FormatInt(plan.Height, 10)), Index: false},
+			},
+		})
+	}
+
 	return abcitypes.ResponseEndBlock{
 		ValidatorUpdates: ctrler.nextBlockCtx.ValUpdates,
 		Events:           ev,
@@ -546,6 +887,13 @@ func (ctrler *RigoApp) Commit() abcitypes.ResponseCommit {
 
 	ctrler.logger.Debug("RigoApp::Commit", "height", ctrler.nextBlockCtx.Height())
 
+	// The ledgers are committed one by one.
+	// If the node crashes before all of them are committed,
+	// the marker lets Info() roll back the ledgers which are already committed.
+	if err := ctrler.metaDB.PutCommittingHeight(ctrler.nextBlockCtx.Height()); err != nil {
+		panic(err)
+	}
+
 	appHash0, ver0, err := ctrler.govCtrler.Commit()
 	if err != nil {
 		panic(err)
@@ -574,15 +922,37 @@ func (ctrler *RigoApp) Commit() abcitypes.ResponseCommit {
 		panic(fmt.Sprintf("Not same versions: gov: %v, account:%v, stake:%v, vm:%v", ver0, ver1, ver2, ver3))
 	}
 
-	appHash := crypto.DefaultHash(appHash0, appHash1, appHash2, appHash3)
+	if xerr := ctrler.commitSupply(ctrler.nextBlockCtx); xerr != nil {
+		panic(xerr)
+	}
+
+	appHash := appRootHashes(appHash0, appHash1, appHash2, appHash3).Hash()
 	ctrler.nextBlockCtx.SetAppHash(appHash)
 	ctrler.logger.Debug("RigoApp::Commit", "height", ver0, "txs", ctrler.nextBlockCtx.TxsCnt(), "app hash", ctrler.nextBlockCtx.AppHash())
 
+	// the upgrade is recorded before the block context,
+	// so it is not lost when the node crashes after the block context is saved.
+	if ctrler.appliedUpgrade != nil {
+		if err := ctrler.metaDB.PutUpgrade(ctrler.appliedUpgrade); err != nil {
+			panic(err)
+		}
+		ctrler.appliedUpgrade = nil
+	}
+
 	ctrler.metaDB.PutLastBlockContext(ctrler.nextBlockCtx)
 	ctrler.metaDB.PutLastBlockHeight(ver0)
+	if err := ctrler.metaDB.DelCommittingHeight(); err != nil {
+		panic(err)
+	}
 
 	ctrler.lastBlockCtx = ctrler.nextBlockCtx
 	ctrler.nextBlockCtx = nil
+	ctrler.checkAcctHandler = newCheckAcctHandler(ctrler.acctCtrler)
+	ctrler.replacedTxs.prune(ctrler.acctCtrler)
+
+	if interval := ctrler.rootConfig.App.SnapshotInterval; interval > 0 && ver0%interval == 0 {
+		ctrler.takeSnapshot(ver0)
+	}
 
 	return abcitypes.ResponseCommit{
 		Data: appHash[:],
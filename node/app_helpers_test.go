@@ -0,0 +1,140 @@
+package node
+
+import (
+	"github.com/holiman/uint256"
+	cfg "github.com/rigochain/rigo-go/cmd/config"
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/genesis"
+	"github.com/rigochain/rigo-go/libs/web3"
+	"github.com/rigochain/rigo-go/types/crypto"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testChainID = "rigo_app_test_chain"
+
+// testGenesisTime is the time of the genesis of the test chains.
+// It is close to the local time, since the time of a tx is checked against the block time.
+var testGenesisTime = time.Now().Unix()
+
+type testValidator struct {
+	pubBytes []byte
+	addr     []byte
+	power    int64
+	// the wallet of the validator's key, which signs the txs of the validator.
+	wallet *web3.Wallet
+}
+
+func newTestRigoApp(t *testing.T, name string, setup func(*cfg.Config)) *RigoApp {
+	rootDir := filepath.Join(os.TempDir(), "rigo-app-test-"+name)
+	require.NoError(t, os.RemoveAll(rootDir))
+	t.Cleanup(func() { _ = os.RemoveAll(rootDir) })
+
+	config := cfg.DefaultConfig()
+	config.SetRoot(rootDir)
+	if setup != nil {
+		setup(config)
+	}
+
+	app := NewRigoApp(config, log.NewNopLogger())
+	require.NoError(t, app.Start())
+	return app
+}
+
+func newTestValidator(t *testing.T, power int64) *testValidator {
+	prvKey := secp256k1.GenPrivKey()
+	pubBytes := prvKey.PubKey().Bytes()
+	addr, xerr := crypto.PubBytes2Addr(pubBytes)
+	require.NoError(t, xerr)
+	return &testValidator{pubBytes: pubBytes, addr: addr, power: power, wallet: web3.ImportKey(prvKey, nil)}
+}
+
+// initTestChain initializes `app` with the validator `val` and the genesis accounts of `holders`.
+func initTestChain(t *testing.T, app *RigoApp, val *testValidator, holders ...*web3.Wallet) {
+	initTestChainWith(t, app, val, ctrlertypes.DefaultGovParams(), holders...)
+}
+
+// initTestChainWith is the same as initTestChain except that the chain starts with `govParams`.
+func initTestChainWith(t *testing.T, app *RigoApp, val *testValidator, govParams *ctrlertypes.GovParams, holders ...*web3.Wallet) {
+	var assetHolders []*genesis.GenesisAssetHolder
+	for _, w := range holders {
+		assetHolders = append(assetHolders, &genesis.GenesisAssetHolder{
+			Address: w.Address(),
+			Balance: uint256.NewInt(0).Mul(uint256.NewInt(1_000_000_000), uint256.NewInt(1_000_000_000_000_000_000)),
+		})
+	}
+	initTestChainWithHolders(t, app, val, govParams, assetHolders...)
+}
+
+// initTestChainWithHolders initializes `app` with the validator `val` and the genesis asset holders.
+func initTestChainWithHolders(t *testing.T, app *RigoApp, val *testValidator, govParams *ctrlertypes.GovParams, holders ...*genesis.GenesisAssetHolder) {
+	require.EqualValues(t, 0, app.Info(abcitypes.RequestInfo{}).LastBlockHeight)
+
+	appState := genesis.GenesisAppState{
+		AssetHolders: holders,
+		GovParams:    govParams,
+	}
+	bz, err := tmjson.Marshal(appState)
+	require.NoError(t, err)
+
+	app.InitChain(abcitypes.RequestInitChain{
+		ChainId:       testChainID,
+		Validators:    []abcitypes.ValidatorUpdate{abcitypes.UpdateValidator(val.pubBytes, val.power, "secp256k1")},
+		AppStateBytes: bz,
+	})
+}
+
+func testBeginBlockReq(height int64, val *testValidator) abcitypes.RequestBeginBlock {
+	req := abcitypes.RequestBeginBlock{
+		Header: tmproto.Header{
+			ChainID:         testChainID,
+			Height:          height,
+			Time:            time.Unix(testGenesisTime+height, 0),
+			ProposerAddress: val.addr,
+		},
+	}
+	if height > 1 {
+		// the first block has no last commit.
+		req.LastCommitInfo.Votes = []abcitypes.VoteInfo{
+			{
+				Validator:       abcitypes.Validator{Address: val.addr, Power: val.power},
+				SignedLastBlock: true,
+			},
+		}
+	}
+	return req
+}
+
+// execTestBlock executes the block of `height` proposed and signed by `val` and returns the app hash.
+func execTestBlock(t *testing.T, app *RigoApp, height int64, val *testValidator, txs ...[]byte) []byte {
+	app.BeginBlock(testBeginBlockReq(height, val))
+	for _, tx := range txs {
+		resp := app.DeliverTx(abcitypes.RequestDeliverTx{Tx: tx})
+		require.Equal(t, abcitypes.CodeTypeOK, resp.Code, resp.Log)
+	}
+	app.EndBlock(abcitypes.RequestEndBlock{Height: height})
+	return app.Commit().Data
+}
+
+// newTestTransfer returns the encoded transfer tx signed by `from`.
+func newTestTransfer(t *testing.T, from, to *web3.Wallet, nonce uint64) []byte {
+	govParams := ctrlertypes.DefaultGovParams()
+	return signTestTrx(t, from, web3.NewTrxTransfer(from.Address(), to.Address(), nonce, govParams.MinTrxGas(), govParams.GasPrice(), uint256.NewInt(1000)))
+}
+
+// signTestTrx returns the encoded `tx` signed by `w`.
+func signTestTrx(t *testing.T, w *web3.Wallet, tx *ctrlertypes.Trx) []byte {
+	_, _, err := w.SignTrxRLP(tx, testChainID)
+	require.NoError(t, err)
+	bz, err := tx.Encode()
+	require.NoError(t, err)
+	return bz
+}
@@ -0,0 +1,67 @@
+package node
+
+import (
+	"github.com/holiman/uint256"
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/libs/web3"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"testing"
+)
+
+func TestBaseFee(t *testing.T) {
+	app := newTestRigoApp(t, "base-fee", nil)
+	defer func() { _ = app.Stop() }()
+
+	govParams := ctrlertypes.Test8GovParams_BaseFee()
+	val := newTestValidator(t, 10_000_000)
+	w0, w1 := web3.NewWallet(nil), web3.NewWallet(nil)
+	initTestChainWith(t, app, val, govParams, w0)
+
+	gas := govParams.MinTrxGas()
+	newTransfer := func(nonce uint64, gasPrice *uint256.Int) []byte {
+		return signTestTrx(t, w0, web3.NewTrxTransfer(w0.Address(), w1.Address(), nonce, gas, gasPrice, uint256.NewInt(1000)))
+	}
+
+	// the block 1 uses twice the target gas.
+	execTestBlock(t, app, 1, val, newTransfer(0, govParams.GasPrice()), newTransfer(1, govParams.GasPrice()))
+	require.Equal(t, govParams.GasPrice(), app.lastBlockCtx.BaseFee())
+	require.Equal(t, 2*gas, app.lastBlockCtx.GasUsed())
+
+	baseFee := new(uint256.Int).Mul(govParams.GasPrice(), uint256.NewInt(112))
+	baseFee = baseFee.Div(baseFee, uint256.NewInt(100))
+
+	// the gas price less than the base fee is rejected.
+	resp := app.CheckTx(abcitypes.RequestCheckTx{Tx: newTransfer(2, govParams.GasPrice())})
+	require.Equal(t, xerrors.ErrCheckTx.Code(), resp.Code, resp.Log)
+
+	gasPrice := new(uint256.Int).Add(baseFee, uint256.NewInt(1_000_000_000))
+	app.BeginBlock(testBeginBlockReq(2, val))
+	require.Equal(t, baseFee, app.nextBlockCtx.BaseFee())
+	respDeliver := app.DeliverTx(abcitypes.RequestDeliverTx{Tx: newTransfer(2, govParams.GasPrice())})
+	require.Equal(t, xerrors.ErrDeliverTx.Code(), respDeliver.Code, respDeliver.Log)
+	respDeliver = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: newTransfer(2, gasPrice)})
+	require.Equal(t, abcitypes.CodeTypeOK, respDeliver.Code, respDeliver.Log)
+	app.EndBlock(abcitypes.RequestEndBlock{Height: 2})
+	app.Commit()
+
+	// the base fee is burned and the tip is given to the proposer.
+	dist, xerr := app.acctCtrler.FeeDistributionAt(2)
+	require.NoError(t, xerr)
+	require.Equal(t, ctrlertypes.GasToFee(gas, gasPrice), dist.Total)
+	require.Equal(t, ctrlertypes.GasToFee(gas, baseFee), dist.Burned)
+	require.Equal(t, ctrlertypes.GasToFee(gas, uint256.NewInt(1_000_000_000)), dist.Tip)
+	require.Equal(t, dist.Tip, dist.Proposer)
+
+	// the block 2 uses the target gas, so the base fee is not changed.
+	require.Equal(t, baseFee, ctrlertypes.NextBaseFee(app.lastBlockCtx.BaseFee(), app.lastBlockCtx.GasUsed(), app.govCtrler))
+
+	// the empty blocks lower the base fee to the gas price.
+	for h := int64(3); h <= 5; h++ {
+		execTestBlock(t, app, h, val)
+	}
+	qresp := app.Query(abcitypes.RequestQuery{Path: "base_fee"})
+	require.Equal(t, abcitypes.CodeTypeOK, qresp.Code, qresp.Log)
+	require.Contains(t, string(qresp.Value), `"baseFee":"`+govParams.GasPrice().Dec()+`"`)
+}
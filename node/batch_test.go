@@ -0,0 +1,78 @@
+package node
+
+import (
+	"github.com/holiman/uint256"
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/libs/web3"
+	"github.com/rigochain/rigo-go/types"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"testing"
+)
+
+func TestBatchTransfer(t *testing.T) {
+	app := newTestRigoApp(t, "batch", nil)
+	defer func() { _ = app.Stop() }()
+
+	val := newTestValidator(t, 1_000_000)
+	w0 := web3.NewWallet(nil)
+	initTestChain(t, app, val, w0)
+	balance0 := new(uint256.Int).Mul(uint256.NewInt(1_000_000_000), uint256.NewInt(1_000_000_000_000_000_000))
+
+	govParams := ctrlertypes.DefaultGovParams()
+	addrs := []types.Address{types.RandAddress(), types.RandAddress()}
+	recipients := []*ctrlertypes.BatchRecipient{
+		{To: addrs[0], Amount: uint256.NewInt(1000)},
+		{To: addrs[1], Amount: uint256.NewInt(2000)},
+		{To: addrs[0], Amount: uint256.NewInt(3000)},
+	}
+
+	app.BeginBlock(testBeginBlockReq(1, val))
+
+	// the fee is charged per recipient.
+	tx := web3.NewTrxBatch(w0.Address(), 0, govParams.MinTrxGas(), govParams.GasPrice(), recipients...)
+	tx.Gas = govParams.MinTrxGas() * 2
+	resp := app.DeliverTx(abcitypes.RequestDeliverTx{Tx: signTestTrx(t, w0, tx)})
+	require.NotEqual(t, abcitypes.CodeTypeOK, resp.Code)
+
+	// `Amount` should be the sum of the amounts to the recipients.
+	tx = web3.NewTrxBatch(w0.Address(), 0, govParams.MinTrxGas(), govParams.GasPrice(), recipients...)
+	tx.Amount = uint256.NewInt(5000)
+	resp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: signTestTrx(t, w0, tx)})
+	require.NotEqual(t, abcitypes.CodeTypeOK, resp.Code)
+
+	// the sender can not be a recipient.
+	tx = web3.NewTrxBatch(w0.Address(), 0, govParams.MinTrxGas(), govParams.GasPrice(),
+		append(recipients, &ctrlertypes.BatchRecipient{To: w0.Address(), Amount: uint256.NewInt(1)})...)
+	resp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: signTestTrx(t, w0, tx)})
+	require.NotEqual(t, abcitypes.CodeTypeOK, resp.Code)
+
+	tx = web3.NewTrxBatch(w0.Address(), 0, govParams.MinTrxGas(), govParams.GasPrice(), recipients...)
+	resp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: signTestTrx(t, w0, tx)})
+	require.Equal(t, abcitypes.CodeTypeOK, resp.Code, resp.Log)
+	require.EqualValues(t, govParams.MinTrxGas()*3, resp.GasUsed)
+
+	var credits []abcitypes.Event
+	for _, evt := range resp.Events {
+		if evt.Type == ctrlertypes.EVENT_TYPE_BATCH_TRANSFER {
+			credits = append(credits, evt)
+		}
+	}
+	require.Len(t, credits, len(recipients))
+	for i, evt := range credits {
+		require.Equal(t, recipients[i].To.String(), string(evt.Attributes[1].Value))
+		require.Equal(t, recipients[i].Amount.Dec(), string(evt.Attributes[2].Value))
+	}
+
+	app.EndBlock(abcitypes.RequestEndBlock{Height: 1})
+	app.Commit()
+
+	require.EqualValues(t, 4000, app.acctCtrler.ReadAccount(addrs[0]).Balance.Uint64())
+	require.EqualValues(t, 2000, app.acctCtrler.ReadAccount(addrs[1]).Balance.Uint64())
+
+	// only the successful batch has changed the balance of the sender.
+	spent := new(uint256.Int).Mul(govParams.MinTrxFee(), uint256.NewInt(3))
+	_ = spent.Add(spent, uint256.NewInt(6000))
+	require.Equal(t, new(uint256.Int).Sub(balance0, spent), app.acctCtrler.ReadAccount(w0.Address()).Balance)
+	require.EqualValues(t, 1, app.acctCtrler.ReadAccount(w0.Address()).GetNonce())
+}
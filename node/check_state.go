@@ -0,0 +1,143 @@
+package node
+
+import (
+	"github.com/holiman/uint256"
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/ledger"
+	"github.com/rigochain/rigo-go/types"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	"sync"
+)
+
+// checkAcctHandler is the IAccountHandler used by CheckTx.
+// It keeps the copies of the accounts updated by the txs accepted into the mempool
+// on top of the last committed state,
+// so that the pending nonce and balance of a sender are applied to the next txs of the sender.
+// It is replaced with a new one after Commit, and the txs left in the mempool are rechecked against it.
+type checkAcctHandler struct {
+	ctrlertypes.IAccountHandler
+
+	accts map[ledger.LedgerKey]*ctrlertypes.Account
+	// the txs accepted into the mempool by their sender and nonce.
+	pending map[string]*pendingTrx
+	// the txs rechecked ahead of the pending nonce of their sender.
+	// The mempool v1 rechecks the txs concurrently, so the txs of a sender may be rechecked out of the order of their nonces.
+	queued map[string]*pendingTrx
+	mtx    sync.Mutex
+}
+
+func newCheckAcctHandler(acctHandler ctrlertypes.IAccountHandler) *checkAcctHandler {
+	return &checkAcctHandler{
+		IAccountHandler: acctHandler,
+		accts:           make(map[ledger.LedgerKey]*ctrlertypes.Account),
+		pending:         make(map[string]*pendingTrx),
+		queued:          make(map[string]*pendingTrx),
+	}
+}
+
+func (handler *checkAcctHandler) findAccount(addr types.Address) *ctrlertypes.Account {
+	k := ledger.ToLedgerKey(addr)
+	if acct, ok := handler.accts[k]; ok {
+		return acct
+	}
+
+	// the account is read from the last committed state.
+	acct := handler.IAccountHandler.FindAccount(addr, false)
+	if acct == nil {
+		return nil
+	}
+	acct = acct.Clone()
+	handler.accts[k] = acct
+	return acct
+}
+
+func (handler *checkAcctHandler) FindOrNewAccount(addr types.Address, exec bool) *ctrlertypes.Account {
+	handler.mtx.Lock()
+	defer handler.mtx.Unlock()
+
+	if acct := handler.findAccount(addr); acct != nil {
+		return acct
+	}
+
+	newAcct := ctrlertypes.NewAccountWithName(addr, "")
+	handler.accts[ledger.ToLedgerKey(addr)] = newAcct
+	return newAcct
+}
+
+func (handler *checkAcctHandler) FindAccount(addr types.Address, exec bool) *ctrlertypes.Account {
+	handler.mtx.Lock()
+	defer handler.mtx.Unlock()
+
+	return handler.findAccount(addr)
+}
+
+func (handler *checkAcctHandler) Transfer(from, to types.Address, amt *uint256.Int, exec bool) xerrors.XError {
+	handler.mtx.Lock()
+	defer handler.mtx.Unlock()
+
+	acct0 := handler.findAccount(from)
+	if acct0 == nil {
+		return xerrors.ErrNotFoundAccount.Wrapf("Transfer - address: %v", from)
+	}
+	acct1 := handler.findAccount(to)
+	if acct1 == nil {
+		acct1 = ctrlertypes.NewAccountWithName(to, "")
+	}
+	if xerr := acct0.SubBalance(amt); xerr != nil {
+		return xerr
+	}
+	if xerr := acct1.AddBalance(amt); xerr != nil {
+		_ = acct0.AddBalance(amt) // refund
+		return xerr
+	}
+
+	handler.accts[ledger.ToLedgerKey(to)] = acct1
+	return nil
+}
+
+func (handler *checkAcctHandler) Reward(to types.Address, amt *uint256.Int, exec bool) xerrors.XError {
+	handler.mtx.Lock()
+	defer handler.mtx.Unlock()
+
+	acct := handler.findAccount(to)
+	if acct == nil {
+		return xerrors.ErrNotFoundAccount.Wrapf("Reward - address: %v", to)
+	}
+	return acct.AddBalance(amt)
+}
+
+func (handler *checkAcctHandler) SetAccountCommittable(acct *ctrlertypes.Account, exec bool) xerrors.XError {
+	handler.mtx.Lock()
+	defer handler.mtx.Unlock()
+
+	handler.accts[ledger.ToLedgerKey(acct.Address)] = acct
+	return nil
+}
+
+var _ ctrlertypes.IAccountHandler = (*checkAcctHandler)(nil)
+
+// postCheckTrx applies the fee and the nonce of the tx executed by the EVM to the sender's pending state.
+// The EVM executes a tx only on DeliverTx, so without it the next tx of the sender would be rejected by its nonce.
+// The fee is charged by the gas limit, because the gas used is not known until the tx is delivered.
+func postCheckTrx(ctx *ctrlertypes.TrxContext) xerrors.XError {
+	if ctx.Tx.GetType() != ctrlertypes.TRX_CONTRACT &&
+		!(ctx.Tx.GetType() == ctrlertypes.TRX_TRANSFER && ctx.Receiver.Code != nil) {
+		return nil
+	}
+
+	fee := new(uint256.Int).Mul(ctx.Tx.GasPrice, uint256.NewInt(ctx.Tx.Gas))
+	if ctx.Payer != nil {
+		if xerr := ctx.Payer.SubBalance(fee); xerr != nil {
+			return xerr
+		}
+		if xerr := ctx.AcctHandler.SetAccountCommittable(ctx.Payer, ctx.Exec); xerr != nil {
+			return xerr
+		}
+		_ = fee.Clear()
+	}
+	if xerr := ctx.Sender.SubBalance(new(uint256.Int).Add(fee, ctx.Tx.Amount)); xerr != nil {
+		return xerr
+	}
+	ctx.Sender.AddNonce()
+	return ctx.AcctHandler.SetAccountCommittable(ctx.Sender, ctx.Exec)
+}
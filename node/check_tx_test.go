@@ -0,0 +1,307 @@
+package node
+
+import (
+	"github.com/holiman/uint256"
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/libs/web3"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	tmcfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/mempool"
+	mempoolv1 "github.com/tendermint/tendermint/mempool/v1"
+	"github.com/tendermint/tendermint/proxy"
+	tmtypes "github.com/tendermint/tendermint/types"
+	"testing"
+	"time"
+)
+
+func TestCheckTx_PendingNonce(t *testing.T) {
+	app := newTestRigoApp(t, "check-tx", nil)
+	defer func() { _ = app.Stop() }()
+
+	val := newTestValidator(t, 1_000_000)
+	w0, w1 := web3.NewWallet(nil), web3.NewWallet(nil)
+	initTestChain(t, app, val, w0)
+	execTestBlock(t, app, 1, val, newTestTransfer(t, w0, w1, 0))
+
+	checkTx := func(tx []byte, typ abcitypes.CheckTxType) uint32 {
+		return app.CheckTx(abcitypes.RequestCheckTx{Tx: tx, Type: typ}).Code
+	}
+
+	// the txs of a sender are accepted in the order of their nonces.
+	txs := make(map[uint64][]byte)
+	for nonce := uint64(1); nonce <= 3; nonce++ {
+		txs[nonce] = newTestTransfer(t, w0, w1, nonce)
+		require.Equal(t, abcitypes.CodeTypeOK, checkTx(txs[nonce], abcitypes.CheckTxType_New), "nonce", nonce)
+	}
+	require.NotEqual(t, abcitypes.CodeTypeOK, checkTx(newTestTransfer(t, w0, w1, 1), abcitypes.CheckTxType_New))
+	require.NotEqual(t, abcitypes.CodeTypeOK, checkTx(newTestTransfer(t, w0, w1, 5), abcitypes.CheckTxType_New))
+	// the rejected txs don't change the pending state.
+	txs[4] = newTestTransfer(t, w0, w1, 4)
+	require.Equal(t, abcitypes.CodeTypeOK, checkTx(txs[4], abcitypes.CheckTxType_New))
+
+	// the block includes the txs of the nonces 1 and 2.
+	execTestBlock(t, app, 2, val, txs[1], txs[2])
+
+	// the check-state is reset to the committed state, and the txs left in the mempool are rechecked.
+	// A tx rechecked before the txs of the lower nonces is queued, and it is applied after them.
+	require.Equal(t, abcitypes.CodeTypeOK, checkTx(txs[4], abcitypes.CheckTxType_Recheck))
+	require.EqualValues(t, 3, app.checkAcctHandler.FindAccount(w0.Address(), false).GetNonce())
+	require.Equal(t, abcitypes.CodeTypeOK, checkTx(txs[3], abcitypes.CheckTxType_Recheck))
+	require.EqualValues(t, 5, app.checkAcctHandler.FindAccount(w0.Address(), false).GetNonce())
+	require.Equal(t, abcitypes.CodeTypeOK, checkTx(txs[4], abcitypes.CheckTxType_Recheck))
+	require.EqualValues(t, 5, app.checkAcctHandler.FindAccount(w0.Address(), false).GetNonce())
+
+	// the stale tx is evicted by the recheck.
+	execTestBlock(t, app, 3, val, txs[3], txs[4])
+	require.NotEqual(t, abcitypes.CodeTypeOK, checkTx(txs[4], abcitypes.CheckTxType_Recheck))
+	require.Equal(t, abcitypes.CodeTypeOK, checkTx(newTestTransfer(t, w0, w1, 5), abcitypes.CheckTxType_New))
+}
+
+func TestCheckTx_BadSig(t *testing.T) {
+	app := newTestRigoApp(t, "check-tx-bad-sig", nil)
+	defer func() { _ = app.Stop() }()
+
+	val := newTestValidator(t, 1_000_000)
+	w0, w1 := web3.NewWallet(nil), web3.NewWallet(nil)
+	initTestChain(t, app, val, w0)
+	execTestBlock(t, app, 1, val)
+
+	govParams := ctrlertypes.DefaultGovParams()
+	checkTx := func(tx []byte, typ abcitypes.CheckTxType) uint32 {
+		return app.CheckTx(abcitypes.RequestCheckTx{Tx: tx, Type: typ}).Code
+	}
+	pendingAcct := func() *ctrlertypes.Account {
+		return app.checkAcctHandler.FindAccount(w0.Address(), false)
+	}
+	balance := pendingAcct().Balance.Clone()
+
+	// the tx of `w0` signed by `w1`.
+	forged := signTestTrx(t, w1, web3.NewTrxTransfer(w0.Address(), w1.Address(), 0, govParams.MinTrxGas(), govParams.GasPrice(), uint256.NewInt(1000)))
+	// the tx of `w1` whose fee is paid by `w0`, but not signed by `w0`.
+	tx := web3.SetTrxPayer(web3.NewTrxSetDoc(w1.Address(), 0, govParams.MinTrxGas(), govParams.GasPrice(), "w1", "https://w1.doc"), w0.Address())
+	_, _, err := w1.SignTrxRLP(tx, testChainID)
+	require.NoError(t, err)
+	_, _, err = w1.SignTrxPayerRLP(tx, testChainID)
+	require.NoError(t, err)
+	forgedPayer, xerr := tx.Encode()
+	require.NoError(t, xerr)
+
+	for _, typ := range []abcitypes.CheckTxType{abcitypes.CheckTxType_New, abcitypes.CheckTxType_Recheck} {
+		require.NotEqual(t, abcitypes.CodeTypeOK, checkTx(forged, typ))
+		require.NotEqual(t, abcitypes.CodeTypeOK, checkTx(forgedPayer, typ))
+		require.EqualValues(t, 0, pendingAcct().GetNonce())
+		require.Equal(t, balance, pendingAcct().Balance)
+	}
+
+	// the tx signed by `w0` is accepted at the nonce the forged tx has used.
+	require.Equal(t, abcitypes.CodeTypeOK, checkTx(newTestTransfer(t, w0, w1, 0), abcitypes.CheckTxType_New))
+	require.EqualValues(t, 1, pendingAcct().GetNonce())
+}
+
+func TestCheckTx_Replace(t *testing.T) {
+	app := newTestRigoApp(t, "check-tx-replace", nil)
+	defer func() { _ = app.Stop() }()
+
+	val := newTestValidator(t, 1_000_000)
+	w0, w1 := web3.NewWallet(nil), web3.NewWallet(nil)
+	initTestChain(t, app, val, w0)
+	execTestBlock(t, app, 1, val, newTestTransfer(t, w0, w1, 0))
+
+	govParams := ctrlertypes.DefaultGovParams()
+	newTransfer := func(nonce uint64, gasPrice *uint256.Int) []byte {
+		return signTestTrx(t, w0, web3.NewTrxTransfer(w0.Address(), w1.Address(), nonce, govParams.MinTrxGas(), gasPrice, uint256.NewInt(1000)))
+	}
+	bumpedPrice := func(ratio uint64) *uint256.Int {
+		p := new(uint256.Int).Mul(govParams.GasPrice(), uint256.NewInt(100+ratio))
+		return p.Div(p, uint256.NewInt(100))
+	}
+
+	tx1, tx2 := newTestTransfer(t, w0, w1, 1), newTestTransfer(t, w0, w1, 2)
+	resp := app.CheckTx(abcitypes.RequestCheckTx{Tx: tx1, Type: abcitypes.CheckTxType_New})
+	require.Equal(t, abcitypes.CodeTypeOK, resp.Code, resp.Log)
+	require.Equal(t, w0.Address().String()+"/1", resp.Sender)
+	require.EqualValues(t, govParams.GasPrice().Uint64(), resp.Priority)
+	resp = app.CheckTx(abcitypes.RequestCheckTx{Tx: tx2, Type: abcitypes.CheckTxType_New})
+	require.Equal(t, abcitypes.CodeTypeOK, resp.Code, resp.Log)
+	balance := app.checkAcctHandler.FindAccount(w0.Address(), false).Balance.Clone()
+
+	// the gas price is not bumped enough.
+	resp = app.CheckTx(abcitypes.RequestCheckTx{Tx: newTransfer(1, bumpedPrice(5)), Type: abcitypes.CheckTxType_New})
+	require.NotEqual(t, abcitypes.CodeTypeOK, resp.Code)
+	require.NotEmpty(t, resp.MempoolError)
+
+	// the tx of the nonce 1 is accepted as a replacement, and the replaced tx is recorded.
+	// The pending state keeps the replaced tx until the next block.
+	// The replacing tx has no sender in the mempool, because the replaced tx is still there.
+	tx1r := newTransfer(1, bumpedPrice(10))
+	resp = app.CheckTx(abcitypes.RequestCheckTx{Tx: tx1r, Type: abcitypes.CheckTxType_New})
+	require.Equal(t, abcitypes.CodeTypeOK, resp.Code, resp.Log)
+	require.Empty(t, resp.Sender)
+	require.Greater(t, resp.Priority, int64(govParams.GasPrice().Uint64()))
+	require.Equal(t, balance, app.checkAcctHandler.FindAccount(w0.Address(), false).Balance)
+	require.EqualValues(t, tmtypes.Tx(tx1).Hash(), app.checkAcctHandler.pendingTrx(w0.Address(), 1).txhash)
+	require.Len(t, app.replacedTxs.txs, 1)
+	tx3 := newTestTransfer(t, w0, w1, 3)
+	resp = app.CheckTx(abcitypes.RequestCheckTx{Tx: tx3, Type: abcitypes.CheckTxType_New})
+	require.Equal(t, abcitypes.CodeTypeOK, resp.Code, resp.Log)
+
+	// the replaced tx is evicted by the first recheck after an empty block,
+	// and the replacement takes its place.
+	execTestBlock(t, app, 2, val)
+	require.NotEqual(t, abcitypes.CodeTypeOK, app.CheckTx(abcitypes.RequestCheckTx{Tx: tx1, Type: abcitypes.CheckTxType_Recheck}).Code)
+	for _, tx := range [][]byte{tx2, tx1r, tx3} {
+		require.Equal(t, abcitypes.CodeTypeOK, app.CheckTx(abcitypes.RequestCheckTx{Tx: tx, Type: abcitypes.CheckTxType_Recheck}).Code)
+	}
+
+	// only the difference of the fee is charged on the pending balance, and the pending nonce is kept.
+	diff := new(uint256.Int).Sub(bumpedPrice(10), govParams.GasPrice())
+	_ = diff.Mul(diff, uint256.NewInt(govParams.MinTrxGas()))
+	cost3 := new(uint256.Int).Add(ctrlertypes.GasToFee(govParams.MinTrxGas(), govParams.GasPrice()), uint256.NewInt(1000))
+	balance = balance.Sub(balance, diff)
+	require.Equal(t, balance.Sub(balance, cost3), app.checkAcctHandler.FindAccount(w0.Address(), false).Balance)
+	require.EqualValues(t, 4, app.checkAcctHandler.FindAccount(w0.Address(), false).GetNonce())
+	require.Empty(t, app.replacedTxs.txs)
+
+	// the replaced tx included by a block invalidates the replacing tx by its nonce.
+	tx4, tx4r := newTransfer(4, govParams.GasPrice()), newTransfer(4, bumpedPrice(10))
+	require.Equal(t, abcitypes.CodeTypeOK, app.CheckTx(abcitypes.RequestCheckTx{Tx: tx4, Type: abcitypes.CheckTxType_New}).Code)
+	require.Equal(t, abcitypes.CodeTypeOK, app.CheckTx(abcitypes.RequestCheckTx{Tx: tx4r, Type: abcitypes.CheckTxType_New}).Code)
+	execTestBlock(t, app, 3, val, tx1r, tx2, tx3, tx4)
+	require.NotEqual(t, abcitypes.CodeTypeOK, app.CheckTx(abcitypes.RequestCheckTx{Tx: tx4r, Type: abcitypes.CheckTxType_Recheck}).Code)
+	require.Empty(t, app.replacedTxs.txs)
+}
+
+func TestCheckTx_MempoolV1(t *testing.T) {
+	app := newTestRigoApp(t, "check-tx-mempool-v1", nil)
+	defer func() { _ = app.Stop() }()
+
+	val := newTestValidator(t, 1_000_000)
+	w0, w1 := web3.NewWallet(nil), web3.NewWallet(nil)
+	initTestChain(t, app, val, w0)
+	execTestBlock(t, app, 1, val, newTestTransfer(t, w0, w1, 0))
+
+	conns := proxy.NewAppConns(proxy.NewLocalClientCreator(app))
+	require.NoError(t, conns.Start())
+	defer func() { _ = conns.Stop() }()
+	mp := mempoolv1.NewTxMempool(log.NewNopLogger(), tmcfg.TestMempoolConfig(), conns.Mempool(), 1)
+	mp.EnableTxsAvailable()
+
+	checkTx := func(tx []byte) *abcitypes.ResponseCheckTx {
+		var resp *abcitypes.ResponseCheckTx
+		require.NoError(t, mp.CheckTx(tx, func(r *abcitypes.Response) { resp = r.GetCheckTx() }, mempool.TxInfo{}))
+		return resp
+	}
+	// commits the block of `txs` and waits for the txs left in the mempool to be rechecked.
+	commitBlock := func(height int64, txs ...tmtypes.Tx) {
+		select {
+		case <-mp.TxsAvailable():
+		default:
+		}
+
+		var bzs [][]byte
+		var resps []*abcitypes.ResponseDeliverTx
+		for _, tx := range txs {
+			bzs = append(bzs, tx)
+			resps = append(resps, &abcitypes.ResponseDeliverTx{Code: abcitypes.CodeTypeOK})
+		}
+		execTestBlock(t, app, height, val, bzs...)
+
+		mp.Lock()
+		require.NoError(t, mp.Update(height, txs, resps, nil, nil))
+		mp.Unlock()
+		if mp.Size() > 0 {
+			select {
+			case <-mp.TxsAvailable():
+			case <-time.After(10 * time.Second):
+				require.FailNow(t, "recheck timeout")
+			}
+		}
+	}
+
+	govParams := ctrlertypes.DefaultGovParams()
+	newTransfer := func(nonce uint64, ratio uint64) []byte {
+		gasPrice := new(uint256.Int).Mul(govParams.GasPrice(), uint256.NewInt(100+ratio))
+		_ = gasPrice.Div(gasPrice, uint256.NewInt(100))
+		return signTestTrx(t, w0, web3.NewTrxTransfer(w0.Address(), w1.Address(), nonce, govParams.MinTrxGas(), gasPrice, uint256.NewInt(1000)))
+	}
+
+	// the txs of a sender are accepted at once,
+	// even if the tx of a higher nonce pays the higher gas price.
+	tx1, tx2, tx3 := newTransfer(1, 0), newTransfer(2, 0), newTransfer(3, 50)
+	for _, tx := range [][]byte{tx1, tx2, tx3} {
+		resp := checkTx(tx)
+		require.Equal(t, abcitypes.CodeTypeOK, resp.Code, resp.Log)
+	}
+	require.Equal(t, 3, mp.Size())
+
+	// the replacing tx is added to the mempool with the replaced tx.
+	require.NotEqual(t, abcitypes.CodeTypeOK, checkTx(newTransfer(2, 5)).Code)
+	tx2r := newTransfer(2, 10)
+	require.Equal(t, abcitypes.CodeTypeOK, checkTx(tx2r).Code)
+	require.Equal(t, 4, mp.Size())
+
+	// the replaced tx is evicted by the first recheck, and the others are left.
+	commitBlock(2)
+	require.Equal(t, 3, mp.Size())
+	require.EqualValues(t, 4, app.checkAcctHandler.FindAccount(w0.Address(), false).GetNonce())
+
+	// the txs are reaped in the order of their nonces and all of them are executed.
+	txs := mp.ReapMaxTxs(-1)
+	require.Equal(t, tmtypes.Txs{tx1, tx2r, tx3}, txs)
+	commitBlock(3, txs...)
+	require.Equal(t, 0, mp.Size())
+	require.EqualValues(t, 4, app.acctCtrler.FindAccount(w0.Address(), false).GetNonce())
+}
+
+func TestCheckTx_TrxTime(t *testing.T) {
+	app := newTestRigoApp(t, "check-tx-time", nil)
+	defer func() { _ = app.Stop() }()
+
+	val := newTestValidator(t, 1_000_000)
+	w0, w1 := web3.NewWallet(nil), web3.NewWallet(nil)
+	initTestChain(t, app, val, w0)
+	execTestBlock(t, app, 1, val, newTestTransfer(t, w0, w1, 0))
+
+	govParams := ctrlertypes.DefaultGovParams()
+	window := time.Duration(govParams.TxTimeWindow()) * time.Second
+	newTransfer := func(nonce uint64, txTime time.Time) []byte {
+		tx := web3.NewTrxTransfer(w0.Address(), w1.Address(), nonce, govParams.MinTrxGas(), govParams.GasPrice(), uint256.NewInt(1000))
+		tx.Time = txTime.UnixNano()
+		return signTestTrx(t, w0, tx)
+	}
+
+	// on CheckTx, the tx is checked against the expected time of the next block, not the local time.
+	// The txs out of the window are rejected and evicted by the recheck.
+	nextBlockTime := time.Unix(app.lastBlockCtx.ExpectedNextBlockTimeSeconds(app.rootConfig.Consensus.CreateEmptyBlocksInterval), 0)
+	for _, txTime := range []time.Time{nextBlockTime.Add(-window - time.Second), nextBlockTime.Add(window + time.Second)} {
+		tx := newTransfer(1, txTime)
+		for _, typ := range []abcitypes.CheckTxType{abcitypes.CheckTxType_New, abcitypes.CheckTxType_Recheck} {
+			resp := app.CheckTx(abcitypes.RequestCheckTx{Tx: tx, Type: typ})
+			require.Equal(t, xerrors.ErrCodeCheckTx, resp.Code)
+			require.Contains(t, resp.Log, "out of the valid window")
+		}
+	}
+	// the txs at the edges of the window are accepted.
+	resp := app.CheckTx(abcitypes.RequestCheckTx{Tx: newTransfer(1, nextBlockTime.Add(-window)), Type: abcitypes.CheckTxType_New})
+	require.Equal(t, abcitypes.CodeTypeOK, resp.Code, resp.Log)
+	resp = app.CheckTx(abcitypes.RequestCheckTx{Tx: newTransfer(2, nextBlockTime.Add(window)), Type: abcitypes.CheckTxType_New})
+	require.Equal(t, abcitypes.CodeTypeOK, resp.Code, resp.Log)
+	execTestBlock(t, app, 2, val, newTransfer(1, nextBlockTime))
+
+	// the tx is checked against the block time on DeliverTx.
+	blockTime := time.Unix(testGenesisTime+3, 0)
+	app.BeginBlock(testBeginBlockReq(3, val))
+	deliverResp := app.DeliverTx(abcitypes.RequestDeliverTx{Tx: newTransfer(2, blockTime.Add(-window-time.Second))})
+	require.Contains(t, deliverResp.Log, "out of the valid window")
+	deliverResp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: newTransfer(2, blockTime.Add(window+time.Second))})
+	require.Contains(t, deliverResp.Log, "out of the valid window")
+	deliverResp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: newTransfer(2, blockTime.Add(-window))})
+	require.Equal(t, abcitypes.CodeTypeOK, deliverResp.Code, deliverResp.Log)
+	deliverResp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: newTransfer(3, blockTime.Add(window))})
+	require.Equal(t, abcitypes.CodeTypeOK, deliverResp.Code, deliverResp.Log)
+	app.EndBlock(abcitypes.RequestEndBlock{Height: 3})
+	app.Commit()
+}
@@ -1,6 +1,7 @@
 package node
 
 import (
+	"fmt"
 	abcicli "github.com/tendermint/tendermint/abci/client"
 	abcitypes "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/service"
@@ -44,8 +45,9 @@ type rigoLocalClient struct {
 	abcitypes.Application
 	abcicli.Callback
 
+	// the ReqRes of DeliverTx, whose responses are not available until EndBlock
+	// when the txs are executed in parallel.
 	deliverTxReqReses []*abcicli.ReqRes
-	OnTrxExecFinished func(abcicli.Client, int, *abcitypes.RequestDeliverTx, *abcitypes.ResponseDeliverTx)
 }
 
 var _ abcicli.Client = (*rigoLocalClient)(nil)
@@ -59,9 +61,8 @@ func NewRigoLocalClient(mtx *tmsync.Mutex, app abcitypes.Application) abcicli.Cl
 		mtx = new(tmsync.Mutex)
 	}
 	cli := &rigoLocalClient{
-		mtx:               mtx,
-		Application:       app,
-		OnTrxExecFinished: defualtOnTrxExecFinished,
+		mtx:         mtx,
+		Application: app,
 	}
 	cli.BaseService = *service.NewBaseService(nil, "rigoLocalClient", cli)
 	return cli
@@ -127,32 +128,40 @@ func (client *rigoLocalClient) DeliverTxAsync(params abcitypes.RequestDeliverTx)
 	client.mtx.Lock()
 	defer client.mtx.Unlock()
 
-	// todo: Implement parallel tx processing
-	//rr := newLocalReqRes(abcitypes.ToRequestDeliverTx(params), abcitypes.ToResponseDeliverTx(abcitypes.ResponseDeliverTx{}))
-	//client.deliverTxReqReses = append(client.deliverTxReqReses, rr)
-	//
-	//_ = client.Application.DeliverTx(params)
-	//return nil
-
 	res := client.Application.DeliverTx(params)
+	if app, ok := client.Application.(*RigoApp); ok && app.parallelDeliverTx() {
+		// the response is set and the callback is invoked at EndBlock.
+		rr := abcicli.NewReqRes(abcitypes.ToRequestDeliverTx(params))
+		client.deliverTxReqReses = append(client.deliverTxReqReses, rr)
+		return rr
+	}
+
 	return client.callback(
 		abcitypes.ToRequestDeliverTx(params),
 		abcitypes.ToResponseDeliverTx(res),
 	)
 }
 
-func defualtOnTrxExecFinished(client abcicli.Client, txidx int, req *abcitypes.RequestDeliverTx, res *abcitypes.ResponseDeliverTx) {
-	client.(*rigoLocalClient).onTrxFinished(txidx, req, res)
-}
+// finishDeliverTxs invokes the callbacks of DeliverTx in the order of the requests,
+// after the txs are executed in parallel at EndBlock.
+func (client *rigoLocalClient) finishDeliverTxs() {
+	if len(client.deliverTxReqReses) == 0 {
+		return
+	}
+
+	resps := client.Application.(*RigoApp).takeDeliverTxResponses()
+	if len(resps) != len(client.deliverTxReqReses) {
+		panic(fmt.Sprintf("the number of DeliverTx responses is wrong - expected: %v, actual: %v",
+			len(client.deliverTxReqReses), len(resps)))
+	}
 
-func (client *rigoLocalClient) onTrxFinished(txidx int, req *abcitypes.RequestDeliverTx, res *abcitypes.ResponseDeliverTx) {
-	client.Logger.Info("rigoLocalClient receives finished tx", "index", txidx)
-	if txidx >= len(client.deliverTxReqReses) {
-		panic("web3.deliverTxReqReses's length is wrong")
+	for i, rr := range client.deliverTxReqReses {
+		rr.Response = abcitypes.ToResponseDeliverTx(resps[i])
+		rr.Done()
+		client.Callback(rr.Request, rr.Response)
+		rr.InvokeCallback()
 	}
-	rr := client.deliverTxReqReses[txidx]
-	rr.Response = abcitypes.ToResponseDeliverTx(*res)
-	rr.Done()
+	client.deliverTxReqReses = nil
 }
 
 func (client *rigoLocalClient) CheckTxAsync(req abcitypes.RequestCheckTx) *abcicli.ReqRes {
@@ -215,6 +224,7 @@ func (client *rigoLocalClient) EndBlockAsync(req abcitypes.RequestEndBlock) *abc
 	defer client.mtx.Unlock()
 
 	res := client.Application.EndBlock(req)
+	client.finishDeliverTxs()
 	return client.callback(
 		abcitypes.ToRequestEndBlock(req),
 		abcitypes.ToResponseEndBlock(res),
@@ -343,16 +353,8 @@ func (client *rigoLocalClient) EndBlockSync(req abcitypes.RequestEndBlock) (*abc
 	client.mtx.Lock()
 	defer client.mtx.Unlock()
 
-	// todo: Implement parallel tx processing
-	//// wait that all txs are finished
-	//for _, rr := range client.deliverTxReqReses {
-	//	rr.Wait()
-	//	client.Callback(rr.Request, rr.Response)
-	//	rr.InvokeCallback()
-	//}
-	//client.deliverTxReqReses = nil
-
 	res := client.Application.EndBlock(req)
+	client.finishDeliverTxs()
 	return &res, nil
 }
 
@@ -0,0 +1,96 @@
+package node
+
+import (
+	"github.com/holiman/uint256"
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/libs/web3"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"testing"
+	"time"
+)
+
+func TestValidatorCommission(t *testing.T) {
+	app := newTestRigoApp(t, "commission", nil)
+	defer func() { _ = app.Stop() }()
+
+	val := newTestValidator(t, 1_000_000)
+	w0 := web3.NewWallet(nil)
+	initTestChain(t, app, val, val.wallet, w0)
+
+	govParams := ctrlertypes.DefaultGovParams()
+	app.BeginBlock(testBeginBlockReq(1, val))
+
+	// only the validator can set its commission.
+	resp := app.DeliverTx(abcitypes.RequestDeliverTx{Tx: signTestTrx(t, w0, web3.NewTrxCommission(w0.Address(), 0, govParams.MinTrxGas(), govParams.GasPrice(), 10, 20, 5))})
+	require.NotEqual(t, abcitypes.CodeTypeOK, resp.Code)
+	resp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: signTestTrx(t, val.wallet, web3.NewTrxCommission(val.addr, 0, govParams.MinTrxGas(), govParams.GasPrice(), 10, 101, 5))})
+	require.NotEqual(t, abcitypes.CodeTypeOK, resp.Code, "the max rate is over 100")
+	resp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: signTestTrx(t, val.wallet, web3.NewTrxCommission(val.addr, 0, govParams.MinTrxGas(), govParams.GasPrice(), 30, 20, 5))})
+	require.NotEqual(t, abcitypes.CodeTypeOK, resp.Code, "the rate is over the max rate")
+	resp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: signTestTrx(t, val.wallet, web3.NewTrxCommission(val.addr, 0, govParams.MinTrxGas(), govParams.GasPrice(), 10, 20, 5))})
+	require.Equal(t, abcitypes.CodeTypeOK, resp.Code, resp.Log)
+
+	delegated := int64(1000)
+	resp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: signTestTrx(t, w0, web3.NewTrxStaking(w0.Address(), val.addr, 0, govParams.MinTrxGas(), govParams.GasPrice(), ctrlertypes.PowerToAmount(delegated)))})
+	require.Equal(t, abcitypes.CodeTypeOK, resp.Code, resp.Log)
+	app.EndBlock(abcitypes.RequestEndBlock{Height: 1})
+	app.Commit()
+
+	commission := app.stakeCtrler.Delegatee(val.addr).GetCommission()
+	require.NotNil(t, commission)
+	require.EqualValues(t, 10, commission.Rate)
+	require.EqualValues(t, testGenesisTime+1, commission.UpdateTime)
+
+	// the validator takes 10% of the reward of `w0`.
+	// no reward is issued at the first block, which has no last commit.
+	rwdPerPower := govParams.RewardPerPower()
+	require.Nil(t, app.stakeCtrler.ReadRewardOf(w0.Address()))
+	require.Nil(t, app.stakeCtrler.ReadRewardOf(val.addr))
+
+	// the reward at block 2 is based on the stakes committed at block 1.
+	selfPower := val.power
+	val.power += delegated
+	execTestBlock(t, app, 2, val)
+
+	delegatorRwd := new(uint256.Int).Mul(uint256.NewInt(uint64(delegated)), rwdPerPower)
+	expectedCommission := new(uint256.Int).Div(delegatorRwd, uint256.NewInt(10))
+	_ = delegatorRwd.Sub(delegatorRwd, expectedCommission)
+	selfRwd := new(uint256.Int).Mul(uint256.NewInt(uint64(selfPower)), rwdPerPower)
+
+	rwdObj0 := app.stakeCtrler.ReadRewardOf(w0.Address())
+	require.Equal(t, delegatorRwd, rwdObj0.GetCumulated())
+	require.Equal(t, uint256.NewInt(0), rwdObj0.GetCommission())
+
+	rwdObjVal := app.stakeCtrler.ReadRewardOf(val.addr)
+	require.Equal(t, expectedCommission, rwdObjVal.GetCommission())
+	require.Equal(t, new(uint256.Int).Add(selfRwd, expectedCommission), rwdObjVal.GetCumulated())
+
+	// the rate can not be changed within a day.
+	app.BeginBlock(testBeginBlockReq(3, val))
+	resp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: signTestTrx(t, val.wallet, web3.NewTrxCommission(val.addr, 1, govParams.MinTrxGas(), govParams.GasPrice(), 15, 20, 5))})
+	require.NotEqual(t, abcitypes.CodeTypeOK, resp.Code)
+	app.EndBlock(abcitypes.RequestEndBlock{Height: 3})
+	app.Commit()
+
+	// a day later, the rate can be changed by up to the max change rate.
+	req := testBeginBlockReq(4, val)
+	req.Header.Time = time.Unix(testGenesisTime+1+24*60*60, 0)
+	app.BeginBlock(req)
+
+	newCommissionTrx := func(nonce uint64, rate, maxRate, maxChangeRate int64) []byte {
+		tx := web3.NewTrxCommission(val.addr, nonce, govParams.MinTrxGas(), govParams.GasPrice(), rate, maxRate, maxChangeRate)
+		tx.Time = req.Header.Time.UnixNano()
+		return signTestTrx(t, val.wallet, tx)
+	}
+	resp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: newCommissionTrx(1, 16, 20, 5)})
+	require.NotEqual(t, abcitypes.CodeTypeOK, resp.Code, "the change is over the max change rate")
+	resp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: newCommissionTrx(1, 15, 30, 5)})
+	require.NotEqual(t, abcitypes.CodeTypeOK, resp.Code, "the max rate can not be changed")
+	resp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: newCommissionTrx(1, 15, 20, 5)})
+	require.Equal(t, abcitypes.CodeTypeOK, resp.Code, resp.Log)
+	app.EndBlock(abcitypes.RequestEndBlock{Height: 4})
+	app.Commit()
+
+	require.EqualValues(t, 15, app.stakeCtrler.Delegatee(val.addr).GetCommission().Rate)
+}
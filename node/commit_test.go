@@ -0,0 +1,89 @@
+package node
+
+import (
+	cfg "github.com/rigochain/rigo-go/cmd/config"
+	"github.com/rigochain/rigo-go/libs/web3"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	"testing"
+)
+
+// TestCommit_RecoverPartialCommit simulates a crash in the middle of RigoApp::Commit.
+// The restarted app should roll back the ledgers committed before the crash and replay the block.
+func TestCommit_RecoverPartialCommit(t *testing.T) {
+	ref := newTestRigoApp(t, "commit-ref", nil)
+	defer func() { _ = ref.Stop() }()
+	app := newTestRigoApp(t, "commit-crash", nil)
+
+	val := newTestValidator(t, 1_000_000)
+	w0, w1 := web3.NewWallet(nil), web3.NewWallet(nil)
+	initTestChain(t, ref, val, w0)
+	initTestChain(t, app, val, w0)
+
+	// the reward hash is updated at the crashed height.
+	crashHeight := int64(10)
+	appHashes := make(map[int64][]byte)
+	txs := make(map[int64][]byte)
+	for h := int64(1); h <= crashHeight; h++ {
+		txs[h] = newTestTransfer(t, w0, w1, uint64(h-1))
+		appHashes[h] = execTestBlock(t, ref, h, val, txs[h])
+		if h < crashHeight {
+			require.Equal(t, appHashes[h], execTestBlock(t, app, h, val, txs[h]))
+		}
+	}
+
+	// crash after committing all ledgers except the evm state.
+	app.BeginBlock(testBeginBlockReq(crashHeight, val))
+	require.Equal(t, abcitypes.CodeTypeOK, app.DeliverTx(abcitypes.RequestDeliverTx{Tx: txs[crashHeight]}).Code)
+	app.EndBlock(abcitypes.RequestEndBlock{Height: crashHeight})
+	require.NoError(t, app.metaDB.PutCommittingHeight(crashHeight))
+	_, _, xerr := app.govCtrler.Commit()
+	require.NoError(t, xerr)
+	_, _, xerr = app.acctCtrler.Commit()
+	require.NoError(t, xerr)
+	_, _, xerr = app.stakeCtrler.Commit()
+	require.NoError(t, xerr)
+	config := app.rootConfig
+	require.NoError(t, app.Stop())
+
+	app = restartTestRigoApp(t, config)
+	defer func() { _ = app.Stop() }()
+
+	info := app.Info(abcitypes.RequestInfo{})
+	require.Equal(t, crashHeight-1, info.LastBlockHeight)
+	require.EqualValues(t, appHashes[crashHeight-1], info.LastBlockAppHash)
+	require.EqualValues(t, 0, app.metaDB.CommittingHeight())
+
+	require.Equal(t, appHashes[crashHeight], execTestBlock(t, app, crashHeight, val, txs[crashHeight]))
+}
+
+// TestCommit_RecoverFinishedCommit simulates a crash after the block context is saved.
+func TestCommit_RecoverFinishedCommit(t *testing.T) {
+	app := newTestRigoApp(t, "commit-finished", nil)
+
+	val := newTestValidator(t, 1_000_000)
+	w0, w1 := web3.NewWallet(nil), web3.NewWallet(nil)
+	initTestChain(t, app, val, w0)
+
+	appHash := execTestBlock(t, app, 1, val, newTestTransfer(t, w0, w1, 0))
+	require.NoError(t, app.metaDB.PutCommittingHeight(1))
+	config := app.rootConfig
+	require.NoError(t, app.Stop())
+
+	app = restartTestRigoApp(t, config)
+	defer func() { _ = app.Stop() }()
+
+	info := app.Info(abcitypes.RequestInfo{})
+	require.EqualValues(t, 1, info.LastBlockHeight)
+	require.EqualValues(t, appHash, info.LastBlockAppHash)
+	require.EqualValues(t, 0, app.metaDB.CommittingHeight())
+
+	execTestBlock(t, app, 2, val, newTestTransfer(t, w0, w1, 1))
+}
+
+func restartTestRigoApp(t *testing.T, config *cfg.Config) *RigoApp {
+	app := NewRigoApp(config, log.NewNopLogger())
+	require.NoError(t, app.Start())
+	return app
+}
@@ -0,0 +1,123 @@
+package node
+
+import (
+	"github.com/holiman/uint256"
+	"github.com/rigochain/rigo-go/ctrlers/gov/proposal"
+	rctypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/libs/web3"
+	"github.com/rigochain/rigo-go/types"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmtypes "github.com/tendermint/tendermint/types"
+	"testing"
+)
+
+func TestCommunityPool_Spend(t *testing.T) {
+	app := newTestRigoApp(t, "community-pool", nil)
+	defer func() { _ = app.Stop() }()
+
+	valWallet, w0, w1 := web3.NewWallet(nil), web3.NewWallet(nil), web3.NewWallet(nil)
+	val := &testValidator{pubBytes: valWallet.GetPubKey(), addr: valWallet.Address(), power: 1_000_000}
+	govParams := rctypes.Test1GovParams()
+	initTestChainWith(t, app, val, govParams, valWallet, w0)
+
+	newSpendProposal := func(nonce uint64, amt uint64, applyingHeight int64) []byte {
+		spend := &rctypes.CommunitySpend{Recipient: w1.Address(), Amount: uint256.NewInt(amt)}
+		bz, xerr := spend.Encode()
+		require.NoError(t, xerr)
+		return signTestTrx(t, valWallet, web3.NewTrxProposal(valWallet.Address(), types.ZeroAddress(), nonce, govParams.MinTrxGas(), govParams.GasPrice(),
+			"community spend", 4, 10, applyingHeight, proposal.PROPOSAL_COMMUNITY_SPEND, bz))
+	}
+	newVote := func(nonce uint64, propTx []byte) []byte {
+		return signTestTrx(t, valWallet, web3.NewTrxVoting(valWallet.Address(), types.ZeroAddress(), nonce, govParams.MinTrxGas(), govParams.GasPrice(),
+			tmtypes.Tx(propTx).Hash(), 0))
+	}
+
+	// the spend of zero amount is rejected.
+	require.NotEqual(t, abcitypes.CodeTypeOK, app.CheckTx(abcitypes.RequestCheckTx{Tx: newSpendProposal(0, 0, 24)}).Code)
+
+	// the second spend can not be executed, because the pool has not enough balance after the first spend.
+	prop0, prop1 := newSpendProposal(0, 600, 24), newSpendProposal(1, 600, 25)
+	donation := signTestTrx(t, w0, web3.NewTrxTransfer(w0.Address(), rctypes.CommunityPoolAddress, 0, govParams.MinTrxGas(), govParams.GasPrice(), uint256.NewInt(1000)))
+
+	execTestBlock(t, app, 1, val, donation)
+	execTestBlock(t, app, 2, val)
+	execTestBlock(t, app, 3, val, prop0, prop1)
+	execTestBlock(t, app, 4, val, newVote(2, prop0), newVote(3, prop1))
+	for h := int64(5); h < 24; h++ {
+		execTestBlock(t, app, h, val)
+	}
+
+	app.BeginBlock(testBeginBlockReq(24, val))
+	resp := app.EndBlock(abcitypes.RequestEndBlock{Height: 24})
+	app.Commit()
+	spendEvt := findTestEvent(resp.Events, rctypes.EVENT_TYPE_COMMUNITY_SPEND)
+	require.NotNil(t, spendEvt)
+	require.Equal(t, []byte(w1.Address().String()), spendEvt.Attributes[0].Value)
+	require.Equal(t, []byte("600"), spendEvt.Attributes[2].Value)
+	require.Equal(t, uint256.NewInt(600), app.acctCtrler.ReadAccount(w1.Address()).Balance)
+
+	app.BeginBlock(testBeginBlockReq(25, val))
+	resp = app.EndBlock(abcitypes.RequestEndBlock{Height: 25})
+	app.Commit()
+	require.Nil(t, findTestEvent(resp.Events, rctypes.EVENT_TYPE_COMMUNITY_SPEND))
+	propEvt := findTestEvent(resp.Events, "proposal")
+	require.NotNil(t, propEvt)
+	require.Equal(t, []byte("failed"), propEvt.Attributes[0].Key)
+	require.Equal(t, uint256.NewInt(600), app.acctCtrler.ReadAccount(w1.Address()).Balance)
+
+	qresp := app.Query(abcitypes.RequestQuery{Path: "community_pool", Height: 25})
+	require.Equal(t, abcitypes.CodeTypeOK, qresp.Code, qresp.Log)
+	pool := &struct {
+		Address types.Address             `json:"address"`
+		Balance string                    `json:"balance"`
+		Spends  []*rctypes.CommunitySpend `json:"spends"`
+	}{}
+	require.NoError(t, tmjson.Unmarshal(qresp.Value, pool))
+	require.Equal(t, rctypes.CommunityPoolAddress, pool.Address)
+	require.Equal(t, "400", pool.Balance)
+	require.Len(t, pool.Spends, 1)
+	require.EqualValues(t, 24, pool.Spends[0].Height)
+	require.EqualValues(t, tmtypes.Tx(prop0).Hash(), pool.Spends[0].TxHash)
+	require.Equal(t, uint256.NewInt(600), pool.Spends[0].Amount)
+
+	// the spends after the height are not returned.
+	qresp = app.Query(abcitypes.RequestQuery{Path: "community_pool", Height: 23})
+	require.Equal(t, abcitypes.CodeTypeOK, qresp.Code, qresp.Log)
+	require.NoError(t, tmjson.Unmarshal(qresp.Value, pool))
+	require.Equal(t, "1000", pool.Balance)
+	require.Len(t, pool.Spends, 0)
+
+	// the spends are a part of the gov root hash and of the gov snapshot.
+	root25, xerr := app.govCtrler.RootHashAt(25)
+	require.NoError(t, xerr)
+	exporter, xerr := app.govCtrler.Snapshot(25)
+	require.NoError(t, xerr)
+	items := make(rctypes.SnapshotItems)
+	require.NoError(t, exporter(func(item *rctypes.SnapshotItemProto) xerrors.XError {
+		items.Add(item)
+		return nil
+	}))
+	require.NotEmpty(t, items["community_spends"])
+
+	dst := newTestRigoApp(t, "community-pool-dst", nil)
+	defer func() { _ = dst.Stop() }()
+	restored, xerr := dst.govCtrler.RestoreSnapshot(25, items)
+	require.NoError(t, xerr)
+	require.Equal(t, root25, restored)
+	spends, xerr := dst.govCtrler.CommunitySpends(25)
+	require.NoError(t, xerr)
+	require.Len(t, spends, 1)
+	require.EqualValues(t, tmtypes.Tx(prop0).Hash(), spends[0].TxHash)
+}
+
+func findTestEvent(evts []abcitypes.Event, typ string) *abcitypes.Event {
+	for i := range evts {
+		if evts[i].Type == typ {
+			return &evts[i]
+		}
+	}
+	return nil
+}
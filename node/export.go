@@ -0,0 +1,82 @@
+package node
+
+import (
+	cfg "github.com/rigochain/rigo-go/cmd/config"
+	"github.com/rigochain/rigo-go/ctrlers/stake"
+	"github.com/rigochain/rigo-go/genesis"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// ExportGenesis returns the genesis document of a new network which starts from the state of `height`.
+// If `height` is 0, the state of the last block is exported.
+// All heights in the exported state are rebased, so that `height` becomes 0 in the new network.
+func ExportGenesis(config *cfg.Config, height int64, logger log.Logger) (*tmtypes.GenesisDoc, error) {
+	app := NewRigoApp(config, logger)
+	defer func() { _ = app.Stop() }()
+
+	// Info() finishes the commit interrupted by a crash.
+	lastHeight := app.Info(abcitypes.RequestInfo{}).LastBlockHeight
+	if height == 0 {
+		height = lastHeight
+	}
+	if height < 1 || height > lastHeight {
+		return nil, xerrors.ErrExport.Wrapf("wrong height: %v, the last height is %v", height, lastHeight)
+	}
+
+	appState, xerr := app.exportAppState(height)
+	if xerr != nil {
+		return nil, xerr
+	}
+	stakeState := &stake.GenesisState{
+		Delegatees: appState.Delegatees,
+		Frozen:     appState.FrozenStakes,
+		Rewards:    appState.Rewards,
+	}
+
+	var validators []tmtypes.GenesisValidator
+	for _, val := range stakeState.Validators(appState.GovParams) {
+		pubKey := secp256k1.PubKey(val.PubKey)
+		validators = append(validators, tmtypes.GenesisValidator{
+			Address: pubKey.Address(),
+			PubKey:  pubKey,
+			Power:   val.TotalPower,
+		})
+	}
+
+	genDoc, err := genesis.NewGenesisDocWithAppState(config.ChainID, validators, appState)
+	if err != nil {
+		return nil, xerrors.ErrExport.Wrap(err)
+	}
+
+	logger.Info("Export the state", "height", height, "validators", len(validators))
+	return genDoc, nil
+}
+
+// exportAppState returns the state of all ledgers at `height`.
+func (ctrler *RigoApp) exportAppState(height int64) (*genesis.GenesisAppState, xerrors.XError) {
+	appState := &genesis.GenesisAppState{}
+	if xerr := ctrler.govCtrler.ExportGenesis(height, appState); xerr != nil {
+		return nil, xerrors.ErrExport.Wrap(xerr)
+	}
+	if xerr := ctrler.acctCtrler.ExportGenesis(height, appState); xerr != nil {
+		return nil, xerrors.ErrExport.Wrap(xerr)
+	}
+
+	stakeState, xerr := ctrler.stakeCtrler.ExportGenesis(height)
+	if xerr != nil {
+		return nil, xerrors.ErrExport.Wrap(xerr)
+	}
+	appState.Delegatees = stakeState.Delegatees
+	appState.FrozenStakes = stakeState.Frozen
+	appState.Rewards = stakeState.Rewards
+
+	// the contracts are found in the accounts
+	if xerr := ctrler.vmCtrler.ExportGenesis(height, appState); xerr != nil {
+		return nil, xerrors.ErrExport.Wrap(xerr)
+	}
+	return appState, nil
+}
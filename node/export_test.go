@@ -0,0 +1,101 @@
+package node
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/genesis"
+	"github.com/rigochain/rigo-go/libs/web3"
+	"github.com/rigochain/rigo-go/types"
+	"github.com/rigochain/rigo-go/types/bytes"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/libs/log"
+	"testing"
+)
+
+// testContractCode deploys the contract which stores 42 at the slot 0 and returns the slot 0 when it is called.
+var testContractCode = bytes.HexBytes(common.FromHex("602a600055600b6011600039600b6000f3" + "6000546000526020" + "6000f3"))
+
+func TestExportGenesis(t *testing.T) {
+	app := newTestRigoApp(t, "export-src", nil)
+	config := app.rootConfig
+
+	val := newTestValidator(t, 10_000_000)
+	w0, w1 := web3.NewWallet(nil), web3.NewWallet(nil)
+	initTestChain(t, app, val, w0)
+
+	govParams := ctrlertypes.DefaultGovParams()
+	contractAddr := types.Address(crypto.CreateAddress(w0.Address().Array20(), 1).Bytes())
+	execTestBlock(t, app, 1, val, newTestTransfer(t, w0, w1, 0))
+	execTestBlock(t, app, 2, val,
+		signTestTrx(t, w0, web3.NewTrxContract(w0.Address(), types.ZeroAddress(), 1, 3_000_000, govParams.GasPrice(), uint256.NewInt(0), testContractCode)))
+	execTestBlock(t, app, 3, val,
+		signTestTrx(t, w0, web3.NewTrxSetDoc(w0.Address(), 2, govParams.MinTrxGas(), govParams.GasPrice(), "w0", "https://rigo.test/w0")))
+	for h := int64(4); h <= 5; h++ {
+		execTestBlock(t, app, h, val)
+	}
+
+	srcW0 := app.acctCtrler.ReadAccount(w0.Address())
+	srcW1 := app.acctCtrler.ReadAccount(w1.Address())
+	srcRwd, xerr := app.stakeCtrler.ExportGenesis(5)
+	require.NoError(t, xerr)
+	require.NoError(t, app.Stop())
+
+	_, err := ExportGenesis(config, 6, log.NewNopLogger())
+	require.Error(t, err)
+
+	genDoc, err := ExportGenesis(config, 0, log.NewNopLogger())
+	require.NoError(t, err)
+	require.NoError(t, genDoc.ValidateAndComplete())
+	require.Equal(t, testChainID, genDoc.ChainID)
+	require.Len(t, genDoc.Validators, 1)
+	require.EqualValues(t, val.addr, genDoc.Validators[0].Address)
+	require.Equal(t, val.power, genDoc.Validators[0].Power)
+
+	appState := genesis.GenesisAppState{}
+	require.NoError(t, tmjson.Unmarshal(genDoc.AppState, &appState))
+	require.Len(t, appState.Contracts, 1)
+	require.EqualValues(t, contractAddr, appState.Contracts[0].Address)
+	require.Len(t, appState.Contracts[0].Storage, 1)
+	require.Len(t, appState.Rewards, 1)
+	// the heights are rebased to the new network.
+	require.Equal(t, srcRwd.Rewards[0].GetCumulated(), appState.Rewards[0].GetCumulated())
+	require.EqualValues(t, 0, appState.Rewards[0].Height())
+
+	// start the new network with the exported state.
+	app = newTestRigoApp(t, "export-dst", nil)
+	defer func() { _ = app.Stop() }()
+	require.EqualValues(t, 0, app.Info(abcitypes.RequestInfo{}).LastBlockHeight)
+	resp := app.InitChain(abcitypes.RequestInitChain{
+		ChainId:       genDoc.ChainID,
+		AppStateBytes: genDoc.AppState,
+	})
+	require.EqualValues(t, genDoc.AppHash, resp.AppHash)
+
+	execTestBlock(t, app, 1, val, newTestTransfer(t, w0, w1, 3))
+	for h := int64(2); h <= 3; h++ {
+		execTestBlock(t, app, h, val)
+	}
+
+	dstW0 := app.acctCtrler.ReadAccount(w0.Address())
+	require.Equal(t, srcW0.Nonce+1, dstW0.Nonce)
+	require.Equal(t, srcW0.Name, dstW0.Name)
+	require.Equal(t, srcW0.DocURL, dstW0.DocURL)
+	dstW1 := app.acctCtrler.ReadAccount(w1.Address())
+	require.Equal(t, new(uint256.Int).AddUint64(srcW1.Balance, 1000), dstW1.Balance)
+	require.NotNil(t, app.acctCtrler.ReadAccount(contractAddr).Code)
+
+	delegatee := app.stakeCtrler.Delegatee(val.addr)
+	require.NotNil(t, delegatee)
+	require.Equal(t, val.power, delegatee.TotalPower)
+
+	code, xerr := app.vmCtrler.QueryCode(contractAddr, 3)
+	require.NoError(t, xerr)
+	require.EqualValues(t, testContractCode[0x11:], code)
+	state, xerr := app.vmCtrler.ImmutableStateAt(3)
+	require.NoError(t, xerr)
+	require.Equal(t, common.BigToHash(uint256.NewInt(42).ToBig()), state.GetState(contractAddr.Array20(), common.Hash{}))
+}
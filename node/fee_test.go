@@ -0,0 +1,97 @@
+package node
+
+import (
+	"github.com/holiman/uint256"
+	"github.com/rigochain/rigo-go/ctrlers/account"
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/libs/web3"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"testing"
+)
+
+func TestFeeDistribution(t *testing.T) {
+	app := newTestRigoApp(t, "fee-distribution", nil)
+	defer func() { _ = app.Stop() }()
+
+	govParams := ctrlertypes.Test7GovParams_FeeDistribution()
+	val := newTestValidator(t, 10_000_000)
+	w0, w1 := web3.NewWallet(nil), web3.NewWallet(nil)
+	initTestChainWith(t, app, val, govParams, w0)
+
+	fee := govParams.MinTrxFee()
+	percentOf := func(ratio uint64) *uint256.Int {
+		ret := new(uint256.Int).Mul(fee, uint256.NewInt(ratio))
+		return ret.Div(ret, uint256.NewInt(100))
+	}
+
+	// the first block has no last commit, so the portion of validators is given to the proposer.
+	execTestBlock(t, app, 1, val, newTestTransfer(t, w0, w1, 0))
+	dist, xerr := app.acctCtrler.FeeDistributionAt(1)
+	require.NoError(t, xerr)
+	require.Equal(t, fee, dist.Total)
+	require.Equal(t, percentOf(70), dist.Proposer)
+	require.True(t, dist.Validators.IsZero())
+	require.Equal(t, percentOf(20), dist.Community)
+	require.Equal(t, percentOf(10), dist.Burned)
+
+	valBalance := app.acctCtrler.ReadAccount(val.addr).Balance.Clone()
+	require.Equal(t, percentOf(70), valBalance)
+
+	app.BeginBlock(testBeginBlockReq(2, val))
+	resp := app.DeliverTx(abcitypes.RequestDeliverTx{Tx: newTestTransfer(t, w0, w1, 1)})
+	require.Equal(t, abcitypes.CodeTypeOK, resp.Code, resp.Log)
+	respEnd := app.EndBlock(abcitypes.RequestEndBlock{Height: 2})
+	app.Commit()
+
+	var rewards []abcitypes.Event
+	var burned []abcitypes.Event
+	for _, evt := range respEnd.Events {
+		switch evt.Type {
+		case ctrlertypes.EVENT_TYPE_FEE_REWARD:
+			rewards = append(rewards, evt)
+		case ctrlertypes.EVENT_TYPE_FEE_BURNED:
+			burned = append(burned, evt)
+		}
+	}
+	require.Len(t, rewards, 3) // validator, community and proposer
+	require.Len(t, burned, 1)
+	require.Equal(t, []byte(ctrlertypes.EVENT_ROLE_VALIDATOR), rewards[0].Attributes[1].Value)
+	require.Equal(t, []byte(percentOf(30).Dec()), rewards[0].Attributes[2].Value)
+	require.Equal(t, []byte(ctrlertypes.CommunityPoolAddress.String()), rewards[1].Attributes[0].Value)
+	require.Equal(t, []byte(ctrlertypes.EVENT_ROLE_PROPOSER), rewards[2].Attributes[1].Value)
+	require.Equal(t, []byte(percentOf(40).Dec()), rewards[2].Attributes[2].Value)
+
+	dist, xerr = app.acctCtrler.FeeDistributionAt(2)
+	require.NoError(t, xerr)
+	require.Equal(t, percentOf(40), dist.Proposer)
+	require.Equal(t, percentOf(30), dist.Validators)
+
+	// the proposer is the only validator who signed the last block.
+	_ = valBalance.Add(valBalance, percentOf(70))
+	require.Equal(t, valBalance, app.acctCtrler.ReadAccount(val.addr).Balance)
+	require.Equal(t, percentOf(40), app.acctCtrler.ReadAccount(ctrlertypes.CommunityPoolAddress).Balance)
+
+	// the block without fee
+	execTestBlock(t, app, 3, val)
+	qresp := app.Query(abcitypes.RequestQuery{Path: "fee_distribution", Height: 3})
+	require.Equal(t, abcitypes.CodeTypeOK, qresp.Code, qresp.Log)
+	dist = &account.FeeDistribution{}
+	require.NoError(t, tmjson.Unmarshal(qresp.Value, dist))
+	require.EqualValues(t, 3, dist.Height)
+	require.True(t, dist.Total.IsZero())
+
+	qresp = app.Query(abcitypes.RequestQuery{Path: "fee_distribution", Height: 2})
+	require.Equal(t, abcitypes.CodeTypeOK, qresp.Code, qresp.Log)
+	dist = &account.FeeDistribution{}
+	require.NoError(t, tmjson.Unmarshal(qresp.Value, dist))
+	require.Equal(t, fee, dist.Total)
+	require.Equal(t, percentOf(10), dist.Burned)
+
+	// the records of the blocks rolled back are removed.
+	require.NoError(t, app.acctCtrler.Rollback(1))
+	dist, xerr = app.acctCtrler.FeeDistributionAt(2)
+	require.NoError(t, xerr)
+	require.True(t, dist.Total.IsZero())
+}
@@ -0,0 +1,90 @@
+package node
+
+import (
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/libs/web3"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"testing"
+)
+
+func TestJailValidator(t *testing.T) {
+	app := newTestRigoApp(t, "jail", nil)
+	defer func() { _ = app.Stop() }()
+
+	val := newTestValidator(t, 1_000_000)
+	w0 := web3.NewWallet(nil)
+	govParams := ctrlertypes.Test1GovParams()
+	initTestChainWith(t, app, val, govParams, val.wallet, w0)
+
+	newUnjail := func(w *web3.Wallet, nonce uint64) []byte {
+		return signTestTrx(t, w, web3.NewTrxUnjail(w.Address(), nonce, govParams.MinTrxGas(), govParams.GasPrice()))
+	}
+	staking := signTestTrx(t, w0, web3.NewTrxStaking(w0.Address(), val.addr, 0, govParams.MinTrxGas(), govParams.GasPrice(), ctrlertypes.PowerToAmount(1000)))
+	execTestBlock(t, app, 1, val, staking)
+
+	// the validator misses the blocks from 1.
+	missed := govParams.SignedBlocksWindow() - govParams.MinSignedBlocks() + 1
+	var jailedEvt *abcitypes.Event
+	for h := int64(2); h <= missed+1; h++ {
+		req := testBeginBlockReq(h, val)
+		req.LastCommitInfo.Votes[0].SignedLastBlock = false
+		resp := app.BeginBlock(req)
+		app.EndBlock(abcitypes.RequestEndBlock{Height: h})
+		app.Commit()
+
+		if evt := findTestEvent(resp.Events, ctrlertypes.EVENT_TYPE_VALIDATOR_JAILED); evt != nil {
+			require.Nil(t, jailedEvt)
+			require.Equal(t, missed+1, h)
+			jailedEvt = evt
+		}
+	}
+	require.NotNil(t, jailedEvt)
+
+	releaseHeight := missed + 1 + govParams.JailBlocks()
+	delegatee := app.stakeCtrler.Delegatee(val.addr)
+	require.True(t, delegatee.IsJailed())
+	require.Equal(t, releaseHeight, delegatee.ReleaseHeight)
+	// the stakes are kept.
+	require.EqualValues(t, val.power+1000, delegatee.GetTotalPower())
+	require.Len(t, delegatee.StakesOf(w0.Address()), 1)
+	require.Len(t, app.stakeCtrler.ReadFrozenStakes(), 0)
+
+	// the jailed validator is excluded from the validators at the next block.
+	h := missed + 2
+	app.BeginBlock(testBeginBlockReq(h, val))
+	endResp := app.EndBlock(abcitypes.RequestEndBlock{Height: h})
+	app.Commit()
+	require.Len(t, endResp.ValidatorUpdates, 1)
+	require.EqualValues(t, 0, endResp.ValidatorUpdates[0].Power)
+	require.False(t, app.stakeCtrler.IsValidator(val.addr))
+
+	// it can not be released before `releaseHeight` nor by others.
+	for h++; h < releaseHeight-1; h++ {
+		execTestBlock(t, app, h, val)
+	}
+	require.NotEqual(t, abcitypes.CodeTypeOK, app.CheckTx(abcitypes.RequestCheckTx{Tx: newUnjail(val.wallet, 0)}).Code)
+	execTestBlock(t, app, h, val)
+	h++
+	require.NotEqual(t, abcitypes.CodeTypeOK, app.CheckTx(abcitypes.RequestCheckTx{Tx: newUnjail(w0, 1)}).Code)
+
+	app.BeginBlock(testBeginBlockReq(h, val))
+	resp := app.DeliverTx(abcitypes.RequestDeliverTx{Tx: newUnjail(val.wallet, 0)})
+	require.Equal(t, abcitypes.CodeTypeOK, resp.Code, resp.Log)
+	require.NotNil(t, findTestEvent(resp.Events, ctrlertypes.EVENT_TYPE_VALIDATOR_UNJAILED))
+	app.EndBlock(abcitypes.RequestEndBlock{Height: h})
+	app.Commit()
+
+	delegatee = app.stakeCtrler.Delegatee(val.addr)
+	require.False(t, delegatee.IsJailed())
+	require.EqualValues(t, 0, delegatee.ReleaseHeight)
+
+	// the released validator is back at the next block.
+	h++
+	app.BeginBlock(testBeginBlockReq(h, val))
+	endResp = app.EndBlock(abcitypes.RequestEndBlock{Height: h})
+	app.Commit()
+	require.Len(t, endResp.ValidatorUpdates, 1)
+	require.EqualValues(t, val.power+1000, endResp.ValidatorUpdates[0].Power)
+	require.True(t, app.stakeCtrler.IsValidator(val.addr))
+}
@@ -0,0 +1,242 @@
+package node
+
+import (
+	"fmt"
+	"github.com/holiman/uint256"
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/types"
+	"github.com/rigochain/rigo-go/types/bytes"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"math"
+	"sync"
+)
+
+// pendingTrx is a tx accepted into the mempool.
+// `cost` is the amount taken from the sender's pending balance by the tx,
+// and `payerCost` is the one taken from the fee payer's pending balance if the tx has a fee payer.
+type pendingTrx struct {
+	txhash    bytes.HexBytes
+	gasPrice  *uint256.Int
+	priority  int64
+	cost      *uint256.Int
+	payer     types.Address
+	payerCost *uint256.Int
+}
+
+func pendingTrxKey(from types.Address, nonce uint64) string {
+	return fmt.Sprintf("%v/%v", from, nonce)
+}
+
+func (handler *checkAcctHandler) pendingTrx(from types.Address, nonce uint64) *pendingTrx {
+	handler.mtx.Lock()
+	defer handler.mtx.Unlock()
+
+	return handler.pending[pendingTrxKey(from, nonce)]
+}
+
+func (handler *checkAcctHandler) setPendingTrx(ctx *ctrlertypes.TrxContext, priority int64, cost, payerCost *uint256.Int) *pendingTrx {
+	handler.mtx.Lock()
+	defer handler.mtx.Unlock()
+
+	pend := newPendingTrx(ctx, priority, cost, payerCost)
+	handler.pending[pendingTrxKey(ctx.Tx.From, ctx.Tx.Nonce)] = pend
+	handler.promote(ctx.Tx.From)
+	return pend
+}
+
+func (handler *checkAcctHandler) queuedTrx(from types.Address, nonce uint64) *pendingTrx {
+	handler.mtx.Lock()
+	defer handler.mtx.Unlock()
+
+	return handler.queued[pendingTrxKey(from, nonce)]
+}
+
+// queueTrx keeps the tx whose nonce is ahead of the pending nonce of its sender.
+// The costs of the tx are not taken from the pending balances until the pending nonce reaches its nonce.
+func (handler *checkAcctHandler) queueTrx(ctx *ctrlertypes.TrxContext, priority int64, cost, payerCost *uint256.Int) *pendingTrx {
+	handler.mtx.Lock()
+	defer handler.mtx.Unlock()
+
+	pend := newPendingTrx(ctx, priority, cost, payerCost)
+	handler.queued[pendingTrxKey(ctx.Tx.From, ctx.Tx.Nonce)] = pend
+	return pend
+}
+
+// applyReplacing makes the tx replacing `rtx` take the place of `rtx` in the pending state.
+// It is queued if the pending nonce of the sender has not reached the nonce of `rtx` yet.
+// It is ignored if the nonce of `rtx` is already consumed, e.g. by the replacing tx rechecked earlier.
+// The amount sent by the replacing tx is not added to the pending balance of the receiver,
+// which only makes the following txs of the receiver be checked conservatively.
+func (handler *checkAcctHandler) applyReplacing(rtx *replacedTrx) {
+	handler.mtx.Lock()
+	defer handler.mtx.Unlock()
+
+	sender := handler.findAccount(rtx.from)
+	if sender == nil || sender.GetNonce() > rtx.nonce {
+		return
+	}
+	handler.queued[pendingTrxKey(rtx.from, rtx.nonce)] = rtx.by
+	handler.promote(rtx.from)
+}
+
+// promote applies the queued txs of `from` to the pending state in the order of their nonces,
+// from the pending nonce of `from` until a nonce is missed or the pending balances are not enough.
+// The txs not applied are checked again when they are rechecked after the next block.
+func (handler *checkAcctHandler) promote(from types.Address) {
+	sender := handler.findAccount(from)
+	if sender == nil {
+		return
+	}
+	for {
+		k := pendingTrxKey(from, sender.GetNonce())
+		pend, ok := handler.queued[k]
+		if !ok || sender.Balance.Lt(pend.cost) {
+			return
+		}
+		if pend.payer != nil {
+			payer := handler.findAccount(pend.payer)
+			if payer == nil || payer.Balance.Lt(pend.payerCost) {
+				return
+			}
+			_ = payer.SubBalance(pend.payerCost)
+		}
+		_ = sender.SubBalance(pend.cost)
+		sender.AddNonce()
+
+		delete(handler.queued, k)
+		handler.pending[k] = pend
+	}
+}
+
+func newPendingTrx(ctx *ctrlertypes.TrxContext, priority int64, cost, payerCost *uint256.Int) *pendingTrx {
+	return &pendingTrx{
+		txhash:    ctx.TxHash,
+		gasPrice:  ctx.Tx.GasPrice.Clone(),
+		priority:  priority,
+		cost:      cost,
+		payer:     ctx.Tx.Payer,
+		payerCost: payerCost,
+	}
+}
+
+// canReplace returns true if the gas price of the new tx is higher than `pend` by `bumpRatio`(%) at least.
+func (pend *pendingTrx) canReplace(gasPrice *uint256.Int, bumpRatio int64) bool {
+	minPrice := new(uint256.Int).Mul(pend.gasPrice, uint256.NewInt(uint64(100+bumpRatio)))
+	_ = minPrice.Div(minPrice, uint256.NewInt(100))
+	return gasPrice.Gt(pend.gasPrice) && !gasPrice.Lt(minPrice)
+}
+
+// replacedTrxs has the txs replaced by the txs of the same sender and nonce.
+// A tx is recorded when its replacing tx is accepted by CheckTx.
+// The mempool can not remove a tx by the request of the application,
+// so a replaced tx is left in the mempool until it is rechecked after the next block and is rejected.
+// It is kept across the blocks, unlike `checkAcctHandler`,
+// and it is removed when it is rechecked or its nonce is consumed by a committed tx.
+type replacedTrxs struct {
+	txs map[string]*replacedTrx
+	mtx sync.Mutex
+}
+
+type replacedTrx struct {
+	from  types.Address
+	nonce uint64
+	// the tx replacing it
+	by *pendingTrx
+}
+
+func newReplacedTrxs() *replacedTrxs {
+	return &replacedTrxs{
+		txs: make(map[string]*replacedTrx),
+	}
+}
+
+func (replaced *replacedTrxs) add(txhash bytes.HexBytes, from types.Address, nonce uint64, by *pendingTrx) {
+	replaced.mtx.Lock()
+	defer replaced.mtx.Unlock()
+
+	replaced.txs[txhash.String()] = &replacedTrx{from: from, nonce: nonce, by: by}
+}
+
+// take returns and removes `txhash` if it is replaced. It returns nil, if `txhash` is not replaced.
+func (replaced *replacedTrxs) take(txhash bytes.HexBytes) *replacedTrx {
+	replaced.mtx.Lock()
+	defer replaced.mtx.Unlock()
+
+	k := txhash.String()
+	rtx, ok := replaced.txs[k]
+	if !ok {
+		return nil
+	}
+	delete(replaced.txs, k)
+	return rtx
+}
+
+// prune removes the txs whose nonce is already consumed in the committed state.
+func (replaced *replacedTrxs) prune(acctHandler ctrlertypes.IAccountHandler) {
+	replaced.mtx.Lock()
+	defer replaced.mtx.Unlock()
+
+	for k, rtx := range replaced.txs {
+		if acct := acctHandler.FindAccount(rtx.from, false); acct == nil || acct.GetNonce() > rtx.nonce {
+			delete(replaced.txs, k)
+		}
+	}
+}
+
+// trxPriority returns the priority of the tx in the priority mempool.
+// The tx paying the higher gas price has the higher priority.
+func trxPriority(tx *ctrlertypes.Trx) int64 {
+	if !tx.GasPrice.IsUint64() || tx.GasPrice.Uint64() > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(tx.GasPrice.Uint64())
+}
+
+// priority returns the priority of the tx in the priority mempool.
+// The mempool v1 reaps the txs in the order of their priorities, not in the order of their nonces,
+// so the priority of a tx is kept lower than the one of the tx of the previous nonce of the same sender.
+// If the tx of the previous nonce is not checked yet, e.g. it is rechecked later,
+// the tx has a negative priority to be reaped after it.
+func (handler *checkAcctHandler) priority(tx *ctrlertypes.Trx) int64 {
+	handler.mtx.Lock()
+	defer handler.mtx.Unlock()
+
+	prio := trxPriority(tx)
+	if tx.Nonce == 0 {
+		return prio
+	}
+	k := pendingTrxKey(tx.From, tx.Nonce-1)
+	prev, ok := handler.pending[k]
+	if !ok {
+		prev, ok = handler.queued[k]
+	}
+	if ok {
+		if prev.priority-1 < prio {
+			return prev.priority - 1
+		}
+		return prio
+	}
+	if acct := handler.IAccountHandler.FindAccount(tx.From, false); acct != nil && acct.GetNonce() < tx.Nonce {
+		return -int64(tx.Nonce - acct.GetNonce())
+	}
+	return prio
+}
+
+// mempoolSender returns the sender of `tx` in the mempool.
+// The mempool v1 keeps only one tx per sender, so the sender has the nonce of the tx
+// not to reject the following txs of the same address.
+func mempoolSender(tx *ctrlertypes.Trx) string {
+	return pendingTrxKey(tx.From, tx.Nonce)
+}
+
+// checkTxResponse returns the response of CheckTx accepting the tx of `ctx`.
+func checkTxResponse(ctx *ctrlertypes.TrxContext, priority int64) abcitypes.ResponseCheckTx {
+	return abcitypes.ResponseCheckTx{
+		Code:      abcitypes.CodeTypeOK,
+		Data:      ctx.RetData,
+		GasWanted: int64(ctx.Tx.Gas),
+		GasUsed:   int64(ctx.GasUsed),
+		Sender:    mempoolSender(ctx.Tx),
+		Priority:  priority,
+	}
+}
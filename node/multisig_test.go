@@ -0,0 +1,75 @@
+package node
+
+import (
+	"github.com/holiman/uint256"
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/libs/web3"
+	"github.com/rigochain/rigo-go/types"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"testing"
+)
+
+func TestMultiSigAccount(t *testing.T) {
+	app := newTestRigoApp(t, "multisig", nil)
+	defer func() { _ = app.Stop() }()
+
+	val := newTestValidator(t, 1_000_000)
+	w0, w1, w2, w3 := web3.NewWallet(nil), web3.NewWallet(nil), web3.NewWallet(nil), web3.NewWallet(nil)
+	initTestChain(t, app, val, w0)
+
+	govParams := ctrlertypes.DefaultGovParams()
+	initBalance := uint256.MustFromDecimal("10000000000000000000") // 10 RIGO
+	tx := web3.NewTrxMultiSig(w0.Address(), 0, govParams.MinTrxGas(), govParams.GasPrice(), initBalance, 2, w1.GetPubKey(), w2.GetPubKey(), w3.GetPubKey())
+	msAddr := tx.To
+	execTestBlock(t, app, 1, val, signTestTrx(t, w0, tx))
+
+	msAcct := app.acctCtrler.ReadAccount(msAddr)
+	require.NotNil(t, msAcct.GetMultiSig())
+	require.EqualValues(t, 2, msAcct.GetMultiSig().Threshold)
+	require.Equal(t, initBalance, msAcct.Balance)
+
+	// the multi-signature account can not be registered again.
+	tx = web3.NewTrxMultiSig(w0.Address(), 1, govParams.MinTrxGas(), govParams.GasPrice(), uint256.NewInt(0), 2, w3.GetPubKey(), w2.GetPubKey(), w1.GetPubKey())
+	require.Equal(t, msAddr, tx.To)
+	app.BeginBlock(testBeginBlockReq(2, val))
+	resp := app.DeliverTx(abcitypes.RequestDeliverTx{Tx: signTestTrx(t, w0, tx)})
+	require.NotEqual(t, abcitypes.CodeTypeOK, resp.Code)
+
+	coSign := func(tx *ctrlertypes.Trx, signers ...*web3.Wallet) []byte {
+		for _, w := range signers {
+			_, err := w.CoSignTrxRLP(tx, testChainID)
+			require.NoError(t, err)
+		}
+		bz, xerr := tx.Encode()
+		require.NoError(t, xerr)
+		return bz
+	}
+
+	// transfer
+	to := types.RandAddress()
+	resp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: coSign(web3.NewTrxTransfer(msAddr, to, 0, govParams.MinTrxGas(), govParams.GasPrice(), uint256.NewInt(1000)), w1)})
+	require.Equal(t, xerrors.ErrCodeDeliverTx, resp.Code, "not enough signatures")
+	resp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: signTestTrx(t, w1, web3.NewTrxTransfer(msAddr, to, 0, govParams.MinTrxGas(), govParams.GasPrice(), uint256.NewInt(1000)))})
+	require.NotEqual(t, abcitypes.CodeTypeOK, resp.Code, "single signature")
+	resp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: coSign(web3.NewTrxTransfer(msAddr, to, 0, govParams.MinTrxGas(), govParams.GasPrice(), uint256.NewInt(1000)), w3, w1)})
+	require.Equal(t, abcitypes.CodeTypeOK, resp.Code, resp.Log)
+
+	// staking
+	stakeAmt := ctrlertypes.PowerToAmount(1)
+	resp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: coSign(web3.NewTrxStaking(msAddr, msAddr, 1, govParams.MinTrxGas(), govParams.GasPrice(), stakeAmt), w1, w2)})
+	require.NotEqual(t, abcitypes.CodeTypeOK, resp.Code, "a multi-signature account can not be a validator")
+	resp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: coSign(web3.NewTrxStaking(msAddr, val.addr, 1, govParams.MinTrxGas(), govParams.GasPrice(), stakeAmt), w1, w2)})
+	require.Equal(t, abcitypes.CodeTypeOK, resp.Code, resp.Log)
+	app.EndBlock(abcitypes.RequestEndBlock{Height: 2})
+	app.Commit()
+
+	require.EqualValues(t, 1000, app.acctCtrler.ReadAccount(to).Balance.Uint64())
+	msAcct = app.acctCtrler.ReadAccount(msAddr)
+	require.EqualValues(t, 2, msAcct.GetNonce())
+	require.NotNil(t, msAcct.GetMultiSig())
+	stakes := app.stakeCtrler.Delegatee(val.addr).GetAllStakes()
+	require.Len(t, stakes, 2)
+	require.Equal(t, types.Address(msAddr), stakes[1].From)
+}
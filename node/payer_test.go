@@ -0,0 +1,96 @@
+package node
+
+import (
+	"github.com/holiman/uint256"
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/libs/web3"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"testing"
+)
+
+func TestFeePayer(t *testing.T) {
+	app := newTestRigoApp(t, "payer", nil)
+	defer func() { _ = app.Stop() }()
+
+	val := newTestValidator(t, 1_000_000)
+	payer, user := web3.NewWallet(nil), web3.NewWallet(nil)
+	initTestChain(t, app, val, payer)
+	balance0 := new(uint256.Int).Mul(uint256.NewInt(1_000_000_000), uint256.NewInt(1_000_000_000_000_000_000))
+	execTestBlock(t, app, 1, val)
+
+	govParams := ctrlertypes.DefaultGovParams()
+	sponsor := func(tx *ctrlertypes.Trx, w *web3.Wallet) []byte {
+		_, _, err := user.SignTrxRLP(tx, testChainID)
+		require.NoError(t, err)
+		if w != nil {
+			_, _, err = w.SignTrxPayerRLP(tx, testChainID)
+			require.NoError(t, err)
+		}
+		bz, xerr := tx.Encode()
+		require.NoError(t, xerr)
+		return bz
+	}
+	newSetDoc := func() *ctrlertypes.Trx {
+		return web3.SetTrxPayer(web3.NewTrxSetDoc(user.Address(), 0, govParams.MinTrxGas(), govParams.GasPrice(), "user", "https://user.doc"), payer.Address())
+	}
+
+	// `user` has never held any asset.
+	resp := app.CheckTx(abcitypes.RequestCheckTx{Tx: sponsor(newSetDoc(), payer), Type: abcitypes.CheckTxType_New})
+	require.Equal(t, abcitypes.CodeTypeOK, resp.Code, resp.Log)
+	resp = app.CheckTx(abcitypes.RequestCheckTx{Tx: signTestTrx(t, user, web3.NewTrxSetDoc(user.Address(), 0, govParams.MinTrxGas(), govParams.GasPrice(), "user", "https://user.doc")), Type: abcitypes.CheckTxType_New})
+	require.NotEqual(t, abcitypes.CodeTypeOK, resp.Code, "no fee payer")
+
+	app.BeginBlock(testBeginBlockReq(2, val))
+
+	tx := newSetDoc()
+	tx.Version = 1
+	deliverResp := app.DeliverTx(abcitypes.RequestDeliverTx{Tx: sponsor(tx, payer)})
+	require.NotEqual(t, abcitypes.CodeTypeOK, deliverResp.Code, "the tx version having no fee payer")
+	deliverResp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: sponsor(newSetDoc(), nil)})
+	require.NotEqual(t, abcitypes.CodeTypeOK, deliverResp.Code, "not signed by the payer")
+	deliverResp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: sponsor(newSetDoc(), user)})
+	require.NotEqual(t, abcitypes.CodeTypeOK, deliverResp.Code, "signed by other than the payer")
+	tx = newSetDoc()
+	tx.Payer = user.Address()
+	deliverResp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: sponsor(tx, user)})
+	require.NotEqual(t, abcitypes.CodeTypeOK, deliverResp.Code, "the sender can not be the payer")
+
+	deliverResp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: sponsor(newSetDoc(), payer)})
+	require.Equal(t, abcitypes.CodeTypeOK, deliverResp.Code, deliverResp.Log)
+
+	// the sender transfers all of its balance while the fee is paid by the payer.
+	deliverResp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: signTestTrx(t, payer, web3.NewTrxTransfer(payer.Address(), user.Address(), 0, govParams.MinTrxGas(), govParams.GasPrice(), uint256.NewInt(1000)))})
+	require.Equal(t, abcitypes.CodeTypeOK, deliverResp.Code, deliverResp.Log)
+	tx = web3.SetTrxPayer(web3.NewTrxTransfer(user.Address(), val.addr, 1, govParams.MinTrxGas(), govParams.GasPrice(), uint256.NewInt(1000)), payer.Address())
+	deliverResp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: sponsor(tx, payer)})
+	require.Equal(t, abcitypes.CodeTypeOK, deliverResp.Code, deliverResp.Log)
+
+	// a failed sponsored tx does not create the account of its sender.
+	stranger := web3.NewWallet(nil)
+	tx = web3.SetTrxPayer(web3.NewTrxSetDoc(stranger.Address(), 0, govParams.MinTrxGas(), govParams.GasPrice(), "stranger", "https://stranger.doc"), payer.Address())
+	_, _, err := stranger.SignTrxRLP(tx, testChainID)
+	require.NoError(t, err)
+	_, _, err = stranger.SignTrxPayerRLP(tx, testChainID)
+	require.NoError(t, err)
+	bz, xerr := tx.Encode()
+	require.NoError(t, xerr)
+	deliverResp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: bz})
+	require.NotEqual(t, abcitypes.CodeTypeOK, deliverResp.Code, "signed by other than the payer")
+
+	app.EndBlock(abcitypes.RequestEndBlock{Height: 2})
+	app.Commit()
+
+	require.Nil(t, app.acctCtrler.FindAccount(stranger.Address(), false))
+
+	userAcct := app.acctCtrler.ReadAccount(user.Address())
+	require.Equal(t, "user", userAcct.Name)
+	require.True(t, userAcct.Balance.IsZero())
+	require.EqualValues(t, 2, userAcct.GetNonce())
+
+	payerAcct := app.acctCtrler.ReadAccount(payer.Address())
+	spent := new(uint256.Int).Mul(govParams.MinTrxFee(), uint256.NewInt(3))
+	_ = spent.Add(spent, uint256.NewInt(1000))
+	require.Equal(t, new(uint256.Int).Sub(balance0, spent), payerAcct.Balance)
+	require.EqualValues(t, 1, payerAcct.GetNonce())
+}
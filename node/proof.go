@@ -0,0 +1,80 @@
+package node
+
+import (
+	rctypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	tmcrypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
+)
+
+// The names of the modules in the Merkle tree of the app hash.
+// The key path of a proven item is `/<module>/<ledger>/<key>`. (see `rctypes.ProofKeyPath`)
+const (
+	ProofModuleGov     = "gov"
+	ProofModuleAccount = "account"
+	ProofModuleStake   = "stake"
+	ProofModuleVM      = "vm"
+)
+
+func appRootHashes(govHash, acctHash, stakeHash, vmHash []byte) rctypes.MerkleRoots {
+	return rctypes.MerkleRoots{
+		ProofModuleGov:     govHash,
+		ProofModuleAccount: acctHash,
+		ProofModuleStake:   stakeHash,
+		ProofModuleVM:      vmHash,
+	}
+}
+
+func (ctrler *RigoApp) appRootHashesAt(height int64) (rctypes.MerkleRoots, xerrors.XError) {
+	h0, xerr := ctrler.govCtrler.RootHashAt(height)
+	if xerr != nil {
+		return nil, xerr
+	}
+	h1, xerr := ctrler.acctCtrler.RootHashAt(height)
+	if xerr != nil {
+		return nil, xerr
+	}
+	h2, xerr := ctrler.stakeCtrler.RootHashAt(height)
+	if xerr != nil {
+		return nil, xerr
+	}
+	h3, xerr := ctrler.vmCtrler.RootHashAt(height)
+	if xerr != nil {
+		return nil, xerr
+	}
+	return appRootHashes(h0, h1, h2, h3), nil
+}
+
+// prove returns the item queried by `req` as stored in the ledger and the proofs chained to the app hash of `req.Height`.
+// The app hash of `req.Height` is included in the header of the next block.
+func (ctrler *RigoApp) prove(req abcitypes.RequestQuery) ([]byte, *tmcrypto.ProofOps, xerrors.XError) {
+	var module string
+	var handler rctypes.IProofHandler
+
+	switch req.Path {
+	case "account":
+		module, handler = ProofModuleAccount, ctrler.acctCtrler
+	case "stakes", "delegatee", "reward", "slashes":
+		module, handler = ProofModuleStake, ctrler.stakeCtrler
+	case "proposal", "gov_params":
+		module, handler = ProofModuleGov, ctrler.govCtrler
+	default:
+		return nil, nil, xerrors.ErrQuery.Wrapf("the query path '%v' does not support proofs", req.Path)
+	}
+
+	bz, proofOps, xerr := handler.Prove(req)
+	if xerr != nil {
+		return nil, nil, xerr
+	}
+
+	roots, xerr := ctrler.appRootHashesAt(req.Height)
+	if xerr != nil {
+		return nil, nil, xerrors.ErrQuery.Wrap(xerr)
+	}
+	rootOp, xerr := roots.ProofOp(module)
+	if xerr != nil {
+		return nil, nil, xerrors.ErrQuery.Wrap(xerr)
+	}
+	proofOps.Ops = append(proofOps.Ops, *rootOp)
+	return bz, proofOps, nil
+}
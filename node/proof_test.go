@@ -0,0 +1,95 @@
+package node
+
+import (
+	"github.com/holiman/uint256"
+	"github.com/rigochain/rigo-go/ctrlers/stake"
+	rctypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/ledger"
+	"github.com/rigochain/rigo-go/libs/web3"
+	"github.com/rigochain/rigo-go/types/bytes"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"testing"
+)
+
+func TestQuery_Prove(t *testing.T) {
+	app := newTestRigoApp(t, "prove", nil)
+	defer func() { _ = app.Stop() }()
+
+	val := newTestValidator(t, 1_000_000)
+	w0, w1 := web3.NewWallet(nil), web3.NewWallet(nil)
+	initTestChain(t, app, val, w0)
+
+	appHashes := map[int64][]byte{
+		1: execTestBlock(t, app, 1, val, newTestTransfer(t, w0, w1, 0)),
+		2: execTestBlock(t, app, 2, val, newTestTransfer(t, w0, w1, 1)),
+	}
+	for h := int64(3); h <= 10; h++ {
+		appHashes[h] = execTestBlock(t, app, h, val)
+	}
+
+	ledgerKey := func(bz []byte) []byte {
+		k := ledger.ToLedgerKey(bz)
+		return k[:]
+	}
+	prove := func(path string, data []byte, height int64) abcitypes.ResponseQuery {
+		resp := app.Query(abcitypes.RequestQuery{Path: path, Data: data, Height: height, Prove: true})
+		require.Equal(t, abcitypes.CodeTypeOK, resp.Code, resp.Log)
+		require.NotNil(t, resp.ProofOps)
+		require.Len(t, resp.ProofOps.Ops, 3)
+		return resp
+	}
+
+	// the account and its proof
+	resp := prove("account", w1.Address(), 1)
+	acct := &rctypes.Account{}
+	require.NoError(t, acct.Decode(resp.Value))
+	require.Equal(t, uint256.NewInt(1000), acct.Balance)
+	keyPath := rctypes.ProofKeyPath(ProofModuleAccount, "accounts", ledgerKey(w1.Address()))
+	require.NoError(t, rctypes.VerifyProof(resp.ProofOps, appHashes[1], keyPath, resp.Value))
+
+	// the proof is chained only to the app hash of the queried height.
+	require.Error(t, rctypes.VerifyProof(resp.ProofOps, appHashes[2], keyPath, resp.Value))
+	// the tampered account is not verified.
+	acct.Balance = uint256.NewInt(1_000_000)
+	tampered, xerr := acct.Encode()
+	require.NoError(t, xerr)
+	require.Error(t, rctypes.VerifyProof(resp.ProofOps, appHashes[1], keyPath, tampered))
+	// the proof of an account is not the proof of another account.
+	otherKeyPath := rctypes.ProofKeyPath(ProofModuleAccount, "accounts", ledgerKey(w0.Address()))
+	require.Error(t, rctypes.VerifyProof(resp.ProofOps, appHashes[1], otherKeyPath, resp.Value))
+
+	// the absence of an account
+	w2 := web3.NewWallet(nil)
+	resp = prove("account", w2.Address(), 10)
+	require.Nil(t, resp.Value)
+	keyPath = rctypes.ProofKeyPath(ProofModuleAccount, "accounts", ledgerKey(w2.Address()))
+	require.NoError(t, rctypes.VerifyProof(resp.ProofOps, appHashes[10], keyPath, nil))
+
+	// the delegatee
+	resp = prove("delegatee", val.addr, 10)
+	delegatee := &stake.Delegatee{}
+	require.NoError(t, delegatee.Decode(resp.Value))
+	require.Equal(t, val.power, delegatee.TotalPower)
+	keyPath = rctypes.ProofKeyPath(ProofModuleStake, "delegatees", ledgerKey(val.addr))
+	require.NoError(t, rctypes.VerifyProof(resp.ProofOps, appHashes[10], keyPath, resp.Value))
+
+	// the reward is proven at the height where the reward ledger is included in the app hash.
+	resp = prove("reward", val.addr, 10)
+	require.NotNil(t, resp.Value)
+	keyPath = rctypes.ProofKeyPath(ProofModuleStake, "rewards", ledgerKey(val.addr))
+	require.NoError(t, rctypes.VerifyProof(resp.ProofOps, appHashes[10], keyPath, resp.Value))
+	require.NotEqual(t, abcitypes.CodeTypeOK, app.Query(abcitypes.RequestQuery{Path: "reward", Data: val.addr, Height: 9, Prove: true}).Code)
+
+	// the governance parameters
+	resp = prove("gov_params", nil, 5)
+	keyPath = rctypes.ProofKeyPath(ProofModuleGov, "gov_params", bytes.ZeroBytes(32))
+	require.NoError(t, rctypes.VerifyProof(resp.ProofOps, appHashes[5], keyPath, resp.Value))
+
+	// the aggregated results are not proven.
+	for _, path := range []string{"stakes", "stakes/total_power", "vm_call"} {
+		resp := app.Query(abcitypes.RequestQuery{Path: path, Data: val.addr, Height: 10, Prove: true})
+		require.NotEqual(t, abcitypes.CodeTypeOK, resp.Code, path)
+		require.Nil(t, resp.ProofOps, path)
+	}
+}
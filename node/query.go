@@ -1,6 +1,7 @@
 package node
 
 import (
+	rctypes "github.com/rigochain/rigo-go/ctrlers/types"
 	rtypes "github.com/rigochain/rigo-go/types"
 	"github.com/rigochain/rigo-go/types/bytes"
 	"github.com/rigochain/rigo-go/types/xerrors"
@@ -22,6 +23,17 @@ func (ctrler *RigoApp) Query(req abcitypes.RequestQuery) abcitypes.ResponseQuery
 
 	var xerr xerrors.XError
 
+	if req.Prove {
+		// the value is the item as stored in the ledger, so that it can be verified with the proofs.
+		response.Value, response.ProofOps, xerr = ctrler.prove(req)
+		if xerr != nil {
+			ctrler.logger.Error("RigoApp - Query returns error", "error", xerr, "request", req)
+			response.Code = xerr.Code()
+			response.Log = xerr.Error()
+		}
+		return response
+	}
+
 	switch req.Path {
 	case "account":
 		response.Value, xerr = ctrler.acctCtrler.Query(req)
@@ -39,7 +51,15 @@ func (ctrler *RigoApp) Query(req abcitypes.RequestQuery) abcitypes.ResponseQuery
 			}
 		}
 
-	case "stakes", "stakes/total_power", "stakes/voting_power", "delegatee", "reward":
+	case "fee_distribution":
+		response.Value, xerr = ctrler.acctCtrler.Query(req)
+	case "community_pool":
+		response.Value, xerr = ctrler.queryCommunityPool(req.Height)
+	case "supply":
+		response.Value, xerr = ctrler.querySupply(req.Height)
+	case "base_fee":
+		response.Value, xerr = ctrler.queryBaseFee()
+	case "stakes", "stakes/total_power", "stakes/voting_power", "delegatee", "reward", "slashes", "slashes/delegator":
 		response.Value, xerr = ctrler.stakeCtrler.Query(req)
 	case "proposal", "gov_params":
 		response.Value, xerr = ctrler.govCtrler.Query(req)
@@ -57,3 +77,51 @@ func (ctrler *RigoApp) Query(req abcitypes.RequestQuery) abcitypes.ResponseQuery
 
 	return response
 }
+
+// queryCommunityPool returns the balance of the community pool and the history of its spends at `height`.
+func (ctrler *RigoApp) queryCommunityPool(height int64) ([]byte, xerrors.XError) {
+	acctHandler, xerr := ctrler.acctCtrler.ImmutableAcctCtrlerAt(height)
+	if xerr != nil {
+		return nil, xerrors.ErrQuery.Wrap(xerr)
+	}
+	balance := "0"
+	if acct := acctHandler.FindAccount(rctypes.CommunityPoolAddress, false); acct != nil {
+		balance = acct.Balance.Dec()
+	}
+
+	spends, xerr := ctrler.govCtrler.CommunitySpends(height)
+	if xerr != nil {
+		return nil, xerrors.ErrQuery.Wrap(xerr)
+	}
+
+	bz, err := tmjson.Marshal(&struct {
+		Address rtypes.Address            `json:"address"`
+		Balance string                    `json:"balance"`
+		Spends  []*rctypes.CommunitySpend `json:"spends"`
+	}{
+		Address: rctypes.CommunityPoolAddress,
+		Balance: balance,
+		Spends:  spends,
+	})
+	if err != nil {
+		return nil, xerrors.ErrQuery.Wrap(err)
+	}
+	return bz, nil
+}
+
+// queryBaseFee returns the base fee of the next block.
+// The gas price of a tx should not be less than it, so that the tx is included in the next block.
+func (ctrler *RigoApp) queryBaseFee() ([]byte, xerrors.XError) {
+	baseFee := rctypes.NextBaseFee(ctrler.lastBlockCtx.BaseFee(), ctrler.lastBlockCtx.GasUsed(), ctrler.govCtrler)
+	bz, err := tmjson.Marshal(&struct {
+		Height  int64  `json:"height,string"`
+		BaseFee string `json:"baseFee"`
+	}{
+		Height:  ctrler.lastBlockCtx.Height() + 1,
+		BaseFee: baseFee.Dec(),
+	})
+	if err != nil {
+		return nil, xerrors.ErrQuery.Wrap(err)
+	}
+	return bz, nil
+}
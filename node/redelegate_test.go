@@ -0,0 +1,81 @@
+package node
+
+import (
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/libs/web3"
+	"github.com/rigochain/rigo-go/types"
+	"github.com/rigochain/rigo-go/types/bytes"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	"testing"
+)
+
+func TestRedelegate(t *testing.T) {
+	app := newTestRigoApp(t, "redelegate", nil)
+	defer func() { _ = app.Stop() }()
+
+	val := newTestValidator(t, 1_000_000)
+	w0, w1 := web3.NewWallet(nil), web3.NewWallet(nil)
+	initTestChain(t, app, val, w0, w1)
+
+	govParams := ctrlertypes.DefaultGovParams()
+	delegated := int64(1000)
+	txStaking := signTestTrx(t, w0, web3.NewTrxStaking(w0.Address(), val.addr, 0, govParams.MinTrxGas(), govParams.GasPrice(), ctrlertypes.PowerToAmount(delegated)))
+	stakeTxHash := bytes.HexBytes(tmtypes.Tx(txStaking).Hash())
+
+	// `w1` becomes another validator.
+	execTestBlock(t, app, 1, val,
+		txStaking,
+		signTestTrx(t, w1, web3.NewTrxStaking(w1.Address(), w1.Address(), 0, govParams.MinTrxGas(), govParams.GasPrice(), govParams.MinValidatorStake())),
+	)
+
+	app.BeginBlock(testBeginBlockReq(2, val))
+	newRedelegate := func(nonce uint64, src, to types.Address, txhash bytes.HexBytes) []byte {
+		return signTestTrx(t, w0, web3.NewTrxRedelegate(w0.Address(), src, to, nonce, govParams.MinTrxGas(), govParams.GasPrice(), txhash))
+	}
+	resp := app.DeliverTx(abcitypes.RequestDeliverTx{Tx: newRedelegate(1, val.addr, val.addr, stakeTxHash)})
+	require.NotEqual(t, abcitypes.CodeTypeOK, resp.Code, "the same delegatee")
+	resp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: newRedelegate(1, val.addr, w1.Address(), bytes.RandBytes(32))})
+	require.NotEqual(t, abcitypes.CodeTypeOK, resp.Code, "unknown stake")
+	resp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: newRedelegate(1, w1.Address(), val.addr, stakeTxHash)})
+	require.NotEqual(t, abcitypes.CodeTypeOK, resp.Code, "the stake is not delegated to the source")
+	resp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: signTestTrx(t, w1, web3.NewTrxRedelegate(w1.Address(), val.addr, w1.Address(), 1, govParams.MinTrxGas(), govParams.GasPrice(), stakeTxHash))})
+	require.NotEqual(t, abcitypes.CodeTypeOK, resp.Code, "not the owner")
+	resp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: newRedelegate(1, val.addr, w1.Address(), stakeTxHash)})
+	require.Equal(t, abcitypes.CodeTypeOK, resp.Code, resp.Log)
+	resp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: newRedelegate(2, w1.Address(), val.addr, stakeTxHash)})
+	require.NotEqual(t, abcitypes.CodeTypeOK, resp.Code, "the stake is in the unbonding window of the last redelegation")
+	app.EndBlock(abcitypes.RequestEndBlock{Height: 2})
+	app.Commit()
+
+	// the stake is moved at once without being frozen.
+	require.Len(t, app.stakeCtrler.ReadFrozenStakes(), 0)
+	require.EqualValues(t, val.power, app.stakeCtrler.Delegatee(val.addr).GetTotalPower())
+	dst := app.stakeCtrler.Delegatee(w1.Address())
+	_, s0 := dst.FindStake(stakeTxHash)
+	require.NotNil(t, s0)
+	require.EqualValues(t, delegated, s0.Power)
+	require.Equal(t, types.Address(val.addr), s0.RedelegatedFrom)
+	require.EqualValues(t, 2, s0.RedelegatedHeight)
+	require.EqualValues(t, 3, s0.StartHeight)
+
+	// the redelegated stake is slashed for the misbehavior of the source validator before the redelegation.
+	req := testBeginBlockReq(3, val)
+	req.ByzantineValidators = []abcitypes.Evidence{
+		{
+			Type:      abcitypes.EvidenceType_DUPLICATE_VOTE,
+			Validator: abcitypes.Validator{Address: val.addr, Power: val.power},
+			Height:    1,
+		},
+	}
+	app.BeginBlock(req)
+	app.EndBlock(abcitypes.RequestEndBlock{Height: 3})
+	app.Commit()
+
+	slashed := delegated * govParams.SlashRatio() / 100
+	_, s0 = app.stakeCtrler.Delegatee(w1.Address()).FindStake(stakeTxHash)
+	require.EqualValues(t, delegated-slashed, s0.Power)
+	require.EqualValues(t, ctrlertypes.AmountToPower(govParams.MinValidatorStake())+delegated-slashed,
+		app.stakeCtrler.Delegatee(w1.Address()).GetTotalPower())
+}
@@ -0,0 +1,219 @@
+package node
+
+import (
+	"bytes"
+	"fmt"
+	cfg "github.com/rigochain/rigo-go/cmd/config"
+	rctypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmos "github.com/tendermint/tendermint/libs/os"
+	tmstore "github.com/tendermint/tendermint/proto/tendermint/store"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmstate "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	dbm "github.com/tendermint/tm-db"
+	"path/filepath"
+)
+
+// RollbackState rewinds the application state and the tendermint state by `n` heights.
+// The block of the rolled back height + 1 is left in the block store,
+// so it is re-executed when the node is restarted, and the next blocks are fetched from the peers.
+// It returns the rolled back height and its app hash.
+func RollbackState(config *cfg.Config, n int64, logger log.Logger) (int64, []byte, error) {
+	if n <= 0 {
+		return -1, nil, xerrors.ErrRollback.Wrapf("wrong number of heights: %v", n)
+	}
+
+	app := NewRigoApp(config, logger)
+	defer func() { _ = app.Stop() }()
+
+	// Info() finishes the commit interrupted by a crash.
+	lastHeight := app.Info(abcitypes.RequestInfo{}).LastBlockHeight
+	height := lastHeight - n
+	if height < 1 {
+		return -1, nil, xerrors.ErrRollback.Wrapf("can not rollback %v heights from the height %v", n, lastHeight)
+	}
+
+	blockStoreDB, stateStore, err := openTmStores(config)
+	if err != nil {
+		return -1, nil, err
+	}
+	defer func() {
+		_ = blockStoreDB.Close()
+		_ = stateStore.Close()
+	}()
+
+	// Nothing is changed until it is confirmed that all ledgers have the state of `height`.
+	header, appHash, err := loadTmHeader(blockStoreDB, stateStore, height)
+	if err != nil {
+		return -1, nil, err
+	}
+	roots, xerr := app.appRootHashesAt(height)
+	if xerr != nil {
+		return -1, nil, xerrors.ErrRollback.Wrap(xerr)
+	}
+	if hash := roots.Hash(); !bytes.Equal(hash, appHash) {
+		return -1, nil, xerrors.ErrRollback.Wrapf("the app hash of the height %v is wrong - expected:%X, actual:%X", height, appHash, hash)
+	}
+
+	blockCtx := rctypes.NewBlockContext(abcitypes.RequestBeginBlock{Header: header}, nil, nil, nil)
+	blockCtx.SetAppHash(appHash)
+	if xerr := app.rollback(blockCtx); xerr != nil {
+		return -1, nil, xerr
+	}
+
+	// The tendermint state is rewound last.
+	// If it fails, the tendermint state is ahead of the app, and the blocks are replayed to the app when the node is restarted.
+	if err := rollbackTmState(blockStoreDB, stateStore, height); err != nil {
+		return -1, nil, err
+	}
+
+	logger.Info("Rollback the state", "from", lastHeight, "to", height, "app hash", blockCtx.AppHash())
+	return height, appHash, nil
+}
+
+// rollback rewinds all ledgers to the height of `blockCtx` and makes `blockCtx` the last block context.
+func (ctrler *RigoApp) rollback(blockCtx *rctypes.BlockContext) xerrors.XError {
+	ctrler.mtx.Lock()
+	defer ctrler.mtx.Unlock()
+
+	height := blockCtx.Height()
+	if xerr := ctrler.rollbackCtrlers(height); xerr != nil {
+		return xerr
+	}
+	if err := ctrler.metaDB.DelUpgradesAbove(height); err != nil {
+		return xerrors.ErrRollback.Wrap(err)
+	}
+	ctrler.restoreBlockFee(blockCtx)
+	if err := ctrler.metaDB.PutLastBlockContext(blockCtx); err != nil {
+		return xerrors.ErrRollback.Wrap(err)
+	}
+	if err := ctrler.metaDB.PutLastBlockHeight(height); err != nil {
+		return xerrors.ErrRollback.Wrap(err)
+	}
+	if err := ctrler.snapshotStore.removeAbove(height); err != nil {
+		return xerrors.ErrRollback.Wrap(err)
+	}
+
+	ctrler.lastBlockCtx = blockCtx
+	ctrler.checkAcctHandler = newCheckAcctHandler(ctrler.acctCtrler)
+	return nil
+}
+
+func openTmStores(config *cfg.Config) (dbm.DB, tmstate.Store, error) {
+	dbType := dbm.BackendType(config.DBBackend)
+
+	if !tmos.FileExists(filepath.Join(config.DBDir(), "blockstore.db")) {
+		return nil, nil, xerrors.ErrRollback.Wrapf("no blockstore found in %v", config.DBDir())
+	}
+	blockStoreDB, err := dbm.NewDB("blockstore", dbType, config.DBDir())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !tmos.FileExists(filepath.Join(config.DBDir(), "state.db")) {
+		_ = blockStoreDB.Close()
+		return nil, nil, xerrors.ErrRollback.Wrapf("no statestore found in %v", config.DBDir())
+	}
+	stateDB, err := dbm.NewDB("state", dbType, config.DBDir())
+	if err != nil {
+		_ = blockStoreDB.Close()
+		return nil, nil, err
+	}
+	stateStore := tmstate.NewStore(stateDB, tmstate.StoreOptions{
+		DiscardABCIResponses: config.Storage.DiscardABCIResponses,
+	})
+
+	return blockStoreDB, stateStore, nil
+}
+
+// loadTmHeader returns the header of `height` and the app hash of `height`,
+// which is included in the header of the next block.
+func loadTmHeader(blockStoreDB dbm.DB, stateStore tmstate.Store, height int64) (tmproto.Header, []byte, error) {
+	state, err := stateStore.Load()
+	if err != nil {
+		return tmproto.Header{}, nil, err
+	}
+	if state.LastBlockHeight <= height {
+		return tmproto.Header{}, nil, xerrors.ErrRollback.Wrapf("the tendermint state(%v) is not higher than %v", state.LastBlockHeight, height)
+	}
+
+	blockStore := store.NewBlockStore(blockStoreDB)
+	meta := blockStore.LoadBlockMeta(height)
+	if meta == nil {
+		return tmproto.Header{}, nil, xerrors.ErrRollback.Wrapf("block at height %v not found", height)
+	}
+	next := blockStore.LoadBlockMeta(height + 1)
+	if next == nil {
+		return tmproto.Header{}, nil, xerrors.ErrRollback.Wrapf("block at height %v not found", height+1)
+	}
+	return *meta.Header.ToProto(), next.Header.AppHash, nil
+}
+
+// rollbackTmState rewinds the tendermint state to `height`.
+// tmstate.Rollback rewinds the state only by one height and requires that the block store is not ahead of the state,
+// so the blocks higher than `height + 1` are removed from the block store.
+func rollbackTmState(blockStoreDB dbm.DB, stateStore tmstate.Store, height int64) error {
+	for {
+		state, err := stateStore.Load()
+		if err != nil {
+			return err
+		}
+		if state.LastBlockHeight < height {
+			return xerrors.ErrRollback.Wrapf("the tendermint state(%v) is lower than %v", state.LastBlockHeight, height)
+		}
+		if state.LastBlockHeight == height {
+			return nil
+		}
+
+		blockStore := store.NewBlockStore(blockStoreDB)
+		if blockStore.Height() > state.LastBlockHeight {
+			if err := removeLastBlock(blockStoreDB, blockStore); err != nil {
+				return err
+			}
+			blockStore = store.NewBlockStore(blockStoreDB)
+		}
+		if _, _, err := tmstate.Rollback(blockStore, stateStore); err != nil {
+			return err
+		}
+	}
+}
+
+// removeLastBlock removes the highest block and its commits from the block store.
+func removeLastBlock(db dbm.DB, blockStore *store.BlockStore) error {
+	height := blockStore.Height()
+	meta := blockStore.LoadBlockMeta(height)
+	if meta == nil {
+		return xerrors.ErrRollback.Wrapf("block at height %v not found", height)
+	}
+
+	batch := db.NewBatch()
+	defer batch.Close()
+
+	keys := [][]byte{
+		[]byte(fmt.Sprintf("H:%v", height)),
+		[]byte(fmt.Sprintf("BH:%x", meta.BlockID.Hash)),
+		// the commit of `height - 1` is saved with the block of `height`.
+		[]byte(fmt.Sprintf("C:%v", height-1)),
+		[]byte(fmt.Sprintf("SC:%v", height)),
+	}
+	for i := 0; i < int(meta.BlockID.PartSetHeader.Total); i++ {
+		keys = append(keys, []byte(fmt.Sprintf("P:%v:%v", height, i)))
+	}
+	for _, k := range keys {
+		if err := batch.Delete(k); err != nil {
+			return err
+		}
+	}
+	if err := batch.WriteSync(); err != nil {
+		return err
+	}
+
+	store.SaveBlockStoreState(&tmstore.BlockStoreState{
+		Base:   blockStore.Base(),
+		Height: height - 1,
+	}, db)
+	return nil
+}
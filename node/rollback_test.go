@@ -0,0 +1,210 @@
+package node
+
+import (
+	"github.com/holiman/uint256"
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/libs/web3"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/log"
+	tmstate "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	tmtypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+	"testing"
+	"time"
+)
+
+func TestRollbackState(t *testing.T) {
+	app := newTestRigoApp(t, "rollback", nil)
+	config := app.rootConfig
+
+	val := newTestValidator(t, 1_000_000)
+	w0, w1 := web3.NewWallet(nil), web3.NewWallet(nil)
+	initTestChain(t, app, val, w0)
+
+	lastHeight := int64(12)
+	appHashes := make(map[int64][]byte)
+	txs := make(map[int64][]byte)
+	for h := int64(1); h <= lastHeight; h++ {
+		txs[h] = newTestTransfer(t, w0, w1, uint64(h-1))
+		appHashes[h] = execTestBlock(t, app, h, val, txs[h])
+	}
+	require.NoError(t, app.Stop())
+
+	saveTestTmChain(t, config.DBDir(), appHashes, lastHeight)
+
+	_, _, err := RollbackState(config, lastHeight, log.NewNopLogger())
+	require.Error(t, err)
+
+	height, appHash, err := RollbackState(config, 5, log.NewNopLogger())
+	require.NoError(t, err)
+	require.Equal(t, lastHeight-5, height)
+	require.Equal(t, appHashes[height], appHash)
+
+	// the tendermint state is rolled back and the block of `height + 1` is left to be re-executed.
+	blockStoreDB, stateStore, err := openTmStores(config)
+	require.NoError(t, err)
+	state, err := stateStore.Load()
+	require.NoError(t, err)
+	require.Equal(t, height, state.LastBlockHeight)
+	require.Equal(t, appHashes[height], []byte(state.AppHash))
+	blockStore := store.NewBlockStore(blockStoreDB)
+	require.Equal(t, height+1, blockStore.Height())
+	require.Nil(t, blockStore.LoadBlockMeta(height+2))
+	require.NotNil(t, blockStore.LoadSeenCommit(height+1))
+	require.NoError(t, blockStoreDB.Close())
+	require.NoError(t, stateStore.Close())
+
+	app = restartTestRigoApp(t, config)
+	defer func() { _ = app.Stop() }()
+
+	info := app.Info(abcitypes.RequestInfo{})
+	require.Equal(t, height, info.LastBlockHeight)
+	require.EqualValues(t, appHashes[height], info.LastBlockAppHash)
+
+	for h := height + 1; h <= lastHeight; h++ {
+		require.Equal(t, appHashes[h], execTestBlock(t, app, h, val, txs[h]), "height", h)
+	}
+}
+
+func TestRollbackState_BaseFee(t *testing.T) {
+	app := newTestRigoApp(t, "rollback-base-fee", nil)
+	config := app.rootConfig
+
+	govParams := ctrlertypes.Test8GovParams_BaseFee()
+	val := newTestValidator(t, 1_000_000)
+	w0, w1 := web3.NewWallet(nil), web3.NewWallet(nil)
+	initTestChainWith(t, app, val, govParams, w0)
+
+	// every block uses twice the target gas, so the base fee goes up every block.
+	gas := govParams.MinTrxGas()
+	gasPrice := new(uint256.Int).Mul(govParams.GasPrice(), uint256.NewInt(2))
+	newTransfer := func(nonce uint64) []byte {
+		return signTestTrx(t, w0, web3.NewTrxTransfer(w0.Address(), w1.Address(), nonce, gas, gasPrice, uint256.NewInt(1000)))
+	}
+
+	lastHeight := int64(5)
+	appHashes := make(map[int64][]byte)
+	baseFees := make(map[int64]*uint256.Int)
+	txs := make(map[int64][][]byte)
+	for h := int64(1); h <= lastHeight; h++ {
+		nonce := uint64(2 * (h - 1))
+		txs[h] = [][]byte{newTransfer(nonce), newTransfer(nonce + 1)}
+		appHashes[h] = execTestBlock(t, app, h, val, txs[h]...)
+		baseFees[h] = app.lastBlockCtx.BaseFee()
+	}
+	require.NoError(t, app.Stop())
+
+	saveTestTmChain(t, config.DBDir(), appHashes, lastHeight)
+
+	height, _, err := RollbackState(config, 2, log.NewNopLogger())
+	require.NoError(t, err)
+	require.True(t, baseFees[height].Gt(govParams.GasPrice()))
+
+	app = restartTestRigoApp(t, config)
+	defer func() { _ = app.Stop() }()
+
+	info := app.Info(abcitypes.RequestInfo{})
+	require.Equal(t, height, info.LastBlockHeight)
+	require.Equal(t, baseFees[height], app.lastBlockCtx.BaseFee())
+	require.Equal(t, 2*gas, app.lastBlockCtx.GasUsed())
+
+	// the base fees of the re-executed blocks are the same as before.
+	for h := height + 1; h <= lastHeight; h++ {
+		require.Equal(t, appHashes[h], execTestBlock(t, app, h, val, txs[h]...), "height", h)
+		require.Equal(t, baseFees[h], app.lastBlockCtx.BaseFee(), "height", h)
+	}
+}
+
+func TestRollbackState_WrongAppHash(t *testing.T) {
+	app := newTestRigoApp(t, "rollback-wrong-app-hash", nil)
+	config := app.rootConfig
+
+	val := newTestValidator(t, 1_000_000)
+	w0, w1 := web3.NewWallet(nil), web3.NewWallet(nil)
+	initTestChain(t, app, val, w0)
+
+	lastHeight := int64(5)
+	appHashes := make(map[int64][]byte)
+	for h := int64(1); h <= lastHeight; h++ {
+		appHashes[h] = execTestBlock(t, app, h, val, newTestTransfer(t, w0, w1, uint64(h-1)))
+	}
+	require.NoError(t, app.Stop())
+
+	// the app state does not match the tendermint state of the rollback target.
+	tmAppHashes := make(map[int64][]byte)
+	for h, hash := range appHashes {
+		tmAppHashes[h] = hash
+	}
+	tmAppHashes[3] = appHashes[2]
+	saveTestTmChain(t, config.DBDir(), tmAppHashes, lastHeight)
+
+	_, _, err := RollbackState(config, 2, log.NewNopLogger())
+	require.Error(t, err)
+
+	// neither the tendermint state nor the app state is changed.
+	blockStoreDB, stateStore, err := openTmStores(config)
+	require.NoError(t, err)
+	state, err := stateStore.Load()
+	require.NoError(t, err)
+	require.Equal(t, lastHeight, state.LastBlockHeight)
+	require.Equal(t, lastHeight, store.NewBlockStore(blockStoreDB).Height())
+	require.NoError(t, blockStoreDB.Close())
+	require.NoError(t, stateStore.Close())
+
+	app = restartTestRigoApp(t, config)
+	defer func() { _ = app.Stop() }()
+
+	info := app.Info(abcitypes.RequestInfo{})
+	require.Equal(t, lastHeight, info.LastBlockHeight)
+	require.EqualValues(t, appHashes[lastHeight], info.LastBlockAppHash)
+}
+
+// saveTestTmChain saves the tendermint state and the blocks of the heights [1, `lastHeight`] in `dbDir`,
+// as if the blocks had been executed by the app producing `appHashes`.
+func saveTestTmChain(t *testing.T, dbDir string, appHashes map[int64][]byte, lastHeight int64) {
+	blockStoreDB, err := dbm.NewDB("blockstore", dbm.GoLevelDBBackend, dbDir)
+	require.NoError(t, err)
+	defer blockStoreDB.Close()
+	stateDB, err := dbm.NewDB("state", dbm.GoLevelDBBackend, dbDir)
+	require.NoError(t, err)
+	stateStore := tmstate.NewStore(stateDB, tmstate.StoreOptions{})
+	defer stateStore.Close()
+
+	pubKey := ed25519.GenPrivKey().PubKey()
+	state, err := tmstate.MakeGenesisState(&tmtypes.GenesisDoc{
+		ChainID:     testChainID,
+		GenesisTime: time.Unix(1_700_000_000, 0),
+		Validators:  []tmtypes.GenesisValidator{{Address: pubKey.Address(), PubKey: pubKey, Power: 10}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, stateStore.Save(state))
+
+	blockStore := store.NewBlockStore(blockStoreDB)
+	lastCommit := &tmtypes.Commit{}
+	for h := int64(1); h <= lastHeight; h++ {
+		block := tmtypes.MakeBlock(h, nil, lastCommit, nil)
+		block.Header.Populate(
+			state.Version.Consensus, state.ChainID,
+			time.Unix(1_700_000_000+h, 0), state.LastBlockID,
+			state.Validators.Hash(), state.NextValidators.Hash(),
+			tmtypes.HashConsensusParams(state.ConsensusParams), state.AppHash, state.LastResultsHash,
+			pubKey.Address(),
+		)
+		parts := block.MakePartSet(tmtypes.BlockPartSizeBytes)
+		blockID := tmtypes.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+		lastCommit = &tmtypes.Commit{Height: h, BlockID: blockID, Signatures: []tmtypes.CommitSig{tmtypes.NewCommitSigAbsent()}}
+		blockStore.SaveBlock(block, parts, lastCommit)
+
+		state.LastBlockHeight = h
+		state.LastBlockID = blockID
+		state.LastBlockTime = block.Time
+		state.LastValidators = state.Validators.Copy()
+		state.Validators = state.NextValidators.Copy()
+		state.NextValidators = state.NextValidators.CopyIncrementProposerPriority(1)
+		state.AppHash = appHashes[h]
+		require.NoError(t, stateStore.Save(state))
+	}
+}
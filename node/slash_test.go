@@ -0,0 +1,147 @@
+package node
+
+import (
+	"github.com/rigochain/rigo-go/ctrlers/stake"
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/libs/web3"
+	"github.com/rigochain/rigo-go/types"
+	"github.com/rigochain/rigo-go/types/bytes"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmtypes "github.com/tendermint/tendermint/types"
+	"testing"
+)
+
+func TestSlashHistory(t *testing.T) {
+	app := newTestRigoApp(t, "slash_history", nil)
+	defer func() { _ = app.Stop() }()
+
+	val := newTestValidator(t, 1_000_000)
+	w0 := web3.NewWallet(nil)
+	initTestChain(t, app, val, w0)
+
+	govParams := ctrlertypes.DefaultGovParams()
+	delegated := int64(1000)
+	txStaking := signTestTrx(t, w0, web3.NewTrxStaking(w0.Address(), val.addr, 0, govParams.MinTrxGas(), govParams.GasPrice(), ctrlertypes.PowerToAmount(delegated)))
+	execTestBlock(t, app, 1, val, txStaking)
+	execTestBlock(t, app, 2, val)
+
+	evi := abcitypes.Evidence{
+		Type:      abcitypes.EvidenceType_DUPLICATE_VOTE,
+		Validator: abcitypes.Validator{Address: val.addr, Power: val.power},
+		Height:    1,
+	}
+	punish := func(height int64) abcitypes.ResponseBeginBlock {
+		req := testBeginBlockReq(height, val)
+		req.ByzantineValidators = []abcitypes.Evidence{evi}
+		resp := app.BeginBlock(req)
+		app.EndBlock(abcitypes.RequestEndBlock{Height: height})
+		app.Commit()
+		return resp
+	}
+
+	resp := punish(3)
+	require.NotNil(t, findTestEvent(resp.Events, "punishment.stake"))
+	require.NotNil(t, findTestEvent(resp.Events, "punishment.gov"))
+
+	slashedSelf := val.power * govParams.SlashRatio() / 100
+	slashed := delegated * govParams.SlashRatio() / 100
+	require.EqualValues(t, val.power+delegated-slashedSelf-slashed, app.stakeCtrler.Delegatee(val.addr).GetTotalPower())
+
+	// the same misbehavior is not punished again.
+	resp = punish(4)
+	require.Nil(t, findTestEvent(resp.Events, "punishment.stake"))
+	require.Nil(t, findTestEvent(resp.Events, "punishment.gov"))
+	require.EqualValues(t, val.power+delegated-slashedSelf-slashed, app.stakeCtrler.Delegatee(val.addr).GetTotalPower())
+
+	qresp := app.Query(abcitypes.RequestQuery{Path: "slashes", Data: val.addr, Height: 4})
+	require.Equal(t, abcitypes.CodeTypeOK, qresp.Code, qresp.Log)
+	history := &stake.SlashHistory{}
+	require.NoError(t, tmjson.Unmarshal(qresp.Value, history))
+	require.Equal(t, types.Address(val.addr), history.Addr)
+	require.Len(t, history.Slashings, 1)
+	slashing := history.Slashings[0]
+	require.Equal(t, "DUPLICATE_VOTE", slashing.Type)
+	require.EqualValues(t, 1, slashing.EvidenceHeight)
+	require.EqualValues(t, 3, slashing.Height)
+	require.Equal(t, govParams.SlashRatio(), slashing.Ratio)
+	require.Len(t, slashing.Stakes, 2)
+	for _, s0 := range slashing.Stakes {
+		require.Equal(t, types.Address(val.addr), s0.To)
+		if s0.Owner.Compare(w0.Address()) == 0 {
+			require.Equal(t, bytes.HexBytes(tmtypes.Tx(txStaking).Hash()), s0.TxHash)
+			require.Equal(t, slashed, s0.Power)
+		} else {
+			require.Equal(t, types.Address(val.addr), s0.Owner)
+			require.Equal(t, slashedSelf, s0.Power)
+		}
+	}
+
+	// the history of the delegator has only its own stakes.
+	qresp = app.Query(abcitypes.RequestQuery{Path: "slashes/delegator", Data: w0.Address(), Height: 4})
+	require.Equal(t, abcitypes.CodeTypeOK, qresp.Code, qresp.Log)
+	var histories []*stake.SlashHistory
+	require.NoError(t, tmjson.Unmarshal(qresp.Value, &histories))
+	require.Len(t, histories, 1)
+	require.Equal(t, types.Address(val.addr), histories[0].Addr)
+	require.Len(t, histories[0].Slashings, 1)
+	require.Len(t, histories[0].Slashings[0].Stakes, 1)
+	require.Equal(t, slashed, histories[0].Slashings[0].Stakes[0].Power)
+
+	// no slashing before the evidence is handled.
+	qresp = app.Query(abcitypes.RequestQuery{Path: "slashes", Data: val.addr, Height: 2})
+	require.NotEqual(t, abcitypes.CodeTypeOK, qresp.Code)
+}
+
+func TestSlashDuplicateEvidence(t *testing.T) {
+	app := newTestRigoApp(t, "slash_duplicate", nil)
+	defer func() { _ = app.Stop() }()
+
+	val := newTestValidator(t, 1_000_000)
+	w0 := web3.NewWallet(nil)
+	initTestChain(t, app, val, w0)
+
+	govParams := ctrlertypes.DefaultGovParams()
+	execTestBlock(t, app, 1, val)
+	execTestBlock(t, app, 2, val)
+
+	evi := abcitypes.Evidence{
+		Type:      abcitypes.EvidenceType_DUPLICATE_VOTE,
+		Validator: abcitypes.Validator{Address: val.addr, Power: val.power},
+		Height:    1,
+	}
+	evi1 := evi
+	evi1.Height = 2
+
+	// the same evidence is included twice in a block, with another evidence of the validator.
+	req := testBeginBlockReq(3, val)
+	req.ByzantineValidators = []abcitypes.Evidence{evi, evi1, evi}
+	resp := app.BeginBlock(req)
+	app.EndBlock(abcitypes.RequestEndBlock{Height: 3})
+	app.Commit()
+
+	countEvents := func(typ string) int {
+		cnt := 0
+		for _, evt := range resp.Events {
+			if evt.Type == typ {
+				cnt++
+			}
+		}
+		return cnt
+	}
+	require.Equal(t, 2, countEvents("punishment.stake"))
+	require.Equal(t, 2, countEvents("punishment.gov"))
+
+	slashed := val.power * govParams.SlashRatio() / 100
+	slashed += (val.power - slashed) * govParams.SlashRatio() / 100
+	require.EqualValues(t, val.power-slashed, app.stakeCtrler.Delegatee(val.addr).GetTotalPower())
+
+	qresp := app.Query(abcitypes.RequestQuery{Path: "slashes", Data: val.addr, Height: 3})
+	require.Equal(t, abcitypes.CodeTypeOK, qresp.Code, qresp.Log)
+	history := &stake.SlashHistory{}
+	require.NoError(t, tmjson.Unmarshal(qresp.Value, history))
+	require.Len(t, history.Slashings, 2)
+	require.EqualValues(t, 1, history.Slashings[0].EvidenceHeight)
+	require.EqualValues(t, 2, history.Slashings[1].EvidenceHeight)
+}
@@ -0,0 +1,439 @@
+package node
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	rctypes "github.com/rigochain/rigo-go/ctrlers/types"
+	abytes "github.com/rigochain/rigo-go/types/bytes"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"google.golang.org/protobuf/proto"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	snapshotStoreApp    = "rigo_app"
+	snapshotStoreSupply = "supply"
+
+	// maxSnapshotChunkSize is smaller than the max size of chunk message of tendermint (16MB).
+	maxSnapshotChunkSize = 8 * 1024 * 1024
+	snapshotFileName     = "snapshot"
+)
+
+// snapshotStore manages the snapshots saved in `dir`.
+// Each snapshot is saved in the directory `dir/<height>`,
+// which has a `snapshot` file containing abcitypes.Snapshot and chunk files named by their indices.
+// The metadata of a snapshot is the concatenation of the sha256 hashes of all chunks,
+// and the hash of a snapshot is the sha256 hash of the metadata.
+type snapshotStore struct {
+	dir string
+	mtx sync.RWMutex
+}
+
+func newSnapshotStore(dir string) *snapshotStore {
+	return &snapshotStore{dir: dir}
+}
+
+func (store *snapshotStore) heightDir(height int64) string {
+	return filepath.Join(store.dir, strconv.FormatInt(height, 10))
+}
+
+func (store *snapshotStore) save(height int64, exporters []rctypes.SnapshotExporter) (*abcitypes.Snapshot, error) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+
+	tmpDir := store.heightDir(height) + ".tmp"
+	if err := os.RemoveAll(tmpDir); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(tmpDir, 0o700); err != nil {
+		return nil, err
+	}
+
+	var metadata []byte
+	chunk := &rctypes.SnapshotChunkProto{}
+	chunkSize := 0
+	flush := func() error {
+		bz, err := proto.Marshal(chunk)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(tmpDir, strconv.Itoa(len(metadata)/sha256.Size)), bz, 0o600); err != nil {
+			return err
+		}
+		h := sha256.Sum256(bz)
+		metadata = append(metadata, h[:]...)
+
+		chunk = &rctypes.SnapshotChunkProto{}
+		chunkSize = 0
+		return nil
+	}
+
+	for _, exporter := range exporters {
+		if xerr := exporter(func(item *rctypes.SnapshotItemProto) xerrors.XError {
+			chunk.Items = append(chunk.Items, item)
+			chunkSize += proto.Size(item)
+			if chunkSize >= maxSnapshotChunkSize {
+				if err := flush(); err != nil {
+					return xerrors.From(err)
+				}
+			}
+			return nil
+		}); xerr != nil {
+			_ = os.RemoveAll(tmpDir)
+			return nil, xerr
+		}
+	}
+	if len(chunk.Items) > 0 || len(metadata) == 0 {
+		if err := flush(); err != nil {
+			_ = os.RemoveAll(tmpDir)
+			return nil, err
+		}
+	}
+
+	hash := sha256.Sum256(metadata)
+	snapshot := &abcitypes.Snapshot{
+		Height:   uint64(height),
+		Format:   rctypes.SNAPSHOT_FORMAT,
+		Chunks:   uint32(len(metadata) / sha256.Size),
+		Hash:     hash[:],
+		Metadata: metadata,
+	}
+	bz, err := snapshot.Marshal()
+	if err != nil {
+		_ = os.RemoveAll(tmpDir)
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, snapshotFileName), bz, 0o600); err != nil {
+		_ = os.RemoveAll(tmpDir)
+		return nil, err
+	}
+	if err := os.RemoveAll(store.heightDir(height)); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmpDir, store.heightDir(height)); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// list returns all snapshots ordered by height descending.
+func (store *snapshotStore) list() ([]*abcitypes.Snapshot, error) {
+	store.mtx.RLock()
+	defer store.mtx.RUnlock()
+
+	entries, err := os.ReadDir(store.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var snapshots []*abcitypes.Snapshot
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := strconv.ParseInt(entry.Name(), 10, 64); err != nil {
+			// e.g. the temporary directory of a snapshot being saved
+			continue
+		}
+
+		bz, err := os.ReadFile(filepath.Join(store.dir, entry.Name(), snapshotFileName))
+		if err != nil {
+			return nil, err
+		}
+		snapshot := &abcitypes.Snapshot{}
+		if err := snapshot.Unmarshal(bz); err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Height > snapshots[j].Height
+	})
+	return snapshots, nil
+}
+
+func (store *snapshotStore) loadChunk(height uint64, format, chunk uint32) ([]byte, error) {
+	store.mtx.RLock()
+	defer store.mtx.RUnlock()
+
+	if format != rctypes.SNAPSHOT_FORMAT {
+		return nil, fmt.Errorf("unknown snapshot format: %v", format)
+	}
+	return os.ReadFile(filepath.Join(store.heightDir(int64(height)), strconv.FormatUint(uint64(chunk), 10)))
+}
+
+// prune removes the old snapshots except the `keepRecent` recent snapshots.
+func (store *snapshotStore) prune(keepRecent int) error {
+	if keepRecent <= 0 {
+		return nil
+	}
+
+	snapshots, err := store.list()
+	if err != nil {
+		return err
+	}
+
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+
+	for i := keepRecent; i < len(snapshots); i++ {
+		if err := os.RemoveAll(store.heightDir(int64(snapshots[i].Height))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeAbove removes the snapshots of the heights higher than `height`.
+func (store *snapshotStore) removeAbove(height int64) error {
+	snapshots, err := store.list()
+	if err != nil {
+		return err
+	}
+
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+
+	for _, snapshot := range snapshots {
+		if int64(snapshot.Height) <= height {
+			break
+		}
+		if err := os.RemoveAll(store.heightDir(int64(snapshot.Height))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// snapshotRestorer holds the state of the snapshot being restored.
+type snapshotRestorer struct {
+	snapshot *abcitypes.Snapshot
+	appHash  []byte
+	items    rctypes.SnapshotItems
+}
+
+func (restorer *snapshotRestorer) chunkHash(idx uint32) []byte {
+	return restorer.snapshot.Metadata[idx*sha256.Size : (idx+1)*sha256.Size]
+}
+
+// takeSnapshot is called in Commit and captures the state of `height`.
+// The captured state is saved to the snapshot store in another goroutine.
+func (ctrler *RigoApp) takeSnapshot(height int64) {
+	if !atomic.CompareAndSwapInt32(&ctrler.snapshotting, 0, 1) {
+		ctrler.logger.Info("skip the snapshot because the previous snapshot is still being taken", "height", height)
+		return
+	}
+
+	exporters, xerr := ctrler.snapshotExporters(height)
+	if xerr != nil {
+		atomic.StoreInt32(&ctrler.snapshotting, 0)
+		ctrler.logger.Error("fail to take a snapshot", "height", height, "error", xerr)
+		return
+	}
+
+	go func() {
+		defer atomic.StoreInt32(&ctrler.snapshotting, 0)
+
+		snapshot, err := ctrler.snapshotStore.save(height, exporters)
+		if err != nil {
+			ctrler.logger.Error("fail to save a snapshot", "height", height, "error", err)
+			return
+		}
+		ctrler.logger.Info("snapshot is saved", "height", height, "chunks", snapshot.Chunks, "hash", abytes.HexBytes(snapshot.Hash))
+
+		if err := ctrler.snapshotStore.prune(ctrler.rootConfig.App.SnapshotKeepRecent); err != nil {
+			ctrler.logger.Error("fail to prune snapshots", "error", err)
+		}
+	}()
+}
+
+func (ctrler *RigoApp) snapshotExporters(height int64) ([]rctypes.SnapshotExporter, xerrors.XError) {
+	var exporters []rctypes.SnapshotExporter
+	for _, h := range []rctypes.ISnapshotHandler{ctrler.govCtrler, ctrler.acctCtrler, ctrler.stakeCtrler, ctrler.vmCtrler} {
+		exporter, xerr := h.Snapshot(height)
+		if xerr != nil {
+			return nil, xerr
+		}
+		exporters = append(exporters, exporter)
+	}
+
+	appItems, err := ctrler.metaDB.Items(snapshotStoreApp)
+	if err != nil {
+		return nil, xerrors.ErrSnapshot.Wrap(err)
+	}
+	// the supply of `height` is recorded in Commit before the snapshot is taken.
+	supplyItem, xerr := ctrler.supplyLedger.snapshotItem(height)
+	if xerr != nil {
+		return nil, xerrors.ErrSnapshot.Wrap(xerr)
+	}
+	exporters = append(exporters, func(cb func(*rctypes.SnapshotItemProto) xerrors.XError) xerrors.XError {
+		for _, item := range appItems {
+			if xerr := cb(item); xerr != nil {
+				return xerr
+			}
+		}
+		if supplyItem != nil {
+			return cb(supplyItem)
+		}
+		return nil
+	})
+	return exporters, nil
+}
+
+// restoreSnapshot imports all items of the snapshot and
+// returns the app hash which is computed in the same way as Commit.
+func (ctrler *RigoApp) restoreSnapshot(height int64, items rctypes.SnapshotItems) ([]byte, xerrors.XError) {
+	appHash0, xerr := ctrler.govCtrler.RestoreSnapshot(height, items)
+	if xerr != nil {
+		return nil, xerr
+	}
+	appHash1, xerr := ctrler.acctCtrler.RestoreSnapshot(height, items)
+	if xerr != nil {
+		return nil, xerr
+	}
+	appHash2, xerr := ctrler.stakeCtrler.RestoreSnapshot(height, items)
+	if xerr != nil {
+		return nil, xerr
+	}
+	appHash3, xerr := ctrler.vmCtrler.RestoreSnapshot(height, items)
+	if xerr != nil {
+		return nil, xerr
+	}
+	return appRootHashes(appHash0, appHash1, appHash2, appHash3).Hash(), nil
+}
+
+func (ctrler *RigoApp) ListSnapshots(req abcitypes.RequestListSnapshots) abcitypes.ResponseListSnapshots {
+	snapshots, err := ctrler.snapshotStore.list()
+	if err != nil {
+		ctrler.logger.Error("ListSnapshots", "error", err)
+		return abcitypes.ResponseListSnapshots{}
+	}
+	return abcitypes.ResponseListSnapshots{Snapshots: snapshots}
+}
+
+func (ctrler *RigoApp) LoadSnapshotChunk(req abcitypes.RequestLoadSnapshotChunk) abcitypes.ResponseLoadSnapshotChunk {
+	chunk, err := ctrler.snapshotStore.loadChunk(req.Height, req.Format, req.Chunk)
+	if err != nil {
+		ctrler.logger.Error("LoadSnapshotChunk", "height", req.Height, "chunk", req.Chunk, "error", err)
+		return abcitypes.ResponseLoadSnapshotChunk{}
+	}
+	return abcitypes.ResponseLoadSnapshotChunk{Chunk: chunk}
+}
+
+func (ctrler *RigoApp) OfferSnapshot(req abcitypes.RequestOfferSnapshot) abcitypes.ResponseOfferSnapshot {
+	ctrler.mtx.Lock()
+	defer ctrler.mtx.Unlock()
+
+	snapshot := req.Snapshot
+	if snapshot == nil {
+		return abcitypes.ResponseOfferSnapshot{Result: abcitypes.ResponseOfferSnapshot_REJECT}
+	}
+	if snapshot.Format != rctypes.SNAPSHOT_FORMAT {
+		return abcitypes.ResponseOfferSnapshot{Result: abcitypes.ResponseOfferSnapshot_REJECT_FORMAT}
+	}
+	if hash := sha256.Sum256(snapshot.Metadata); snapshot.Chunks == 0 ||
+		len(snapshot.Metadata) != int(snapshot.Chunks)*sha256.Size ||
+		!bytes.Equal(hash[:], snapshot.Hash) {
+		ctrler.logger.Error("OfferSnapshot", "error", "wrong snapshot metadata", "height", snapshot.Height)
+		return abcitypes.ResponseOfferSnapshot{Result: abcitypes.ResponseOfferSnapshot_REJECT}
+	}
+	if lastHeight := ctrler.metaDB.LastBlockHeight(); lastHeight > 0 {
+		// the ledgers can be restored only when they are empty.
+		ctrler.logger.Error("OfferSnapshot", "error", "the state is not empty", "last height", lastHeight)
+		return abcitypes.ResponseOfferSnapshot{Result: abcitypes.ResponseOfferSnapshot_ABORT}
+	}
+
+	ctrler.restorer = &snapshotRestorer{
+		snapshot: snapshot,
+		appHash:  req.AppHash,
+		items:    make(rctypes.SnapshotItems),
+	}
+	ctrler.logger.Info("OfferSnapshot", "height", snapshot.Height, "chunks", snapshot.Chunks, "app hash", abytes.HexBytes(req.AppHash))
+	return abcitypes.ResponseOfferSnapshot{Result: abcitypes.ResponseOfferSnapshot_ACCEPT}
+}
+
+func (ctrler *RigoApp) ApplySnapshotChunk(req abcitypes.RequestApplySnapshotChunk) abcitypes.ResponseApplySnapshotChunk {
+	ctrler.mtx.Lock()
+	defer ctrler.mtx.Unlock()
+
+	restorer := ctrler.restorer
+	if restorer == nil {
+		ctrler.logger.Error("ApplySnapshotChunk", "error", "no snapshot is offered")
+		return abcitypes.ResponseApplySnapshotChunk{Result: abcitypes.ResponseApplySnapshotChunk_ABORT}
+	}
+	if req.Index >= restorer.snapshot.Chunks {
+		return abcitypes.ResponseApplySnapshotChunk{Result: abcitypes.ResponseApplySnapshotChunk_REJECT_SNAPSHOT}
+	}
+
+	if hash := sha256.Sum256(req.Chunk); !bytes.Equal(hash[:], restorer.chunkHash(req.Index)) {
+		ctrler.logger.Error("ApplySnapshotChunk", "error", "wrong chunk hash", "index", req.Index, "sender", req.Sender)
+		return abcitypes.ResponseApplySnapshotChunk{
+			Result:        abcitypes.ResponseApplySnapshotChunk_RETRY,
+			RefetchChunks: []uint32{req.Index},
+			RejectSenders: []string{req.Sender},
+		}
+	}
+
+	chunk := &rctypes.SnapshotChunkProto{}
+	if err := proto.Unmarshal(req.Chunk, chunk); err != nil {
+		ctrler.logger.Error("ApplySnapshotChunk", "error", err, "index", req.Index)
+		return abcitypes.ResponseApplySnapshotChunk{Result: abcitypes.ResponseApplySnapshotChunk_REJECT_SNAPSHOT}
+	}
+	for _, item := range chunk.Items {
+		restorer.items.Add(item)
+	}
+
+	if req.Index+1 < restorer.snapshot.Chunks {
+		return abcitypes.ResponseApplySnapshotChunk{Result: abcitypes.ResponseApplySnapshotChunk_ACCEPT}
+	}
+
+	// all chunks are received.
+	ctrler.restorer = nil
+
+	height := int64(restorer.snapshot.Height)
+	appHash, xerr := ctrler.restoreSnapshot(height, restorer.items)
+	if xerr != nil {
+		ctrler.logger.Error("ApplySnapshotChunk", "error", xerr, "height", height)
+		ctrler.resetRestored()
+		return abcitypes.ResponseApplySnapshotChunk{Result: abcitypes.ResponseApplySnapshotChunk_ABORT}
+	}
+	if !bytes.Equal(appHash, restorer.appHash) {
+		ctrler.logger.Error("ApplySnapshotChunk", "error", "app hash mismatch", "height", height,
+			"expected", abytes.HexBytes(restorer.appHash), "actual", abytes.HexBytes(appHash))
+		ctrler.resetRestored()
+		return abcitypes.ResponseApplySnapshotChunk{Result: abcitypes.ResponseApplySnapshotChunk_ABORT}
+	}
+
+	if xerr := ctrler.supplyLedger.restore(restorer.items[snapshotStoreSupply]); xerr != nil {
+		ctrler.logger.Error("ApplySnapshotChunk", "error", xerr, "height", height)
+		ctrler.resetRestored()
+		return abcitypes.ResponseApplySnapshotChunk{Result: abcitypes.ResponseApplySnapshotChunk_ABORT}
+	}
+	// the app's meta data is written last, so that nothing else should be undone if it fails.
+	if err := ctrler.metaDB.PutItems(restorer.items[snapshotStoreApp]); err != nil {
+		ctrler.logger.Error("ApplySnapshotChunk", "error", err, "height", height)
+		ctrler.resetRestored()
+		return abcitypes.ResponseApplySnapshotChunk{Result: abcitypes.ResponseApplySnapshotChunk_ABORT}
+	}
+
+	ctrler.logger.Info("snapshot is restored", "height", height, "app hash", abytes.HexBytes(appHash))
+	return abcitypes.ResponseApplySnapshotChunk{Result: abcitypes.ResponseApplySnapshotChunk_ACCEPT}
+}
+
+// resetRestored discards the ledgers restored partially from a snapshot,
+// so that the node can be restored from another snapshot or synced from the genesis.
+func (ctrler *RigoApp) resetRestored() {
+	if xerr := ctrler.rollbackCtrlers(0); xerr != nil {
+		ctrler.logger.Error("fail to reset the restored ledgers", "error", xerr)
+	}
+}
@@ -0,0 +1,120 @@
+package node
+
+import (
+	cfg "github.com/rigochain/rigo-go/cmd/config"
+	"github.com/rigochain/rigo-go/libs/web3"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSnapshot_Restore(t *testing.T) {
+	src := newTestRigoApp(t, "snapshot-src", func(config *cfg.Config) {
+		config.App.SnapshotInterval = 10
+		config.App.SnapshotKeepRecent = 2
+	})
+	defer func() { _ = src.Stop() }()
+
+	val := newTestValidator(t, 1_000_000)
+	w0, w1 := web3.NewWallet(nil), web3.NewWallet(nil)
+	initTestChain(t, src, val, w0)
+
+	lastHeight := int64(32)
+	appHashes := make(map[int64][]byte)
+	txs := make(map[int64][]byte)
+	for h := int64(1); h <= lastHeight; h++ {
+		txs[h] = newTestTransfer(t, w0, w1, uint64(h-1))
+		appHashes[h] = execTestBlock(t, src, h, val, txs[h])
+		require.Eventually(t, func() bool {
+			return atomic.LoadInt32(&src.snapshotting) == 0
+		}, 10*time.Second, 10*time.Millisecond)
+	}
+
+	// the snapshot of 10 is pruned.
+	snapshots := src.ListSnapshots(abcitypes.RequestListSnapshots{}).Snapshots
+	require.Len(t, snapshots, 2)
+	require.EqualValues(t, 30, snapshots[0].Height)
+	require.EqualValues(t, 20, snapshots[1].Height)
+
+	snapshot := snapshots[1]
+	height := int64(snapshot.Height)
+
+	dst := newTestRigoApp(t, "snapshot-dst", nil)
+	defer func() { _ = dst.Stop() }()
+	require.EqualValues(t, 0, dst.Info(abcitypes.RequestInfo{}).LastBlockHeight)
+
+	// no snapshot is offered yet.
+	require.Equal(t, abcitypes.ResponseApplySnapshotChunk_ABORT,
+		dst.ApplySnapshotChunk(abcitypes.RequestApplySnapshotChunk{}).Result)
+
+	require.Equal(t, abcitypes.ResponseOfferSnapshot_REJECT_FORMAT,
+		dst.OfferSnapshot(abcitypes.RequestOfferSnapshot{
+			Snapshot: &abcitypes.Snapshot{Height: snapshot.Height, Format: snapshot.Format + 1},
+			AppHash:  appHashes[height],
+		}).Result)
+	// the ledgers restored with a wrong app hash should be reset.
+	require.Equal(t, abcitypes.ResponseOfferSnapshot_ACCEPT,
+		dst.OfferSnapshot(abcitypes.RequestOfferSnapshot{
+			Snapshot: snapshot,
+			AppHash:  appHashes[height-1],
+		}).Result)
+	for i := uint32(0); i < snapshot.Chunks; i++ {
+		chunk := src.LoadSnapshotChunk(abcitypes.RequestLoadSnapshotChunk{
+			Height: snapshot.Height,
+			Format: snapshot.Format,
+			Chunk:  i,
+		}).Chunk
+		expected := abcitypes.ResponseApplySnapshotChunk_ACCEPT
+		if i+1 == snapshot.Chunks {
+			expected = abcitypes.ResponseApplySnapshotChunk_ABORT
+		}
+		require.Equal(t, expected,
+			dst.ApplySnapshotChunk(abcitypes.RequestApplySnapshotChunk{Index: i, Chunk: chunk, Sender: "good"}).Result)
+	}
+	require.EqualValues(t, 0, dst.Info(abcitypes.RequestInfo{}).LastBlockHeight)
+	require.Nil(t, dst.stakeCtrler.Delegatee(val.addr))
+
+	require.Equal(t, abcitypes.ResponseOfferSnapshot_ACCEPT,
+		dst.OfferSnapshot(abcitypes.RequestOfferSnapshot{
+			Snapshot: snapshot,
+			AppHash:  appHashes[height],
+		}).Result)
+
+	for i := uint32(0); i < snapshot.Chunks; i++ {
+		chunk := src.LoadSnapshotChunk(abcitypes.RequestLoadSnapshotChunk{
+			Height: snapshot.Height,
+			Format: snapshot.Format,
+			Chunk:  i,
+		}).Chunk
+		require.NotEmpty(t, chunk)
+
+		// a tampered chunk should be fetched again.
+		tampered := append([]byte{}, chunk...)
+		tampered[len(tampered)-1] ^= 0xFF
+		resp := dst.ApplySnapshotChunk(abcitypes.RequestApplySnapshotChunk{Index: i, Chunk: tampered, Sender: "bad"})
+		require.Equal(t, abcitypes.ResponseApplySnapshotChunk_RETRY, resp.Result)
+		require.Equal(t, []uint32{i}, resp.RefetchChunks)
+		require.Equal(t, []string{"bad"}, resp.RejectSenders)
+
+		require.Equal(t, abcitypes.ResponseApplySnapshotChunk_ACCEPT,
+			dst.ApplySnapshotChunk(abcitypes.RequestApplySnapshotChunk{Index: i, Chunk: chunk, Sender: "good"}).Result)
+	}
+
+	info := dst.Info(abcitypes.RequestInfo{})
+	require.Equal(t, height, info.LastBlockHeight)
+	require.EqualValues(t, appHashes[height], info.LastBlockAppHash)
+
+	// the restored app can not accept any snapshot.
+	require.Equal(t, abcitypes.ResponseOfferSnapshot_ABORT,
+		dst.OfferSnapshot(abcitypes.RequestOfferSnapshot{
+			Snapshot: snapshot,
+			AppHash:  appHashes[height],
+		}).Result)
+
+	// the restored app should produce the same app hashes as the source app.
+	for h := height + 1; h <= lastHeight; h++ {
+		require.Equal(t, appHashes[h], execTestBlock(t, dst, h, val, txs[h]), "height", h)
+	}
+}
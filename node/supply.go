@@ -0,0 +1,272 @@
+package node
+
+import (
+	"encoding/binary"
+	"github.com/holiman/uint256"
+	"github.com/rigochain/rigo-go/ctrlers/stake"
+	rctypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/genesis"
+	"github.com/rigochain/rigo-go/ledger"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmdb "github.com/tendermint/tm-db"
+)
+
+// supplyLedger records the supply of every height.
+// The records are not a part of the app hash, but the latest record is included in the snapshots
+// so that a node restored from a snapshot can go on accumulating the issued and burned amount.
+// The old records are pruned by the same pruning option as the ledgers.
+type supplyLedger struct {
+	db      tmdb.DB
+	pruning ledger.PruningOption
+	// the records up to `pruned` have been checked by prune.
+	pruned int64
+}
+
+func newSupplyLedger(dbDir string, pruning ledger.PruningOption) (*supplyLedger, error) {
+	db, err := tmdb.NewDB("supply", "goleveldb", dbDir)
+	if err != nil {
+		return nil, err
+	}
+	return &supplyLedger{db: db, pruning: pruning}, nil
+}
+
+func supplyKey(height int64) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, uint64(height))
+	return k
+}
+
+func (ledger *supplyLedger) put(s *rctypes.Supply) xerrors.XError {
+	bz, err := tmjson.Marshal(s)
+	if err != nil {
+		return xerrors.From(err)
+	}
+	if err := ledger.db.SetSync(supplyKey(s.Height), bz); err != nil {
+		return xerrors.From(err)
+	}
+	return nil
+}
+
+// get returns the supply at `height`. It returns nil, if there is no record of `height`.
+func (ledger *supplyLedger) get(height int64) (*rctypes.Supply, xerrors.XError) {
+	bz, err := ledger.db.Get(supplyKey(height))
+	if err != nil {
+		return nil, xerrors.From(err)
+	} else if bz == nil {
+		return nil, nil
+	}
+
+	s := &rctypes.Supply{}
+	if err := tmjson.Unmarshal(bz, s); err != nil {
+		return nil, xerrors.From(err)
+	}
+	return s, nil
+}
+
+// rollback removes the records of the heights higher than `height`.
+func (ledger *supplyLedger) rollback(height int64) xerrors.XError {
+	itr, err := ledger.db.Iterator(supplyKey(height+1), nil)
+	if err != nil {
+		return xerrors.From(err)
+	}
+
+	var keys [][]byte
+	for ; itr.Valid(); itr.Next() {
+		keys = append(keys, append([]byte(nil), itr.Key()...))
+	}
+	if err := itr.Close(); err != nil {
+		return xerrors.From(err)
+	}
+
+	batch := ledger.db.NewBatch()
+	defer batch.Close()
+	for _, k := range keys {
+		if err := batch.Delete(k); err != nil {
+			return xerrors.From(err)
+		}
+	}
+	if err := batch.WriteSync(); err != nil {
+		return xerrors.From(err)
+	}
+	if ledger.pruned > height {
+		ledger.pruned = height
+	}
+	return nil
+}
+
+// prune removes the records which are pruned by `ledger.pruning` when the latest height is `latest`.
+// The record of the genesis is always kept.
+func (ledger *supplyLedger) prune(latest int64) xerrors.XError {
+	if !ledger.pruning.ShouldPrune(latest) {
+		return nil
+	}
+	to := latest - ledger.pruning.KeepRecent
+	if to <= ledger.pruned {
+		return nil
+	}
+
+	itr, err := ledger.db.Iterator(supplyKey(ledger.pruned+1), supplyKey(to+1))
+	if err != nil {
+		return xerrors.From(err)
+	}
+
+	var keys [][]byte
+	for ; itr.Valid(); itr.Next() {
+		h := int64(binary.BigEndian.Uint64(itr.Key()))
+		if h > 0 && ledger.pruning.IsPruned(h, latest) {
+			keys = append(keys, append([]byte(nil), itr.Key()...))
+		}
+	}
+	if err := itr.Close(); err != nil {
+		return xerrors.From(err)
+	}
+
+	batch := ledger.db.NewBatch()
+	defer batch.Close()
+	for _, k := range keys {
+		if err := batch.Delete(k); err != nil {
+			return xerrors.From(err)
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return xerrors.From(err)
+	}
+	ledger.pruned = to
+	return nil
+}
+
+func (ledger *supplyLedger) close() error {
+	return ledger.db.Close()
+}
+
+// genesisSupply returns the supply of the genesis, which is recorded at the height 0.
+// The initial stakes of the validators are issued at InitChain, so they are included in the supply.
+func genesisSupply(appState *genesis.GenesisAppState, initStakes []*stake.InitStake) *rctypes.Supply {
+	s := rctypes.NewSupply(0)
+	for _, holder := range appState.AssetHolders {
+		_ = s.Total.Add(s.Total, holder.Balance)
+	}
+	for _, acct := range appState.Accounts {
+		_ = s.Total.Add(s.Total, acct.Balance)
+	}
+	for _, d := range appState.Delegatees {
+		_ = s.Staked.Add(s.Staked, rctypes.PowerToAmount(d.TotalPower))
+	}
+	for _, initS0 := range initStakes {
+		for _, s0 := range initS0.Stakes {
+			_ = s.Staked.Add(s.Staked, rctypes.PowerToAmount(s0.Power))
+		}
+	}
+	for _, s0 := range appState.FrozenStakes {
+		_ = s.Frozen.Add(s.Frozen, rctypes.PowerToAmount(s0.Power))
+	}
+	for _, rwd := range appState.Rewards {
+		_ = s.Rewards.Add(s.Rewards, rwd.GetCumulated())
+	}
+
+	_ = s.Total.Add(s.Total, s.Staked)
+	_ = s.Total.Add(s.Total, s.Frozen)
+	_ = s.Total.Add(s.Total, s.Rewards)
+	s.Circulating = circulatingSupply(s)
+	return s
+}
+
+func circulatingSupply(s *rctypes.Supply) *uint256.Int {
+	ret := new(uint256.Int).Set(s.Total)
+	_ = ret.Sub(ret, s.Staked)
+	_ = ret.Sub(ret, s.Frozen)
+	_ = ret.Sub(ret, s.Rewards)
+	return ret
+}
+
+// startingSupply returns the supply at the height of `blockCtx`, which is computed from the committed state.
+// It is used when there is no record of the previous height, e.g. the node has been running before the supply is recorded.
+// The issued and burned amounts are counted from this height.
+func (ctrler *RigoApp) startingSupply(blockCtx *rctypes.BlockContext) *rctypes.Supply {
+	s := rctypes.NewSupply(blockCtx.Height())
+	s.Staked = ctrler.stakeCtrler.ReadTotalAmount()
+	s.Frozen = ctrler.stakeCtrler.TotalFrozenAmount()
+	s.Rewards = ctrler.stakeCtrler.TotalRewardAmount()
+
+	_ = s.Total.Add(ctrler.acctCtrler.ReadTotalBalance(), s.Staked)
+	_ = s.Total.Add(s.Total, s.Frozen)
+	_ = s.Total.Add(s.Total, s.Rewards)
+	s.Circulating = circulatingSupply(s)
+	return s
+}
+
+// commitSupply records the supply at the height of `blockCtx`, which is committed just before.
+// The issued and burned amounts are accumulated on the supply of the previous height.
+func (ctrler *RigoApp) commitSupply(blockCtx *rctypes.BlockContext) xerrors.XError {
+	height := blockCtx.Height()
+	prev, xerr := ctrler.supplyLedger.get(height - 1)
+	if xerr != nil {
+		return xerr
+	} else if prev == nil {
+		ctrler.logger.Info("RigoApp: no supply of the previous height, start recording the supply", "height", height)
+		if xerr := ctrler.supplyLedger.put(ctrler.startingSupply(blockCtx)); xerr != nil {
+			return xerr
+		}
+		return ctrler.supplyLedger.prune(height)
+	}
+
+	issued, burned := blockCtx.Issued(), blockCtx.Burned()
+
+	s := rctypes.NewSupply(height)
+	_ = s.Issued.Add(prev.Issued, issued)
+	_ = s.Burned.Add(prev.Burned, burned)
+	_ = s.Total.Add(prev.Total, issued)
+	_ = s.Total.Sub(s.Total, burned)
+	s.Staked = ctrler.stakeCtrler.ReadTotalAmount()
+	s.Frozen = ctrler.stakeCtrler.TotalFrozenAmount()
+	s.Rewards = ctrler.stakeCtrler.TotalRewardAmount()
+	s.Circulating = circulatingSupply(s)
+
+	if xerr := ctrler.supplyLedger.put(s); xerr != nil {
+		return xerr
+	}
+	return ctrler.supplyLedger.prune(height)
+}
+
+// snapshotItem returns the record at `height` as a snapshot item.
+// It returns nil, if there is no record of `height`.
+func (ledger *supplyLedger) snapshotItem(height int64) (*rctypes.SnapshotItemProto, xerrors.XError) {
+	bz, err := ledger.db.Get(supplyKey(height))
+	if err != nil {
+		return nil, xerrors.From(err)
+	} else if bz == nil {
+		return nil, nil
+	}
+	return &rctypes.SnapshotItemProto{
+		Store: snapshotStoreSupply,
+		Key:   supplyKey(height),
+		Value: bz,
+	}, nil
+}
+
+// restore puts the records included in a snapshot.
+func (ledger *supplyLedger) restore(items []*rctypes.SnapshotItemProto) xerrors.XError {
+	for _, item := range items {
+		if err := ledger.db.SetSync(item.Key, item.Value); err != nil {
+			return xerrors.From(err)
+		}
+	}
+	return nil
+}
+
+// querySupply returns the supply at `height`.
+func (ctrler *RigoApp) querySupply(height int64) ([]byte, xerrors.XError) {
+	s, xerr := ctrler.supplyLedger.get(height)
+	if xerr != nil {
+		return nil, xerrors.ErrQuery.Wrap(xerr)
+	} else if s == nil {
+		return nil, xerrors.ErrQuery.Wrapf("no supply at the height %v", height)
+	}
+
+	bz, err := tmjson.Marshal(s)
+	if err != nil {
+		return nil, xerrors.ErrQuery.Wrap(err)
+	}
+	return bz, nil
+}
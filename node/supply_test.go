@@ -0,0 +1,142 @@
+package node
+
+import (
+	"github.com/holiman/uint256"
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/ledger"
+	"github.com/rigochain/rigo-go/libs/web3"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSupply(t *testing.T) {
+	app := newTestRigoApp(t, "supply", nil)
+	defer func() { _ = app.Stop() }()
+
+	govParams := ctrlertypes.Test7GovParams_FeeDistribution()
+	val := newTestValidator(t, 10_000_000)
+	w0, w1 := web3.NewWallet(nil), web3.NewWallet(nil)
+	initTestChainWith(t, app, val, govParams, w0)
+
+	s0, xerr := app.supplyLedger.get(0)
+	require.NoError(t, xerr)
+	require.NotNil(t, s0)
+	require.Equal(t, ctrlertypes.PowerToAmount(val.power), s0.Staked)
+	genBalance := new(uint256.Int).Mul(uint256.NewInt(1_000_000_000), uint256.NewInt(1_000_000_000_000_000_000))
+	require.Equal(t, new(uint256.Int).Add(genBalance, s0.Staked), s0.Total)
+	require.Equal(t, genBalance, s0.Circulating)
+
+	for h := int64(1); h <= 8; h++ {
+		execTestBlock(t, app, h, val, newTestTransfer(t, w0, w1, uint64(h-1)))
+	}
+
+	qresp := app.Query(abcitypes.RequestQuery{Path: "supply", Height: 8})
+	require.Equal(t, abcitypes.CodeTypeOK, qresp.Code, qresp.Log)
+	s8 := &ctrlertypes.Supply{}
+	require.NoError(t, tmjson.Unmarshal(qresp.Value, s8))
+	require.EqualValues(t, 8, s8.Height)
+	require.False(t, s8.Issued.IsZero())
+	require.Equal(t, app.stakeCtrler.ReadTotalRewards(), s8.Rewards)
+	require.Equal(t, s8.Issued, s8.Rewards) // no reward is withdrawn.
+
+	// 10% of the fee of every block is burned.
+	burned := new(uint256.Int).Mul(govParams.MinTrxFee(), uint256.NewInt(8))
+	burned = burned.Div(burned, uint256.NewInt(10))
+	require.Equal(t, burned, s8.Burned)
+
+	total := new(uint256.Int).Add(s0.Total, s8.Issued)
+	require.Equal(t, total.Sub(total, s8.Burned), s8.Total)
+
+	// the circulating supply is the sum of the balances.
+	circulating := uint256.NewInt(0)
+	for _, addr := range [][]byte{w0.Address(), w1.Address(), val.addr, ctrlertypes.CommunityPoolAddress} {
+		_ = circulating.Add(circulating, app.acctCtrler.ReadAccount(addr).Balance)
+	}
+	require.Equal(t, circulating, s8.Circulating)
+
+	// the supply is queryable at any height.
+	qresp = app.Query(abcitypes.RequestQuery{Path: "supply", Height: 3})
+	require.Equal(t, abcitypes.CodeTypeOK, qresp.Code, qresp.Log)
+	s3 := &ctrlertypes.Supply{}
+	require.NoError(t, tmjson.Unmarshal(qresp.Value, s3))
+	require.EqualValues(t, 3, s3.Height)
+	require.True(t, s3.Burned.Lt(s8.Burned))
+
+	qresp = app.Query(abcitypes.RequestQuery{Path: "supply", Height: 9})
+	require.NotEqual(t, abcitypes.CodeTypeOK, qresp.Code)
+
+	// the records of the blocks rolled back are removed.
+	require.NoError(t, app.supplyLedger.rollback(3))
+	s8, xerr = app.supplyLedger.get(8)
+	require.NoError(t, xerr)
+	require.Nil(t, s8)
+}
+
+func TestSupply_Starting(t *testing.T) {
+	app := newTestRigoApp(t, "supply_starting", nil)
+	defer func() { _ = app.Stop() }()
+
+	val := newTestValidator(t, 10_000_000)
+	w0, w1 := web3.NewWallet(nil), web3.NewWallet(nil)
+	initTestChain(t, app, val, w0)
+	execTestBlock(t, app, 1, val, newTestTransfer(t, w0, w1, 0))
+
+	// e.g. the node has been running before the supply is recorded.
+	require.NoError(t, app.supplyLedger.rollback(-1))
+
+	execTestBlock(t, app, 2, val, newTestTransfer(t, w0, w1, 1))
+	s2, xerr := app.supplyLedger.get(2)
+	require.NoError(t, xerr)
+	require.NotNil(t, s2)
+	require.True(t, s2.Issued.IsZero())
+	require.True(t, s2.Burned.IsZero())
+	require.Equal(t, app.stakeCtrler.ReadTotalRewards(), s2.Rewards)
+
+	balances := app.acctCtrler.ReadTotalBalance()
+	require.Equal(t, balances, s2.Circulating)
+	total := new(uint256.Int).Add(balances, s2.Staked)
+	require.Equal(t, total.Add(total, s2.Rewards), s2.Total)
+
+	// the supply of the next height is accumulated on the starting record.
+	execTestBlock(t, app, 3, val, newTestTransfer(t, w0, w1, 2))
+	s3, xerr := app.supplyLedger.get(3)
+	require.NoError(t, xerr)
+	require.NotNil(t, s3)
+	require.False(t, s3.Issued.IsZero())
+	require.Equal(t, app.stakeCtrler.ReadTotalRewards(), s3.Rewards)
+
+	// the latest record is included in the snapshot.
+	item, xerr := app.supplyLedger.snapshotItem(3)
+	require.NoError(t, xerr)
+	require.NotNil(t, item)
+	require.Equal(t, snapshotStoreSupply, item.Store)
+}
+
+func TestSupplyLedger_Prune(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "rigo-supply-prune-test")
+	require.NoError(t, os.RemoveAll(dir))
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	ledger0, err := newSupplyLedger(dir, ledger.PruningOption{KeepRecent: 3, KeepEvery: 4, Interval: 2})
+	require.NoError(t, err)
+	defer func() { _ = ledger0.close() }()
+
+	for h := int64(0); h <= 10; h++ {
+		require.NoError(t, ledger0.put(ctrlertypes.NewSupply(h)))
+		require.NoError(t, ledger0.prune(h))
+	}
+
+	for h := int64(0); h <= 10; h++ {
+		s, xerr := ledger0.get(h)
+		require.NoError(t, xerr)
+		if h == 0 || h%4 == 0 || h > 10-3 {
+			require.NotNil(t, s, "height %v", h)
+		} else {
+			require.Nil(t, s, "height %v", h)
+		}
+	}
+}
@@ -11,35 +11,37 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 type TrxExecutor struct {
-	txCtxChs []chan *ctrlertypes.TrxContext
-	logger   log.Logger
+	laneCh  chan func()
+	routine int
+	logger  log.Logger
 }
 
+// NewTrxExecutor returns TrxExecutor running `n` execution routines.
+// If `n` is 0, only ExecuteSync is available.
 func NewTrxExecutor(n int, logger log.Logger) *TrxExecutor {
-	txCtxChs := make([]chan *ctrlertypes.TrxContext, n)
-	for i := 0; i < n; i++ {
-		txCtxChs[i] = make(chan *ctrlertypes.TrxContext, 5000)
-	}
 	return &TrxExecutor{
-		txCtxChs: txCtxChs,
-		logger:   logger,
+		laneCh:  make(chan func(), n),
+		routine: n,
+		logger:  logger,
 	}
 }
 
 func (txe *TrxExecutor) Start() {
-	for i, ch := range txe.txCtxChs {
-		go executionRoutine(fmt.Sprintf("executionRoutine-%d", i), ch, txe.logger)
+	for i := 0; i < txe.routine; i++ {
+		go executionRoutine(fmt.Sprintf("executionRoutine-%d", i), txe.laneCh, txe.logger)
 	}
 }
 
 func (txe *TrxExecutor) Stop() {
-	for _, ch := range txe.txCtxChs {
-		close(ch)
+	if txe.laneCh != nil {
+		close(txe.laneCh)
 	}
-	txe.txCtxChs = nil
+	txe.laneCh = nil
 }
 
 func (txe *TrxExecutor) ExecuteSync(ctx *ctrlertypes.TrxContext) xerrors.XError {
@@ -54,17 +56,22 @@ func (txe *TrxExecutor) ExecuteSync(ctx *ctrlertypes.TrxContext) xerrors.XError
 	return nil
 }
 
-func (txe *TrxExecutor) ExecuteAsync(ctx *ctrlertypes.TrxContext) xerrors.XError {
-	n := len(txe.txCtxChs)
-	i := int(ctx.Tx.From[0]) % n
+// ExecuteLanes calls `fn` for each lane in the execution routines and waits until all lanes are finished.
+func (txe *TrxExecutor) ExecuteLanes(lanes []*trxLane, fn func(*trxLane)) xerrors.XError {
+	if txe.routine == 0 || txe.laneCh == nil {
+		return xerrors.NewOrdinary("transaction execution routine is not available")
+	}
 
-	if txe.txCtxChs[i] == nil {
-		return xerrors.NewOrdinary("transaction execution channel is not available")
+	wg := sync.WaitGroup{}
+	wg.Add(len(lanes))
+	for _, lane := range lanes {
+		_lane := lane
+		txe.laneCh <- func() {
+			defer wg.Done()
+			fn(_lane)
+		}
 	}
-	//if ctx.Exec {
-	//	txe.logger.Info("[DEBUG] TrxExecutor::ExecuteAsync", "index", i, "txhash", ctx.TxHash)
-	//}
-	txe.txCtxChs[i] <- ctx
+	wg.Wait()
 
 	return nil
 }
@@ -81,24 +88,11 @@ func goid() int {
 	return id
 }
 
-func executionRoutine(name string, ch chan *ctrlertypes.TrxContext, logger log.Logger) {
+func executionRoutine(name string, ch chan func(), logger log.Logger) {
 	logger.Info("Start transaction execution routine", "goid", goid(), "name", name)
 
-	for ctx := range ch {
-		//if ctx.Exec {
-		//	logger.Info("[DEBUG] Begin of executionRoutine", "txhash", ctx.TxHash, "goid", goid(), "name", name)
-		//}
-		var xerr xerrors.XError
-
-		if xerr = validateTrx(ctx); xerr == nil {
-			xerr = runTrx(ctx)
-		}
-
-		//if ctx.Exec {
-		//	logger.Info("[DEBUG] End of executionRoutine", "txhash", ctx.TxHash, "goid", goid(), "name", name)
-		//}
-
-		ctx.Callback(ctx, xerr)
+	for fn := range ch {
+		fn()
 	}
 }
 
@@ -114,14 +108,32 @@ func commonValidation0(ctx *ctrlertypes.TrxContext) xerrors.XError {
 	if len(tx.To) != rtypes.AddrSize {
 		return xerrors.ErrInvalidAddress
 	}
+	if len(tx.Payer) > 0 {
+		if tx.Version < ctrlertypes.TRX_VERSION_FEE_PAYER {
+			return xerrors.ErrInvalidTrx.Wrapf("the fee payer is not available in the tx version %v", tx.Version)
+		}
+		if len(tx.Payer) != rtypes.AddrSize {
+			return xerrors.ErrInvalidAddress
+		}
+		if tx.Payer.Compare(tx.From) == 0 {
+			return xerrors.ErrInvalidTrx.Wrapf("the fee payer is the sender")
+		}
+	} else if len(tx.PayerSig) > 0 {
+		return xerrors.ErrInvalidTrxSig.Wrapf("the signature of no fee payer")
+	}
 	if tx.Amount.Sign() < 0 {
 		return xerrors.ErrInvalidAmount
 	}
 	if tx.Gas < 0 || tx.Gas > math.MaxInt64 {
 		return xerrors.ErrInvalidGas
 	}
-	if tx.GasPrice.Sign() < 0 || tx.GasPrice.Cmp(ctx.GovHandler.GasPrice()) != 0 {
-		return xerrors.ErrInvalidGasPrice
+	// the gas price over the base fee is the tip given to the proposer.
+	baseFee := ctx.BaseFee
+	if baseFee == nil {
+		baseFee = ctx.GovHandler.GasPrice()
+	}
+	if tx.GasPrice.Sign() < 0 || tx.GasPrice.Lt(baseFee) {
+		return xerrors.ErrInvalidGasPrice.Wrapf("the gas price(%v) is less than the base fee(%v)", tx.GasPrice.Dec(), baseFee.Dec())
 	}
 
 	feeAmt := new(uint256.Int).Mul(tx.GasPrice, uint256.NewInt(tx.Gas))
@@ -129,13 +141,44 @@ func commonValidation0(ctx *ctrlertypes.TrxContext) xerrors.XError {
 		return xerrors.ErrInvalidGas.Wrapf("too small gas(fee)")
 	}
 
+	// the tx is checked against the block time when it is delivered,
+	// and against the expected time of the next block, which is based on the last block time, when it is checked.
+	// So, the result of CheckTx does not depend on the local clock of the node.
+	if xerr := validateTrxTime(tx, ctx.BlockTime, ctx.GovHandler.TxTimeWindow()); xerr != nil {
+		return xerr
+	}
+
+	// CheckTx verifies the signatures before it changes the pending state of the sender. (see RigoApp.CheckTx)
 	if ctx.Exec {
+		if xerr := verifyTrxSigs(ctx); xerr != nil {
+			return xerr
+		}
+	}
+	return nil
+}
+
+// verifyTrxSigs verifies the signatures of the sender and the fee payer of the tx.
+func verifyTrxSigs(ctx *ctrlertypes.TrxContext) xerrors.XError {
+	tx := ctx.Tx
+
+	// the tx from a multi-signature account has no single public key of the sender.
+	if ms := ctx.Sender.GetMultiSig(); ms != nil {
+		if xerr := ctrlertypes.VerifyTrxMultiSigRLP(tx, ms, ctx.ChainID); xerr != nil {
+			return xerr
+		}
+	} else {
 		_, pubKeyBytes, xerr := ctrlertypes.VerifyTrxRLP(tx, ctx.ChainID)
 		if xerr != nil {
 			return xerr
 		}
 		ctx.SenderPubKey = pubKeyBytes
 	}
+
+	if ctx.Payer != nil {
+		if _, _, xerr := ctrlertypes.VerifyTrxPayerRLP(tx, ctx.ChainID); xerr != nil {
+			return xerr
+		}
+	}
 	return nil
 }
 
@@ -147,10 +190,25 @@ func commonValidation1(ctx *ctrlertypes.TrxContext) xerrors.XError {
 	tx := ctx.Tx
 
 	feeAmt := new(uint256.Int).Mul(tx.GasPrice, uint256.NewInt(tx.Gas))
-	needAmt := new(uint256.Int).Add(feeAmt, tx.Amount)
-	if xerr := ctx.Sender.CheckBalance(needAmt); xerr != nil {
+
+	// the balance locked by a vesting schedule can be staked, but it can not be spent.
+	spendAmt, stakeAmt := tx.Amount.Clone(), uint256.NewInt(0)
+	if tx.GetType() == ctrlertypes.TRX_STAKING {
+		spendAmt, stakeAmt = stakeAmt, spendAmt
+	}
+	if ctx.Payer != nil {
+		if xerr := ctx.Payer.CheckBalance(feeAmt, ctx.BlockTime); xerr != nil {
+			return xerr
+		}
+	} else {
+		_ = spendAmt.Add(spendAmt, feeAmt)
+	}
+	if xerr := ctx.Sender.CheckBalance(spendAmt, ctx.BlockTime); xerr != nil {
 		return xerr
 	}
+	if stakeAmt.Sign() > 0 && new(uint256.Int).Add(spendAmt, stakeAmt).Gt(ctx.Sender.GetBalance()) {
+		return xerrors.ErrInsufficientFund
+	}
 	if xerr := ctx.Sender.CheckNonce(tx.Nonce); xerr != nil {
 		return xerr.Wrap(fmt.Errorf("invalid nonce - ledger: %v, tx:%v, address: %v, txhash: %X", ctx.Sender.GetNonce(), tx.Nonce, ctx.Sender.Address, ctx.TxHash))
 	}
@@ -173,11 +231,11 @@ func validateTrx(ctx *ctrlertypes.TrxContext) xerrors.XError {
 		if xerr := ctx.TrxGovHandler.ValidateTrx(ctx); xerr != nil {
 			return xerr
 		}
-	case ctrlertypes.TRX_TRANSFER, ctrlertypes.TRX_SETDOC:
+	case ctrlertypes.TRX_TRANSFER, ctrlertypes.TRX_SETDOC, ctrlertypes.TRX_MULTISIG, ctrlertypes.TRX_BATCH, ctrlertypes.TRX_VESTING:
 		if xerr := ctx.TrxAcctHandler.ValidateTrx(ctx); xerr != nil {
 			return xerr
 		}
-	case ctrlertypes.TRX_STAKING, ctrlertypes.TRX_UNSTAKING, ctrlertypes.TRX_WITHDRAW:
+	case ctrlertypes.TRX_STAKING, ctrlertypes.TRX_UNSTAKING, ctrlertypes.TRX_WITHDRAW, ctrlertypes.TRX_COMMISSION, ctrlertypes.TRX_REDELEGATE, ctrlertypes.TRX_UNJAIL:
 		if xerr := ctx.TrxStakeHandler.ValidateTrx(ctx); xerr != nil {
 			return xerr
 		}
@@ -205,7 +263,7 @@ func runTrx(ctx *ctrlertypes.TrxContext) xerrors.XError {
 		if xerr := ctx.TrxGovHandler.ExecuteTrx(ctx); xerr != nil {
 			return xerr
 		}
-	case ctrlertypes.TRX_TRANSFER, ctrlertypes.TRX_SETDOC:
+	case ctrlertypes.TRX_TRANSFER, ctrlertypes.TRX_SETDOC, ctrlertypes.TRX_MULTISIG, ctrlertypes.TRX_BATCH, ctrlertypes.TRX_VESTING:
 		if ctx.Tx.GetType() == ctrlertypes.TRX_TRANSFER && ctx.Receiver.Code != nil {
 			if xerr := ctx.TrxEVMHandler.ExecuteTrx(ctx); xerr != nil && xerr != xerrors.ErrUnknownTrxType {
 				return xerr
@@ -213,7 +271,7 @@ func runTrx(ctx *ctrlertypes.TrxContext) xerrors.XError {
 		} else if xerr := ctx.TrxAcctHandler.ExecuteTrx(ctx); xerr != nil {
 			return xerr
 		}
-	case ctrlertypes.TRX_STAKING, ctrlertypes.TRX_UNSTAKING, ctrlertypes.TRX_WITHDRAW:
+	case ctrlertypes.TRX_STAKING, ctrlertypes.TRX_UNSTAKING, ctrlertypes.TRX_WITHDRAW, ctrlertypes.TRX_COMMISSION, ctrlertypes.TRX_REDELEGATE, ctrlertypes.TRX_UNJAIL:
 		if xerr := ctx.TrxStakeHandler.ExecuteTrx(ctx); xerr != nil {
 			return xerr
 		}
@@ -276,9 +334,14 @@ func postRunTrx(ctx *ctrlertypes.TrxContext) xerrors.XError {
 
 		// processing fee = gas * gasPrice
 		fee := new(uint256.Int).Mul(ctx.Tx.GasPrice, uint256.NewInt(uint64(ctx.Tx.Gas)))
-		if xerr := ctx.Sender.SubBalance(fee); xerr != nil {
+		if xerr := ctx.FeePayer().SubBalance(fee); xerr != nil {
 			return xerr
 		}
+		if ctx.Payer != nil {
+			if xerr := ctx.AcctHandler.SetAccountCommittable(ctx.Payer, ctx.Exec); xerr != nil {
+				return xerr
+			}
+		}
 
 		// processing nonce
 		ctx.Sender.AddNonce()
@@ -292,3 +355,15 @@ func postRunTrx(ctx *ctrlertypes.TrxContext) xerrors.XError {
 	}
 	return nil
 }
+
+// validateTrxTime returns an error if the time of `tx` is more than `window` seconds before or after `now`.
+func validateTrxTime(tx *ctrlertypes.Trx, now, window int64) xerrors.XError {
+	if window <= 0 {
+		return nil
+	}
+	txTime := time.Unix(0, tx.Time).Unix()
+	if txTime < now-window || txTime > now+window {
+		return xerrors.ErrInvalidTrxTime.Wrapf("the tx time(%v) is out of the valid window [%v, %v]", txTime, now-window, now+window)
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+package node
+
+import (
+	"github.com/holiman/uint256"
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/ledger"
+	"github.com/rigochain/rigo-go/types"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"sync"
+)
+
+// laneTrx is a tx which is executed in a lane.
+type laneTrx struct {
+	txbz  []byte
+	tx    *ctrlertypes.Trx // nil if `txbz` can not be decoded.
+	txIdx int
+
+	resp abcitypes.ResponseDeliverTx
+	fee  *uint256.Int
+}
+
+// trxLane is a group of txs which touch the accounts not touched by txs of the other lanes.
+// The txs of a lane are executed serially in the order of the block,
+// and the lanes are executed in parallel.
+type trxLane struct {
+	txs         []*laneTrx
+	addrs       map[ledger.LedgerKey]struct{}
+	acctHandler *laneAcctHandler
+}
+
+// groupTrxLanes groups `txs` into the lanes by the sender, receiver and fee payer addresses.
+// The txs sharing an address are in the same lane, and the lanes are ordered by their first tx.
+func groupTrxLanes(txs []*laneTrx) []*trxLane {
+	var lanes []*trxLane
+	laneOf := make(map[ledger.LedgerKey]*trxLane)
+
+	for _, ltx := range txs {
+		var keys []ledger.LedgerKey
+		if ltx.tx != nil {
+			keys = append(keys, ledger.ToLedgerKey(ltx.tx.From), ledger.ToLedgerKey(ltx.tx.To))
+			if ltx.tx.Payer != nil {
+				keys = append(keys, ledger.ToLedgerKey(ltx.tx.Payer))
+			}
+		}
+
+		// find the lanes which have to be merged with `ltx`.
+		var lane *trxLane
+		for _, k := range keys {
+			other, ok := laneOf[k]
+			if !ok || other == lane {
+				continue
+			}
+			if lane == nil {
+				lane = other
+				continue
+			}
+
+			// merge the later lane into the earlier one, keeping the order of txs.
+			first, second := lane, other
+			if lanePos(lanes, second) < lanePos(lanes, first) {
+				first, second = second, first
+			}
+			first.txs = mergeLaneTxs(first.txs, second.txs)
+			for k0 := range second.addrs {
+				first.addrs[k0] = struct{}{}
+				laneOf[k0] = first
+			}
+			lanes = removeLane(lanes, second)
+			lane = first
+		}
+
+		if lane == nil {
+			lane = &trxLane{addrs: make(map[ledger.LedgerKey]struct{})}
+			lanes = append(lanes, lane)
+		}
+		lane.txs = append(lane.txs, ltx)
+		for _, k := range keys {
+			lane.addrs[k] = struct{}{}
+			laneOf[k] = lane
+		}
+	}
+	return lanes
+}
+
+func lanePos(lanes []*trxLane, lane *trxLane) int {
+	for i, l := range lanes {
+		if l == lane {
+			return i
+		}
+	}
+	return -1
+}
+
+func removeLane(lanes []*trxLane, lane *trxLane) []*trxLane {
+	i := lanePos(lanes, lane)
+	return append(lanes[:i], lanes[i+1:]...)
+}
+
+func mergeLaneTxs(txs0, txs1 []*laneTrx) []*laneTrx {
+	merged := make([]*laneTrx, 0, len(txs0)+len(txs1))
+	i, j := 0, 0
+	for i < len(txs0) && j < len(txs1) {
+		if txs0[i].txIdx < txs1[j].txIdx {
+			merged = append(merged, txs0[i])
+			i++
+		} else {
+			merged = append(merged, txs1[j])
+			j++
+		}
+	}
+	merged = append(merged, txs0[i:]...)
+	return append(merged, txs1[j:]...)
+}
+
+// findLaneConflict returns true if an account is touched by more than one lane.
+func findLaneConflict(lanes []*trxLane) bool {
+	touchedBy := make(map[ledger.LedgerKey]*trxLane)
+	for _, lane := range lanes {
+		for k := range lane.acctHandler.touched {
+			if other, ok := touchedBy[k]; ok && other != lane {
+				return true
+			}
+			touchedBy[k] = lane
+		}
+	}
+	return false
+}
+
+// laneAcctHandler is the IAccountHandler used by the txs of a lane.
+// It works on the copies of the accounts and keeps the updated accounts
+// until `flush` is called, so that the result of a lane can be discarded when the lanes conflict.
+type laneAcctHandler struct {
+	ctrlertypes.IAccountHandler
+
+	accts   map[ledger.LedgerKey]*ctrlertypes.Account
+	touched map[ledger.LedgerKey]struct{}
+	updated []*ctrlertypes.Account
+
+	mtx sync.Mutex
+}
+
+func newLaneAcctHandler(acctHandler ctrlertypes.IAccountHandler) *laneAcctHandler {
+	return &laneAcctHandler{
+		IAccountHandler: acctHandler,
+		accts:           make(map[ledger.LedgerKey]*ctrlertypes.Account),
+		touched:         make(map[ledger.LedgerKey]struct{}),
+	}
+}
+
+func (handler *laneAcctHandler) findAccount(addr types.Address, exec bool) *ctrlertypes.Account {
+	k := ledger.ToLedgerKey(addr)
+	handler.touched[k] = struct{}{}
+
+	if acct, ok := handler.accts[k]; ok {
+		return acct
+	}
+	acct := handler.IAccountHandler.FindAccount(addr, exec)
+	if acct == nil {
+		return nil
+	}
+	acct = acct.Clone()
+	handler.accts[k] = acct
+	return acct
+}
+
+func (handler *laneAcctHandler) setAccountCommittable(acct *ctrlertypes.Account) {
+	k := ledger.ToLedgerKey(acct.Address)
+	handler.touched[k] = struct{}{}
+
+	handler.accts[k] = acct
+	for _, updated := range handler.updated {
+		if updated == acct {
+			return
+		}
+	}
+	handler.updated = append(handler.updated, acct)
+}
+
+func (handler *laneAcctHandler) FindOrNewAccount(addr types.Address, exec bool) *ctrlertypes.Account {
+	handler.mtx.Lock()
+	defer handler.mtx.Unlock()
+
+	if acct := handler.findAccount(addr, exec); acct != nil {
+		return acct
+	}
+
+	newAcct := ctrlertypes.NewAccountWithName(addr, "")
+	handler.setAccountCommittable(newAcct)
+	return newAcct
+}
+
+func (handler *laneAcctHandler) FindAccount(addr types.Address, exec bool) *ctrlertypes.Account {
+	handler.mtx.Lock()
+	defer handler.mtx.Unlock()
+
+	return handler.findAccount(addr, exec)
+}
+
+func (handler *laneAcctHandler) Transfer(from, to types.Address, amt *uint256.Int, exec bool) xerrors.XError {
+	handler.mtx.Lock()
+	defer handler.mtx.Unlock()
+
+	acct0 := handler.findAccount(from, exec)
+	if acct0 == nil {
+		return xerrors.ErrNotFoundAccount.Wrapf("Transfer - address: %v", from)
+	}
+	acct1 := handler.findAccount(to, exec)
+	if acct1 == nil {
+		acct1 = ctrlertypes.NewAccountWithName(to, "")
+	}
+	if xerr := acct0.SubBalance(amt); xerr != nil {
+		return xerr
+	}
+	if xerr := acct1.AddBalance(amt); xerr != nil {
+		_ = acct0.AddBalance(amt) // refund
+		return xerr
+	}
+
+	handler.setAccountCommittable(acct0)
+	handler.setAccountCommittable(acct1)
+	return nil
+}
+
+func (handler *laneAcctHandler) Reward(to types.Address, amt *uint256.Int, exec bool) xerrors.XError {
+	handler.mtx.Lock()
+	defer handler.mtx.Unlock()
+
+	acct := handler.findAccount(to, exec)
+	if acct == nil {
+		return xerrors.ErrNotFoundAccount.Wrapf("Reward - address: %v", to)
+	}
+	if xerr := acct.AddBalance(amt); xerr != nil {
+		return xerr
+	}
+	handler.setAccountCommittable(acct)
+	return nil
+}
+
+func (handler *laneAcctHandler) SetAccountCommittable(acct *ctrlertypes.Account, exec bool) xerrors.XError {
+	handler.mtx.Lock()
+	defer handler.mtx.Unlock()
+
+	handler.setAccountCommittable(acct)
+	return nil
+}
+
+// flush sets the updated accounts to the underlying IAccountHandler.
+func (handler *laneAcctHandler) flush() xerrors.XError {
+	handler.mtx.Lock()
+	defer handler.mtx.Unlock()
+
+	for _, acct := range handler.updated {
+		if xerr := handler.IAccountHandler.SetAccountCommittable(acct, true); xerr != nil {
+			return xerr
+		}
+	}
+	handler.updated = nil
+	return nil
+}
+
+var _ ctrlertypes.IAccountHandler = (*laneAcctHandler)(nil)
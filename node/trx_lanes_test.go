@@ -0,0 +1,175 @@
+package node
+
+import (
+	"github.com/holiman/uint256"
+	cfg "github.com/rigochain/rigo-go/cmd/config"
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/ledger"
+	"github.com/rigochain/rigo-go/libs/web3"
+	"github.com/rigochain/rigo-go/types"
+	"github.com/stretchr/testify/require"
+	abcicli "github.com/tendermint/tendermint/abci/client"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"testing"
+)
+
+func Test_groupTrxLanes(t *testing.T) {
+	addrs := make([]types.Address, 8)
+	for i := range addrs {
+		addrs[i] = types.RandAddress()
+	}
+	newLaneTrx := func(txIdx int, from, to types.Address) *laneTrx {
+		return &laneTrx{
+			tx:    web3.NewTrxTransfer(from, to, 0, 0, uint256.NewInt(0), uint256.NewInt(0)),
+			txIdx: txIdx,
+		}
+	}
+
+	ltxs := []*laneTrx{
+		newLaneTrx(0, addrs[0], addrs[1]),
+		newLaneTrx(1, addrs[2], addrs[3]),
+		newLaneTrx(2, addrs[4], addrs[4]),
+		{txIdx: -1}, // not decoded
+		newLaneTrx(3, addrs[1], addrs[0]),
+		newLaneTrx(4, addrs[3], addrs[1]), // merge the lanes of #0 and #1
+	}
+
+	lanes := groupTrxLanes(ltxs)
+	require.Len(t, lanes, 3)
+	require.Equal(t, []*laneTrx{ltxs[0], ltxs[1], ltxs[4], ltxs[5]}, lanes[0].txs)
+	require.Equal(t, []*laneTrx{ltxs[2]}, lanes[1].txs)
+	require.Equal(t, []*laneTrx{ltxs[3]}, lanes[2].txs)
+	require.Len(t, lanes[0].addrs, 4)
+
+	// the tx paid by addrs[4] is in the lane of addrs[4].
+	sponsored := newLaneTrx(5, addrs[6], addrs[7])
+	sponsored.tx = web3.SetTrxPayer(sponsored.tx, addrs[4])
+	lanes = groupTrxLanes(append(ltxs, sponsored))
+	require.Len(t, lanes, 3)
+	require.Equal(t, []*laneTrx{ltxs[2], sponsored}, lanes[1].txs)
+	require.Len(t, lanes[1].addrs, 3)
+}
+
+func Test_findLaneConflict(t *testing.T) {
+	acctHandler := &acctHandlerMock{}
+	lane0 := &trxLane{acctHandler: newLaneAcctHandler(acctHandler)}
+	lane1 := &trxLane{acctHandler: newLaneAcctHandler(acctHandler)}
+
+	addr0, addr1 := types.RandAddress(), types.RandAddress()
+	lane0.acctHandler.FindOrNewAccount(addr0, true)
+	lane1.acctHandler.FindOrNewAccount(addr1, true)
+	require.False(t, findLaneConflict([]*trxLane{lane0, lane1}))
+
+	lane1.acctHandler.FindAccount(addr0, true)
+	require.True(t, findLaneConflict([]*trxLane{lane0, lane1}))
+}
+
+func Test_laneAcctHandler(t *testing.T) {
+	acctHandler := &acctHandlerMock{}
+	handler := newLaneAcctHandler(acctHandler)
+
+	addr := types.RandAddress()
+	acct := handler.FindAccount(addr, true)
+	require.NotNil(t, acct)
+	require.Same(t, acct, handler.FindOrNewAccount(addr, true))
+	require.Empty(t, handler.updated)
+
+	require.NoError(t, acct.AddBalance(uint256.NewInt(1)))
+	require.NoError(t, handler.SetAccountCommittable(acct, true))
+	require.NoError(t, handler.SetAccountCommittable(acct, true))
+	require.Equal(t, []*ctrlertypes.Account{acct}, handler.updated)
+	require.Contains(t, handler.touched, ledger.ToLedgerKey(addr))
+}
+
+// testABCIBlockRunner runs blocks through rigoLocalClient as the consensus engine does.
+type testABCIBlockRunner struct {
+	client abcicli.Client
+	resps  []*abcitypes.ResponseDeliverTx
+}
+
+func newTestABCIBlockRunner(app *RigoApp) *testABCIBlockRunner {
+	runner := &testABCIBlockRunner{client: NewRigoLocalClient(nil, app)}
+	runner.client.SetResponseCallback(func(req *abcitypes.Request, res *abcitypes.Response) {
+		if r, ok := res.Value.(*abcitypes.Response_DeliverTx); ok {
+			runner.resps = append(runner.resps, r.DeliverTx)
+		}
+	})
+	return runner
+}
+
+func (runner *testABCIBlockRunner) execBlock(t *testing.T, height int64, val *testValidator, txs [][]byte) ([]*abcitypes.ResponseDeliverTx, []byte) {
+	runner.resps = nil
+
+	_, err := runner.client.BeginBlockSync(testBeginBlockReq(height, val))
+	require.NoError(t, err)
+	for _, tx := range txs {
+		runner.client.DeliverTxAsync(abcitypes.RequestDeliverTx{Tx: tx})
+	}
+	_, err = runner.client.EndBlockSync(abcitypes.RequestEndBlock{Height: height})
+	require.NoError(t, err)
+	resp, err := runner.client.CommitSync()
+	require.NoError(t, err)
+
+	require.Len(t, runner.resps, len(txs))
+	return runner.resps, resp.Data
+}
+
+func TestParallelDeliverTx(t *testing.T) {
+	serialApp := newTestRigoApp(t, "serial-delivertx", nil)
+	defer func() { _ = serialApp.Stop() }()
+	parallelApp := newTestRigoApp(t, "parallel-delivertx", func(config *cfg.Config) {
+		config.App.ParallelDeliverTx = true
+	})
+	defer func() { _ = parallelApp.Stop() }()
+
+	val := newTestValidator(t, 1_000_000)
+	wallets := make([]*web3.Wallet, 8)
+	for i := range wallets {
+		wallets[i] = web3.NewWallet(nil)
+	}
+	initTestChain(t, serialApp, val, wallets...)
+	initTestChain(t, parallelApp, val, wallets...)
+
+	serialRunner := newTestABCIBlockRunner(serialApp)
+	parallelRunner := newTestABCIBlockRunner(parallelApp)
+
+	govParams := ctrlertypes.DefaultGovParams()
+	nonces := make([]uint64, len(wallets))
+	transfer := func(from, to int) []byte {
+		tx := newTestTransfer(t, wallets[from], wallets[to], nonces[from])
+		nonces[from]++
+		return tx
+	}
+
+	for h := int64(1); h <= 5; h++ {
+		var txs [][]byte
+		// independent transfers
+		for i := 0; i < len(wallets); i += 2 {
+			txs = append(txs, transfer(i, i+1))
+		}
+		// the transfers chained by the sender and receiver
+		txs = append(txs, transfer(1, 2), transfer(2, 3), transfer(3, 0))
+		// the transfer to a new account and the transfers of the same sender
+		txs = append(txs, newTestTransfer(t, wallets[4], web3.NewWallet(nil), nonces[4]))
+		nonces[4]++
+		txs = append(txs, transfer(4, 5), transfer(4, 6))
+		// setdoc
+		txs = append(txs, signTestTrx(t, wallets[7], web3.NewTrxSetDoc(wallets[7].Address(), nonces[7], govParams.MinTrxGas(), govParams.GasPrice(), "name", "https://rigo.io")))
+		nonces[7]++
+		// a tx with the wrong nonce and a tx which can not be decoded
+		txs = append(txs, newTestTransfer(t, wallets[5], wallets[6], nonces[5]+100), []byte("wrong tx"))
+		// staking is executed serially between the lanes.
+		txs = append(txs, signTestTrx(t, wallets[6], web3.NewTrxStaking(wallets[6].Address(), val.addr, nonces[6], govParams.MinTrxGas(), govParams.GasPrice(), uint256.NewInt(1_000_000_000_000_000_000))))
+		nonces[6]++
+		txs = append(txs, transfer(6, 7), transfer(0, 1))
+
+		serialResps, serialHash := serialRunner.execBlock(t, h, val, txs)
+		parallelResps, parallelHash := parallelRunner.execBlock(t, h, val, txs)
+
+		require.Equal(t, serialResps, parallelResps, "height", h)
+		require.Equal(t, serialHash, parallelHash, "height", h)
+
+		// the staking tx should be successful.
+		require.Equal(t, abcitypes.CodeTypeOK, parallelResps[len(parallelResps)-3].Code, parallelResps[len(parallelResps)-3].Log)
+	}
+}
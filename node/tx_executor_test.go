@@ -120,6 +120,22 @@ func init() {
 	txctx = makeTrxCtx(tx, 1)
 	cases = append(cases, &caseObj{"To Zero Address", txctx, nil})
 
+	//
+	// Expired tx
+	tx = web3.NewTrxTransfer(w0.Address(), w1.Address(), 0, govParams.MinTrxGas(), govParams.GasPrice(), uint256.NewInt(1000))
+	tx.Time = time.Now().Add(-time.Duration(govParams.TxTimeWindow()+60) * time.Second).UnixNano()
+	_, _, _ = w0.SignTrxRLP(tx, "tx_executor_test_chain")
+	txctx = makeTrxCtx(tx, 1)
+	cases = append(cases, &caseObj{"Expired tx", txctx, xerrors.ErrInvalidTrxTime})
+
+	//
+	// Future tx
+	tx = web3.NewTrxTransfer(w0.Address(), w1.Address(), 0, govParams.MinTrxGas(), govParams.GasPrice(), uint256.NewInt(1000))
+	tx.Time = time.Now().Add(time.Duration(govParams.TxTimeWindow()+60) * time.Second).UnixNano()
+	_, _, _ = w0.SignTrxRLP(tx, "tx_executor_test_chain")
+	txctx = makeTrxCtx(tx, 1)
+	cases = append(cases, &caseObj{"Future tx", txctx, xerrors.ErrInvalidTrxTime})
+
 	//
 	// Success
 	tx = web3.NewTrxTransfer(w0.Address(), w1.Address(), 0, govParams.MinTrxGas(), govParams.GasPrice(), uint256.NewInt(1000))
@@ -130,7 +146,7 @@ func init() {
 
 func makeTrxCtx(tx *ctrlertypes.Trx, height int64) *ctrlertypes.TrxContext {
 	bz, _ := tx.Encode()
-	txctx, _ := ctrlertypes.NewTrxContext(bz, height, time.Now().UnixMilli(), true, func(_txctx *ctrlertypes.TrxContext) xerrors.XError {
+	txctx, _ := ctrlertypes.NewTrxContext(bz, height, time.Now().Unix(), true, func(_txctx *ctrlertypes.TrxContext) xerrors.XError {
 		_txctx.GovHandler = govParams
 		_txctx.AcctHandler = &acctHandlerMock{}
 		_txctx.TrxAcctHandler = &acctHandlerMock{}
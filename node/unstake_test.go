@@ -0,0 +1,72 @@
+package node
+
+import (
+	"encoding/json"
+	"github.com/holiman/uint256"
+	"github.com/rigochain/rigo-go/ctrlers/stake"
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/libs/web3"
+	"github.com/rigochain/rigo-go/types/bytes"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	"testing"
+)
+
+func TestUnstakingAmount(t *testing.T) {
+	app := newTestRigoApp(t, "unstake_amount", nil)
+	defer func() { _ = app.Stop() }()
+
+	val := newTestValidator(t, 1_000_000)
+	w0 := web3.NewWallet(nil)
+	initTestChain(t, app, val, w0)
+
+	govParams := ctrlertypes.DefaultGovParams()
+	newStaking := func(nonce uint64, power int64) []byte {
+		return signTestTrx(t, w0, web3.NewTrxStaking(w0.Address(), val.addr, nonce, govParams.MinTrxGas(), govParams.GasPrice(), ctrlertypes.PowerToAmount(power)))
+	}
+	newUnstaking := func(nonce uint64, txhash bytes.HexBytes, amt *uint256.Int) []byte {
+		return signTestTrx(t, w0, web3.NewTrxUnstakingAmount(w0.Address(), val.addr, nonce, govParams.MinTrxGas(), govParams.GasPrice(), txhash, amt))
+	}
+
+	txA, txB := newStaking(0, 1000), newStaking(1, 2000)
+	txhashA, txhashB := bytes.HexBytes(tmtypes.Tx(txA).Hash()), bytes.HexBytes(tmtypes.Tx(txB).Hash())
+	execTestBlock(t, app, 1, val, txA)
+	execTestBlock(t, app, 2, val, txB)
+
+	app.BeginBlock(testBeginBlockReq(3, val))
+	resp := app.DeliverTx(abcitypes.RequestDeliverTx{Tx: newUnstaking(2, txhashA, ctrlertypes.PowerToAmount(1001))})
+	require.NotEqual(t, abcitypes.CodeTypeOK, resp.Code, "over the power of the stake")
+	resp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: newUnstaking(2, nil, ctrlertypes.PowerToAmount(3001))})
+	require.NotEqual(t, abcitypes.CodeTypeOK, resp.Code, "over the power of all the stakes")
+	resp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: newUnstaking(2, nil, uint256.NewInt(1))})
+	require.NotEqual(t, abcitypes.CodeTypeOK, resp.Code, "not multiple of AmountPerPower")
+
+	// all of stake B and a part of stake A are frozen.
+	resp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: newUnstaking(2, nil, ctrlertypes.PowerToAmount(2500))})
+	require.Equal(t, abcitypes.CodeTypeOK, resp.Code, resp.Log)
+
+	var frozen []*stake.Stake
+	require.NoError(t, json.Unmarshal(resp.Data, &frozen))
+	require.Len(t, frozen, 2)
+	require.Equal(t, txhashB, frozen[0].TxHash)
+	require.EqualValues(t, 2000, frozen[0].Power)
+	require.NotEqual(t, txhashA, frozen[1].TxHash)
+	require.EqualValues(t, 500, frozen[1].Power)
+	require.EqualValues(t, 3+govParams.LazyRewardBlocks(), frozen[1].RefundHeight)
+
+	// a part of stake A is frozen again.
+	resp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: newUnstaking(3, txhashA, ctrlertypes.PowerToAmount(100))})
+	require.Equal(t, abcitypes.CodeTypeOK, resp.Code, resp.Log)
+	app.EndBlock(abcitypes.RequestEndBlock{Height: 3})
+	app.Commit()
+
+	require.Len(t, app.stakeCtrler.ReadFrozenStakes(), 3)
+	require.Equal(t, ctrlertypes.PowerToAmount(2600), app.stakeCtrler.TotalFrozenAmount())
+	delegatee := app.stakeCtrler.Delegatee(val.addr)
+	stakes := delegatee.StakesOf(w0.Address())
+	require.Len(t, stakes, 1)
+	require.Equal(t, txhashA, stakes[0].TxHash)
+	require.EqualValues(t, 400, stakes[0].Power)
+	require.EqualValues(t, val.power+400, delegatee.GetTotalPower())
+}
@@ -0,0 +1,96 @@
+package node
+
+import (
+	"github.com/rigochain/rigo-go/cmd/version"
+	"github.com/rigochain/rigo-go/ctrlers/account"
+	"github.com/rigochain/rigo-go/ctrlers/gov"
+	"github.com/rigochain/rigo-go/ctrlers/stake"
+	rctypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/ctrlers/vm/evm"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	"sync"
+)
+
+// UpgradeContext is passed to UpgradeHandler.
+// The handler migrates the state through the controllers,
+// and the migrated state is committed with the block of the upgrade height.
+type UpgradeContext struct {
+	Plan     *rctypes.UpgradePlan
+	BlockCtx *rctypes.BlockContext
+
+	GovCtrler   *gov.GovCtrler
+	AcctCtrler  *account.AcctCtrler
+	StakeCtrler *stake.StakeCtrler
+	VMCtrler    *evm.EVMCtrler
+}
+
+// UpgradeHandler runs the state migrations of an upgrade.
+// It is called at EndBlock of the upgrade height, after all controllers' EndBlock.
+type UpgradeHandler func(ctx *UpgradeContext) xerrors.XError
+
+var (
+	upgradeHandlers    = make(map[string]UpgradeHandler)
+	upgradeHandlersMtx sync.RWMutex
+)
+
+// RegisterUpgradeHandler registers the handler of the upgrade named `name`.
+// The binary which has no handler of an upgrade halts at the upgrade height.
+func RegisterUpgradeHandler(name string, handler UpgradeHandler) {
+	upgradeHandlersMtx.Lock()
+	defer upgradeHandlersMtx.Unlock()
+
+	upgradeHandlers[name] = handler
+}
+
+func registeredUpgradeHandlers() map[string]UpgradeHandler {
+	upgradeHandlersMtx.RLock()
+	defer upgradeHandlersMtx.RUnlock()
+
+	handlers := make(map[string]UpgradeHandler, len(upgradeHandlers))
+	for name, handler := range upgradeHandlers {
+		handlers[name] = handler
+	}
+	return handlers
+}
+
+// applyUpgrade runs the handler of the upgrade plan applied by the current block.
+// If the handler is not registered, it panics to halt the node,
+// so that the node is restarted with the binary which has the handler.
+func (ctrler *RigoApp) applyUpgrade(plan *rctypes.UpgradePlan) {
+	handler, ok := ctrler.upgradeHandlers[plan.Name]
+	if !ok {
+		ctrler.logger.Error("UPGRADE NEEDED: the binary has no handler of the upgrade", "name", plan.Name, "height", plan.Height, "info", plan.Info)
+		panic(xerrors.ErrUpgrade.Wrapf("UPGRADE \"%v\" NEEDED at height %v: %v", plan.Name, plan.Height, plan.Info))
+	}
+
+	ctrler.logger.Info("Apply the upgrade", "name", plan.Name, "height", plan.Height)
+	if xerr := handler(&UpgradeContext{
+		Plan:        plan,
+		BlockCtx:    ctrler.nextBlockCtx,
+		GovCtrler:   ctrler.govCtrler,
+		AcctCtrler:  ctrler.acctCtrler,
+		StakeCtrler: ctrler.stakeCtrler,
+		VMCtrler:    ctrler.vmCtrler,
+	}); xerr != nil {
+		ctrler.logger.Error("Apply the upgrade", "name", plan.Name, "error", xerr)
+		panic(xerrors.ErrUpgrade.Wrap(xerr))
+	}
+	ctrler.appliedUpgrade = plan
+}
+
+// appVersion returns the app version at `height`.
+// It is bumped by each upgrade applied at or below `height`.
+func (ctrler *RigoApp) appVersion(height int64) uint64 {
+	ver := version.Uint64(version.MASK_MAJOR_VER, version.MASK_MINOR_VER)
+
+	plans, err := ctrler.metaDB.Upgrades()
+	if err != nil {
+		panic(err)
+	}
+	for _, plan := range plans {
+		if plan.Height <= height {
+			ver++
+		}
+	}
+	return ver
+}
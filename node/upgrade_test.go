@@ -0,0 +1,113 @@
+package node
+
+import (
+	"github.com/holiman/uint256"
+	"github.com/rigochain/rigo-go/cmd/version"
+	"github.com/rigochain/rigo-go/ctrlers/gov/proposal"
+	rctypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/libs/web3"
+	"github.com/rigochain/rigo-go/types"
+	"github.com/rigochain/rigo-go/types/xerrors"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	"testing"
+)
+
+func TestUpgrade_HaltAndMigrate(t *testing.T) {
+	app := newTestRigoApp(t, "upgrade", nil)
+
+	valWallet, w0 := web3.NewWallet(nil), web3.NewWallet(nil)
+	val := &testValidator{pubBytes: valWallet.GetPubKey(), addr: valWallet.Address(), power: 1_000_000}
+	govParams := rctypes.Test1GovParams()
+	initTestChainWith(t, app, val, govParams, valWallet, w0)
+
+	// the genesis validators are selected at the end of the second block,
+	// so the proposal is submitted at 3, voted in [4, 14] and applied at 24.
+	plan := &rctypes.UpgradePlan{Name: "v2", Info: "the second version"}
+	planBz, xerr := plan.Encode()
+	require.NoError(t, xerr)
+	propTx := signTestTrx(t, valWallet, web3.NewTrxProposal(valWallet.Address(), types.ZeroAddress(), 0, govParams.MinTrxGas(), govParams.GasPrice(),
+		"upgrade to v2", 4, 10, 24, proposal.PROPOSAL_UPGRADE, planBz))
+	voteTx := signTestTrx(t, valWallet, web3.NewTrxVoting(valWallet.Address(), types.ZeroAddress(), 1, govParams.MinTrxGas(), govParams.GasPrice(),
+		tmtypes.Tx(propTx).Hash(), 0))
+
+	// the proposal with a wrong plan is rejected.
+	wrongTx := signTestTrx(t, valWallet, web3.NewTrxProposal(valWallet.Address(), types.ZeroAddress(), 0, govParams.MinTrxGas(), govParams.GasPrice(),
+		"wrong upgrade", 4, 10, 24, proposal.PROPOSAL_UPGRADE, []byte(`{"info":"no name"}`)))
+	require.NotEqual(t, abcitypes.CodeTypeOK, app.CheckTx(abcitypes.RequestCheckTx{Tx: wrongTx}).Code)
+
+	execTestBlock(t, app, 1, val)
+	execTestBlock(t, app, 2, val)
+	execTestBlock(t, app, 3, val, propTx)
+	execTestBlock(t, app, 4, val, voteTx)
+	for h := int64(5); h < 24; h++ {
+		execTestBlock(t, app, h, val)
+	}
+
+	// the binary without the handler halts at the upgrade height.
+	app.BeginBlock(testBeginBlockReq(24, val))
+	require.Panics(t, func() { app.EndBlock(abcitypes.RequestEndBlock{Height: 24}) })
+	config := app.rootConfig
+	require.NoError(t, app.Stop())
+
+	// the binary with the handler replays the block of the upgrade height.
+	app = restartTestRigoApp(t, config)
+	defer func() { _ = app.Stop() }()
+	var migrated *rctypes.UpgradePlan
+	app.upgradeHandlers[plan.Name] = func(ctx *UpgradeContext) xerrors.XError {
+		migrated = ctx.Plan
+		return ctx.AcctCtrler.Reward(w0.Address(), uint256.NewInt(100), true)
+	}
+
+	baseVersion := version.Uint64(version.MASK_MAJOR_VER, version.MASK_MINOR_VER)
+	info := app.Info(abcitypes.RequestInfo{})
+	require.EqualValues(t, 23, info.LastBlockHeight)
+	require.Equal(t, baseVersion, info.AppVersion)
+
+	balance := app.acctCtrler.ReadAccount(w0.Address()).Balance
+	app.BeginBlock(testBeginBlockReq(24, val))
+	resp := app.EndBlock(abcitypes.RequestEndBlock{Height: 24})
+	app.Commit()
+	require.NotNil(t, migrated)
+	require.Equal(t, plan.Name, migrated.Name)
+	require.EqualValues(t, 24, migrated.Height)
+	require.Equal(t, rctypes.EVENT_TYPE_UPGRADE, resp.Events[len(resp.Events)-1].Type)
+
+	require.Equal(t, new(uint256.Int).AddUint64(balance, 100), app.acctCtrler.ReadAccount(w0.Address()).Balance)
+	upgrades, err := app.metaDB.Upgrades()
+	require.NoError(t, err)
+	require.Len(t, upgrades, 1)
+	require.Equal(t, migrated, upgrades[0])
+	require.Equal(t, baseVersion+1, app.Info(abcitypes.RequestInfo{}).AppVersion)
+
+	// the upgrade plans with an applied name or a wrong height are rejected.
+	newUpgradeProposal := func(nonce uint64, plan *rctypes.UpgradePlan) []byte {
+		bz, xerr := plan.Encode()
+		require.NoError(t, xerr)
+		return signTestTrx(t, valWallet, web3.NewTrxProposal(valWallet.Address(), types.ZeroAddress(), nonce, govParams.MinTrxGas(), govParams.GasPrice(),
+			"upgrade", 30, 10, 50, proposal.PROPOSAL_UPGRADE, bz))
+	}
+	require.NotEqual(t, abcitypes.CodeTypeOK, app.CheckTx(abcitypes.RequestCheckTx{Tx: newUpgradeProposal(2, &rctypes.UpgradePlan{Name: plan.Name})}).Code)
+	require.NotEqual(t, abcitypes.CodeTypeOK, app.CheckTx(abcitypes.RequestCheckTx{Tx: newUpgradeProposal(2, &rctypes.UpgradePlan{Name: "v3", Height: 20})}).Code)
+	require.NotEqual(t, abcitypes.CodeTypeOK, app.CheckTx(abcitypes.RequestCheckTx{Tx: newUpgradeProposal(2, &rctypes.UpgradePlan{Name: "v3", Height: 60})}).Code)
+	v3Tx := newUpgradeProposal(2, &rctypes.UpgradePlan{Name: "v3", Height: 50})
+	require.Equal(t, abcitypes.CodeTypeOK, app.CheckTx(abcitypes.RequestCheckTx{Tx: v3Tx}).Code)
+
+	// the upgrade plan which has been proposed is rejected.
+	app.BeginBlock(testBeginBlockReq(25, val))
+	require.Equal(t, abcitypes.CodeTypeOK, app.DeliverTx(abcitypes.RequestDeliverTx{Tx: v3Tx}).Code)
+	require.NotEqual(t, abcitypes.CodeTypeOK, app.DeliverTx(abcitypes.RequestDeliverTx{Tx: newUpgradeProposal(3, &rctypes.UpgradePlan{Name: "v3"})}).Code)
+	app.EndBlock(abcitypes.RequestEndBlock{Height: 25})
+	app.Commit()
+	require.NotEqual(t, abcitypes.CodeTypeOK, app.CheckTx(abcitypes.RequestCheckTx{Tx: newUpgradeProposal(3, &rctypes.UpgradePlan{Name: "v3"})}).Code)
+
+	// the upgrade applied above the rollback height is removed.
+	blockCtx := rctypes.NewBlockContext(abcitypes.RequestBeginBlock{Header: tmproto.Header{Height: 23}}, nil, nil, nil)
+	require.NoError(t, app.rollback(blockCtx))
+	upgrades, err = app.metaDB.Upgrades()
+	require.NoError(t, err)
+	require.Len(t, upgrades, 0)
+	require.False(t, app.metaDB.HasUpgrade(plan.Name))
+}
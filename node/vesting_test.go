@@ -0,0 +1,69 @@
+package node
+
+import (
+	"github.com/holiman/uint256"
+	ctrlertypes "github.com/rigochain/rigo-go/ctrlers/types"
+	"github.com/rigochain/rigo-go/genesis"
+	"github.com/rigochain/rigo-go/libs/web3"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"testing"
+)
+
+func TestVestingAccount(t *testing.T) {
+	app := newTestRigoApp(t, "vesting", nil)
+	defer func() { _ = app.Stop() }()
+
+	val := newTestValidator(t, 1_000_000)
+	w0, w1, user := web3.NewWallet(nil), web3.NewWallet(nil), web3.NewWallet(nil)
+	rigo := uint256.NewInt(1_000_000_000_000_000_000)
+	balance0 := new(uint256.Int).Mul(uint256.NewInt(1_000_000_000), rigo)
+
+	// all but 1 RIGO of `w1` is locked until the cliff.
+	vesting := ctrlertypes.NewVesting(new(uint256.Int).Sub(balance0, rigo), testGenesisTime, testGenesisTime+1000, testGenesisTime+2000, 0)
+	initTestChainWithHolders(t, app, val, ctrlertypes.DefaultGovParams(),
+		&genesis.GenesisAssetHolder{Address: w0.Address(), Balance: balance0},
+		&genesis.GenesisAssetHolder{Address: w1.Address(), Balance: balance0, Vesting: vesting},
+	)
+
+	govParams := ctrlertypes.DefaultGovParams()
+	app.BeginBlock(testBeginBlockReq(1, val))
+
+	// the locked balance can not be transferred.
+	resp := app.DeliverTx(abcitypes.RequestDeliverTx{Tx: signTestTrx(t, w1, web3.NewTrxTransfer(w1.Address(), w0.Address(), 0, govParams.MinTrxGas(), govParams.GasPrice(), rigo))})
+	require.NotEqual(t, abcitypes.CodeTypeOK, resp.Code)
+
+	// but it can be staked.
+	stakeAmt := ctrlertypes.PowerToAmount(1000)
+	resp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: signTestTrx(t, w1, web3.NewTrxStaking(w1.Address(), val.addr, 0, govParams.MinTrxGas(), govParams.GasPrice(), stakeAmt))})
+	require.Equal(t, abcitypes.CodeTypeOK, resp.Code, resp.Log)
+
+	// `user` becomes a vesting account.
+	start := testGenesisTime + 1
+	grant := new(uint256.Int).Mul(uint256.NewInt(4), rigo)
+	resp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: signTestTrx(t, w0, web3.NewTrxVesting(w0.Address(), user.Address(), 0, govParams.MinTrxGas(), govParams.GasPrice(), grant, start, start+100, start+400, 100))})
+	require.Equal(t, abcitypes.CodeTypeOK, resp.Code, resp.Log)
+	resp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: signTestTrx(t, w0, web3.NewTrxVesting(w0.Address(), user.Address(), 1, govParams.MinTrxGas(), govParams.GasPrice(), grant, start, start+100, start+400, 30))})
+	require.NotEqual(t, abcitypes.CodeTypeOK, resp.Code, "wrong period")
+	resp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: signTestTrx(t, w0, web3.NewTrxVesting(w0.Address(), user.Address(), 1, govParams.MinTrxGas(), govParams.GasPrice(), uint256.NewInt(1), start, start+1, 1<<40, 0))})
+	require.NotEqual(t, abcitypes.CodeTypeOK, resp.Code, "too small amount")
+	// another schedule ending far in the future does not delay the first schedule.
+	resp = app.DeliverTx(abcitypes.RequestDeliverTx{Tx: signTestTrx(t, w0, web3.NewTrxVesting(w0.Address(), user.Address(), 1, govParams.MinTrxGas(), govParams.GasPrice(), rigo, start, 1<<40, 1<<40, 0))})
+	require.Equal(t, abcitypes.CodeTypeOK, resp.Code, resp.Log)
+
+	app.EndBlock(abcitypes.RequestEndBlock{Height: 1})
+	app.Commit()
+
+	acct1 := app.acctCtrler.ReadAccount(w1.Address())
+	require.Equal(t, new(uint256.Int).Sub(balance0, new(uint256.Int).Add(stakeAmt, govParams.MinTrxFee())), acct1.Balance)
+	require.Len(t, acct1.GetVestings(), 1)
+	require.True(t, vesting.Equal(acct1.GetVestings()[0]))
+	require.EqualValues(t, 1, acct1.GetNonce())
+
+	userAcct := app.acctCtrler.ReadAccount(user.Address())
+	require.Equal(t, new(uint256.Int).Add(grant, rigo), userAcct.Balance)
+	require.Len(t, userAcct.GetVestings(), 2)
+	require.Equal(t, userAcct.Balance, userAcct.LockedBalance(start))
+	require.Equal(t, new(uint256.Int).Mul(uint256.NewInt(4), rigo), userAcct.LockedBalance(start+199))
+	require.Equal(t, rigo, userAcct.LockedBalance(start+400))
+}
@@ -20,27 +20,27 @@ func adjustHeight(ctx *tmrpctypes.Context, heightPtr *int64) int64 {
 	return *heightPtr
 }
 
-func QueryAccount(ctx *tmrpctypes.Context, addr abytes.HexBytes, heightPtr *int64) (*QueryResult, error) {
+func QueryAccount(ctx *tmrpctypes.Context, addr abytes.HexBytes, heightPtr *int64, prove bool) (*QueryResult, error) {
 	height := adjustHeight(ctx, heightPtr)
-	if resp, err := tmrpccore.ABCIQuery(ctx, "account", tmbytes.HexBytes(addr), height, false); err != nil {
+	if resp, err := tmrpccore.ABCIQuery(ctx, "account", tmbytes.HexBytes(addr), height, prove); err != nil {
 		return nil, err
 	} else {
 		return &QueryResult{resp.Response}, nil
 	}
 }
 
-func QueryDelegatee(ctx *tmrpctypes.Context, addr abytes.HexBytes, heightPtr *int64) (*QueryResult, error) {
+func QueryDelegatee(ctx *tmrpctypes.Context, addr abytes.HexBytes, heightPtr *int64, prove bool) (*QueryResult, error) {
 	height := adjustHeight(ctx, heightPtr)
-	if resp, err := tmrpccore.ABCIQuery(ctx, "delegatee", tmbytes.HexBytes(addr), height, false); err != nil {
+	if resp, err := tmrpccore.ABCIQuery(ctx, "delegatee", tmbytes.HexBytes(addr), height, prove); err != nil {
 		return nil, err
 	} else {
 		return &QueryResult{resp.Response}, nil
 	}
 }
 
-func QueryStakes(ctx *tmrpctypes.Context, addr abytes.HexBytes, heightPtr *int64) (*QueryResult, error) {
+func QueryStakes(ctx *tmrpctypes.Context, addr abytes.HexBytes, heightPtr *int64, prove bool) (*QueryResult, error) {
 	height := adjustHeight(ctx, heightPtr)
-	if resp, err := tmrpccore.ABCIQuery(ctx, "stakes", tmbytes.HexBytes(addr), height, false); err != nil {
+	if resp, err := tmrpccore.ABCIQuery(ctx, "stakes", tmbytes.HexBytes(addr), height, prove); err != nil {
 		return nil, err
 	} else {
 		return &QueryResult{resp.Response}, nil
@@ -65,27 +65,82 @@ func QueryStakes2(ctx *tmrpctypes.Context, heightPtr *int64) (*QueryResult, erro
 	}
 }
 
-func QueryReward(ctx *tmrpctypes.Context, addr abytes.HexBytes, heightPtr *int64) (*QueryResult, error) {
+func QueryReward(ctx *tmrpctypes.Context, addr abytes.HexBytes, heightPtr *int64, prove bool) (*QueryResult, error) {
 	height := adjustHeight(ctx, heightPtr)
-	if resp, err := tmrpccore.ABCIQuery(ctx, "reward", tmbytes.HexBytes(addr), height, false); err != nil {
+	if resp, err := tmrpccore.ABCIQuery(ctx, "reward", tmbytes.HexBytes(addr), height, prove); err != nil {
 		return nil, err
 	} else {
 		return &QueryResult{resp.Response}, nil
 	}
 }
 
-func QueryProposal(ctx *tmrpctypes.Context, txhash abytes.HexBytes, heightPtr *int64) (*QueryResult, error) {
+// QuerySlashes returns the slashing history of the validator `addr`.
+func QuerySlashes(ctx *tmrpctypes.Context, addr abytes.HexBytes, heightPtr *int64, prove bool) (*QueryResult, error) {
 	height := adjustHeight(ctx, heightPtr)
-	if resp, err := tmrpccore.ABCIQuery(ctx, "proposal", tmbytes.HexBytes(txhash), height, false); err != nil {
+	if resp, err := tmrpccore.ABCIQuery(ctx, "slashes", tmbytes.HexBytes(addr), height, prove); err != nil {
 		return nil, err
 	} else {
 		return &QueryResult{resp.Response}, nil
 	}
 }
 
-func QueryGovParams(ctx *tmrpctypes.Context, heightPtr *int64) (*QueryResult, error) {
+// QueryDelegatorSlashes returns the slashings of the stakes owned by `addr`, grouped by the punished validator.
+func QueryDelegatorSlashes(ctx *tmrpctypes.Context, addr abytes.HexBytes, heightPtr *int64) (*QueryResult, error) {
 	height := adjustHeight(ctx, heightPtr)
-	if resp, err := tmrpccore.ABCIQuery(ctx, "gov_params", nil, height, false); err != nil {
+	if resp, err := tmrpccore.ABCIQuery(ctx, "slashes/delegator", tmbytes.HexBytes(addr), height, false); err != nil {
+		return nil, err
+	} else {
+		return &QueryResult{resp.Response}, nil
+	}
+}
+
+func QueryProposal(ctx *tmrpctypes.Context, txhash abytes.HexBytes, heightPtr *int64, prove bool) (*QueryResult, error) {
+	height := adjustHeight(ctx, heightPtr)
+	if resp, err := tmrpccore.ABCIQuery(ctx, "proposal", tmbytes.HexBytes(txhash), height, prove); err != nil {
+		return nil, err
+	} else {
+		return &QueryResult{resp.Response}, nil
+	}
+}
+
+func QueryGovParams(ctx *tmrpctypes.Context, heightPtr *int64, prove bool) (*QueryResult, error) {
+	height := adjustHeight(ctx, heightPtr)
+	if resp, err := tmrpccore.ABCIQuery(ctx, "gov_params", nil, height, prove); err != nil {
+		return nil, err
+	} else {
+		return &QueryResult{resp.Response}, nil
+	}
+}
+
+func QueryFeeDistribution(ctx *tmrpctypes.Context, heightPtr *int64) (*QueryResult, error) {
+	height := adjustHeight(ctx, heightPtr)
+	if resp, err := tmrpccore.ABCIQuery(ctx, "fee_distribution", nil, height, false); err != nil {
+		return nil, err
+	} else {
+		return &QueryResult{resp.Response}, nil
+	}
+}
+
+func QueryCommunityPool(ctx *tmrpctypes.Context, heightPtr *int64) (*QueryResult, error) {
+	height := adjustHeight(ctx, heightPtr)
+	if resp, err := tmrpccore.ABCIQuery(ctx, "community_pool", nil, height, false); err != nil {
+		return nil, err
+	} else {
+		return &QueryResult{resp.Response}, nil
+	}
+}
+
+func QuerySupply(ctx *tmrpctypes.Context, heightPtr *int64) (*QueryResult, error) {
+	height := adjustHeight(ctx, heightPtr)
+	if resp, err := tmrpccore.ABCIQuery(ctx, "supply", nil, height, false); err != nil {
+		return nil, err
+	} else {
+		return &QueryResult{resp.Response}, nil
+	}
+}
+
+func QueryBaseFee(ctx *tmrpctypes.Context) (*QueryResult, error) {
+	if resp, err := tmrpccore.ABCIQuery(ctx, "base_fee", nil, 0, false); err != nil {
 		return nil, err
 	} else {
 		return &QueryResult{resp.Response}, nil
@@ -12,6 +12,16 @@ type QueryResult struct {
 }
 
 func (qr *QueryResult) MarshalJSON() ([]byte, error) {
+	value := json.RawMessage(qr.Value)
+	if qr.ProofOps != nil && len(qr.Value) > 0 {
+		// the proven value is the item encoded in the ledger, which is not a json.
+		bz, err := json.Marshal(bytes.HexBytes(qr.Value))
+		if err != nil {
+			return nil, err
+		}
+		value = bz
+	}
+
 	return json.Marshal(&struct {
 		Code      uint32           `json:"code,omitempty"`
 		Log       string           `json:"log,omitempty"`
@@ -28,7 +38,7 @@ func (qr *QueryResult) MarshalJSON() ([]byte, error) {
 		Info:      qr.Info,
 		Index:     qr.Index,
 		Key:       qr.Key,
-		Value:     qr.Value,
+		Value:     value,
 		ProofOps:  qr.ProofOps,
 		Height:    qr.Height,
 		Codespace: qr.Codespace,
@@ -57,6 +67,13 @@ func (qr *QueryResult) UnmarshalJSON(bz []byte) error {
 	qr.Index = tmpQr.Index
 	qr.Key = tmpQr.Key
 	qr.Value = tmpQr.Value
+	if tmpQr.ProofOps != nil && len(tmpQr.Value) > 0 {
+		var value bytes.HexBytes
+		if err := json.Unmarshal(tmpQr.Value, &value); err != nil {
+			return err
+		}
+		qr.Value = value
+	}
 	qr.ProofOps = tmpQr.ProofOps
 	qr.Height = tmpQr.Height
 	qr.Codespace = tmpQr.Codespace
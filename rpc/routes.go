@@ -6,16 +6,22 @@ import (
 )
 
 func AddRoutes() {
-	tmrpccore.Routes["account"] = tmrpccore_server.NewRPCFunc(QueryAccount, "addr,height")
-	tmrpccore.Routes["delegatee"] = tmrpccore_server.NewRPCFunc(QueryDelegatee, "addr,height")
-	tmrpccore.Routes["stakes"] = tmrpccore_server.NewRPCFunc(QueryStakes, "addr,height")
+	tmrpccore.Routes["account"] = tmrpccore_server.NewRPCFunc(QueryAccount, "addr,height,prove")
+	tmrpccore.Routes["delegatee"] = tmrpccore_server.NewRPCFunc(QueryDelegatee, "addr,height,prove")
+	tmrpccore.Routes["stakes"] = tmrpccore_server.NewRPCFunc(QueryStakes, "addr,height,prove")
 	tmrpccore.Routes["stakes/total_power"] = tmrpccore_server.NewRPCFunc(QueryStakes1, "height")
 	tmrpccore.Routes["stakes/voting_power"] = tmrpccore_server.NewRPCFunc(QueryStakes2, "height")
-	tmrpccore.Routes["reward"] = tmrpccore_server.NewRPCFunc(QueryReward, "addr,height")
-	tmrpccore.Routes["proposals"] = tmrpccore_server.NewRPCFunc(QueryProposal, "txhash,height") // todo: will be deprecated
-	tmrpccore.Routes["proposal"] = tmrpccore_server.NewRPCFunc(QueryProposal, "txhash,height")
-	tmrpccore.Routes["rule"] = tmrpccore_server.NewRPCFunc(QueryGovParams, "height") // todo: will be deprecated
-	tmrpccore.Routes["gov_params"] = tmrpccore_server.NewRPCFunc(QueryGovParams, "height")
+	tmrpccore.Routes["reward"] = tmrpccore_server.NewRPCFunc(QueryReward, "addr,height,prove")
+	tmrpccore.Routes["slashes"] = tmrpccore_server.NewRPCFunc(QuerySlashes, "addr,height,prove")
+	tmrpccore.Routes["slashes/delegator"] = tmrpccore_server.NewRPCFunc(QueryDelegatorSlashes, "addr,height")
+	tmrpccore.Routes["proposals"] = tmrpccore_server.NewRPCFunc(QueryProposal, "txhash,height,prove") // todo: will be deprecated
+	tmrpccore.Routes["proposal"] = tmrpccore_server.NewRPCFunc(QueryProposal, "txhash,height,prove")
+	tmrpccore.Routes["rule"] = tmrpccore_server.NewRPCFunc(QueryGovParams, "height,prove") // todo: will be deprecated
+	tmrpccore.Routes["gov_params"] = tmrpccore_server.NewRPCFunc(QueryGovParams, "height,prove")
+	tmrpccore.Routes["fee_distribution"] = tmrpccore_server.NewRPCFunc(QueryFeeDistribution, "height")
+	tmrpccore.Routes["community_pool"] = tmrpccore_server.NewRPCFunc(QueryCommunityPool, "height")
+	tmrpccore.Routes["supply"] = tmrpccore_server.NewRPCFunc(QuerySupply, "height")
+	tmrpccore.Routes["base_fee"] = tmrpccore_server.NewRPCFunc(QueryBaseFee, "")
 	tmrpccore.Routes["vm_call"] = tmrpccore_server.NewRPCFunc(QueryVM, "addr,to,height,data")
 	tmrpccore.Routes["subscribe"] = tmrpccore_server.NewRPCFunc(Subscribe, "query")
 	tmrpccore.Routes["unsubscribe"] = tmrpccore_server.NewRPCFunc(Unsubscribe, "query")
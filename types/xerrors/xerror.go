@@ -32,6 +32,7 @@ const (
 	ErrCodeInvalidQueryPath
 	ErrCodeInvalidQueryParams
 	ErrCodeNotFoundResult
+	ErrCodePrunedHeight
 	ErrLast
 )
 
@@ -60,6 +61,7 @@ var (
 	ErrInvalidTrxPayloadType   = ErrInvalidTrx.Wrap(errors.New("wrong transaction payload type"))
 	ErrInvalidTrxPayloadParams = ErrInvalidTrx.Wrap(errors.New("invalid params of transaction payload"))
 	ErrInvalidTrxSig           = ErrInvalidTrx.Wrap(errors.New("invalid signature"))
+	ErrInvalidTrxTime          = ErrInvalidTrx.Wrap(errors.New("invalid transaction time"))
 	ErrNotFoundTx              = New(ErrCodeNotFoundTx, "not found tx", nil)
 	ErrNotFoundDelegatee       = New(ErrCodeNotFoundDelegatee, "not found delegatee", nil)
 	ErrNotFoundStake           = New(ErrCodeNotFoundStake, "not found stake", nil)
@@ -72,6 +74,7 @@ var (
 	ErrInvalidQueryParams = New(ErrCodeInvalidQueryParams, "invalid query parameters", nil)
 
 	ErrNotFoundResult = New(ErrCodeNotFoundResult, "not found result", nil)
+	ErrPrunedHeight   = New(ErrCodePrunedHeight, "pruned height", nil)
 
 	// new style errors
 	ErrUnknownTrxType        = NewOrdinary("unknown transaction type")
@@ -79,6 +82,10 @@ var (
 	ErrNoRight               = NewOrdinary("no right")
 	ErrNotVotingPeriod       = NewOrdinary("not voting period")
 	ErrDuplicatedKey         = NewOrdinary("already existed key")
+	ErrSnapshot              = NewOrdinary("snapshot error")
+	ErrRollback              = NewOrdinary("rollback error")
+	ErrUpgrade               = NewOrdinary("upgrade error")
+	ErrExport                = NewOrdinary("export error")
 )
 
 type XError interface {